@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -234,4 +236,94 @@ func (c *Client) DownloadFile(url string) ([]byte, error) {
 	}
 
 	return data, nil
-}
\ No newline at end of file
+}
+
+// UploadFile executes a GraphQL mutation with a local file attached to the variable at
+// fileVariable (a dot path such as "input.file"), following the GraphQL multipart request spec
+func (c *Client) UploadFile(query string, variables map[string]interface{}, fileVariable, filePath string) (map[string]interface{}, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	// The file variable is left null in the operations; the map points the file part at it
+	operations, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %w", err)
+	}
+	fileMap, err := json.Marshal(map[string][]string{"0": {"variables." + fileVariable}})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling file map: %w", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("operations", string(operations)); err != nil {
+		return nil, fmt.Errorf("error writing request: %w", err)
+	}
+	if err := writer.WriteField("map", string(fileMap)); err != nil {
+		return nil, fmt.Errorf("error writing request: %w", err)
+	}
+	part, err := writer.CreateFormFile("0", filepath.Base(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("error writing request: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("error writing request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.config.APIUrl, &body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Apollo-Require-Preflight", "true")
+	req.Header.Set("X-Bloo-Token-ID", c.config.ClientID)
+	req.Header.Set("X-Bloo-Token-Secret", c.config.AuthToken)
+	req.Header.Set("X-Bloo-Company-ID", c.config.CompanyID)
+
+	// Include project context header if project context is set
+	if c.projectID != "" {
+		req.Header.Set("X-Bloo-Project-Id", c.projectID)
+	} else if c.projectSlug != "" {
+		req.Header.Set("X-Bloo-Project-Id", c.projectSlug)
+	}
+
+	// Uploads can take much longer than a regular query
+	uploadClient := &http.Client{Timeout: 10 * time.Minute}
+	resp, err := uploadClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	var response struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []GraphQLError         `json:"errors"`
+	}
+
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+
+	if response.Data == nil {
+		return nil, fmt.Errorf("no data in response")
+	}
+
+	return response.Data, nil
+}
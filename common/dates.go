@@ -0,0 +1,387 @@
+package common
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateFormats lists the absolute date layouts accepted by ParseDate
+var DateFormats = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// DateExamples is a short help text for flags that accept date expressions
+const DateExamples = "ISO-8601 (2025-03-01, 2025-03-01T17:00:00Z) or relative (today, tomorrow, +3d, -1w, +2mo, +30min, next friday, end of month, in 2 weeks 17:00)"
+
+var (
+	relativeOffsetPattern = regexp.MustCompile(`^([+-])\s*(\d+)\s*([a-z]+)$`)
+	inOffsetPattern       = regexp.MustCompile(`^in\s+(\d+|an?)\s+([a-z]+)$`)
+	agoOffsetPattern      = regexp.MustCompile(`^(\d+|an?)\s+([a-z]+)\s+ago$`)
+	weekdayPattern        = regexp.MustCompile(`^(?:(next|this|last|on)\s+)?([a-z]+)$`)
+	boundaryPattern       = regexp.MustCompile(`^(start|beginning|end)\s+of\s+(?:the\s+)?(?:(this|next|last)\s+)?(day|week|month|quarter|year)$`)
+	clockPattern          = regexp.MustCompile(`(?:^|\s+)(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	daysPattern           = regexp.MustCompile(`^\+?\s*(\d+)\s*([a-z]*)$`)
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseWeekday parses a weekday name or abbreviation such as "mon" or "Tuesday"
+func ParseWeekday(name string) (time.Weekday, bool) {
+	weekday, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+	return weekday, ok
+}
+
+// LoadTimezone returns the location for an IANA timezone name, falling back to local time
+func LoadTimezone(timezone string) *time.Location {
+	if timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// ParseDate parses an ISO-8601 or relative date expression in the given timezone
+func ParseDate(expr string, timezone string) (time.Time, error) {
+	t, _, err := ParseDateAt(expr, time.Now().In(LoadTimezone(timezone)))
+	return t, err
+}
+
+// ParseDateFlag parses a date flag value and formats it for the API (empty stays empty)
+func ParseDateFlag(expr string, timezone string) (string, error) {
+	if strings.TrimSpace(expr) == "" {
+		return "", nil
+	}
+	t, err := ParseDate(expr, timezone)
+	if err != nil {
+		return "", err
+	}
+	return FormatAPIDate(t), nil
+}
+
+// ParseDateRangeEnd parses the upper bound of a date range; dates without a time include the whole day
+func ParseDateRangeEnd(expr string, timezone string) (time.Time, error) {
+	t, hasTime, err := ParseDateAt(expr, time.Now().In(LoadTimezone(timezone)))
+	if err != nil {
+		return t, err
+	}
+	if !hasTime {
+		t = t.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return t, nil
+}
+
+// FormatAPIDate formats a time the way the API expects DateTime values
+func FormatAPIDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ParseDateAt parses a date expression relative to now (whose location is used for
+// local dates). The returned bool reports whether the expression set a time of day.
+func ParseDateAt(expr string, now time.Time) (time.Time, bool, error) {
+	original := strings.TrimSpace(expr)
+	if original == "" {
+		return time.Time{}, false, fmt.Errorf("empty date")
+	}
+	loc := now.Location()
+
+	// Absolute dates first
+	if t, err := time.Parse(time.RFC3339, original); err == nil {
+		return t.In(loc), true, nil
+	}
+	for _, layout := range DateFormats {
+		if t, err := time.ParseInLocation(layout, original, loc); err == nil {
+			return t, layout != "2006-01-02", nil
+		}
+	}
+
+	s := strings.Join(strings.Fields(strings.ToLower(original)), " ")
+
+	// Split off a trailing time of day such as "17:00", "5pm" or "at 9:30am"
+	hour, minute := -1, 0
+	if m := clockPattern.FindStringSubmatch(s); m != nil && (m[2] != "" || m[3] != "") {
+		h, _ := strconv.Atoi(m[1])
+		if m[2] != "" {
+			minute, _ = strconv.Atoi(m[2])
+		}
+		if m[3] != "" && (h == 0 || h > 12) {
+			return time.Time{}, false, fmt.Errorf("invalid time of day in '%s'", original)
+		}
+		switch m[3] {
+		case "am":
+			if h == 12 {
+				h = 0
+			}
+		case "pm":
+			if h < 12 {
+				h += 12
+			}
+		}
+		if h > 23 || minute > 59 {
+			return time.Time{}, false, fmt.Errorf("invalid time of day in '%s'", original)
+		}
+		hour = h
+		s = strings.TrimSpace(s[:len(s)-len(m[0])])
+		// The date part may itself be absolute, e.g. "2025-03-01 5pm"
+		if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+			return withClock(t, hour, minute), true, nil
+		}
+	}
+
+	t, hasTime, err := parseRelativeDate(s, now)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date '%s': %v (expected %s)", original, err, DateExamples)
+	}
+
+	if hour >= 0 {
+		return withClock(t, hour, minute), true, nil
+	}
+	return t, hasTime, nil
+}
+
+// parseRelativeDate handles the relative part of a date expression (without a clock time)
+func parseRelativeDate(s string, now time.Time) (time.Time, bool, error) {
+	today := startOfDay(now)
+
+	switch s {
+	case "", "today":
+		return today, false, nil
+	case "now":
+		return now, true, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), false, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), false, nil
+	case "next week":
+		return StartOfWeek(today, time.Monday).AddDate(0, 0, 7), false, nil
+	case "last week":
+		return StartOfWeek(today, time.Monday).AddDate(0, 0, -7), false, nil
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), false, nil
+	case "last month":
+		return time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, today.Location()), false, nil
+	case "next year":
+		return time.Date(today.Year()+1, 1, 1, 0, 0, 0, 0, today.Location()), false, nil
+	case "last year":
+		return time.Date(today.Year()-1, 1, 1, 0, 0, 0, 0, today.Location()), false, nil
+	}
+
+	if m := relativeOffsetPattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		return addDateUnits(now, today, n, m[3])
+	}
+
+	if m := inOffsetPattern.FindStringSubmatch(s); m != nil {
+		return addDateUnits(now, today, parseCount(m[1]), m[2])
+	}
+
+	if m := agoOffsetPattern.FindStringSubmatch(s); m != nil {
+		return addDateUnits(now, today, -parseCount(m[1]), m[2])
+	}
+
+	if m := boundaryPattern.FindStringSubmatch(s); m != nil {
+		return dateBoundary(today, m[1] == "end", m[2], m[3]), m[1] == "end", nil
+	}
+
+	if m := weekdayPattern.FindStringSubmatch(s); m != nil {
+		weekday, ok := ParseWeekday(m[2])
+		if !ok {
+			return time.Time{}, false, fmt.Errorf("unrecognised expression")
+		}
+		diff := (int(weekday) - int(today.Weekday()) + 7) % 7
+		switch m[1] {
+		case "next":
+			// "next friday" is always after today
+			if diff == 0 {
+				diff = 7
+			}
+		case "last":
+			diff -= 7
+		}
+		return today.AddDate(0, 0, diff), false, nil
+	}
+
+	return time.Time{}, false, fmt.Errorf("unrecognised expression")
+}
+
+// addDateUnits offsets a date by n units (hours and minutes are relative to now)
+func addDateUnits(now, today time.Time, n int, unit string) (time.Time, bool, error) {
+	switch strings.TrimSuffix(unit, "s") {
+	case "m":
+		return time.Time{}, false, fmt.Errorf("unit 'm' is ambiguous; use 'min' for minutes or 'mo' for months")
+	case "min", "minute":
+		return now.Add(time.Duration(n) * time.Minute), true, nil
+	case "h", "hr", "hour":
+		return now.Add(time.Duration(n) * time.Hour), true, nil
+	case "d", "day":
+		return today.AddDate(0, 0, n), false, nil
+	case "w", "wk", "week":
+		return today.AddDate(0, 0, 7*n), false, nil
+	case "mo", "mon", "month":
+		return addMonths(today, n), false, nil
+	case "q", "quarter":
+		return addMonths(today, 3*n), false, nil
+	case "y", "yr", "year":
+		return addMonths(today, 12*n), false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("unknown unit '%s'", unit)
+}
+
+// dateBoundary returns the start or end of the day/week/month/quarter/year containing today
+func dateBoundary(today time.Time, end bool, which, period string) time.Time {
+	loc := today.Location()
+	var start time.Time
+	var next time.Time
+
+	switch period {
+	case "day":
+		start = today
+		next = start.AddDate(0, 0, 1)
+	case "week":
+		start = StartOfWeek(today, time.Monday)
+		next = start.AddDate(0, 0, 7)
+	case "month":
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+		next = start.AddDate(0, 1, 0)
+	case "quarter":
+		firstMonth := ((int(today.Month())-1)/3)*3 + 1
+		start = time.Date(today.Year(), time.Month(firstMonth), 1, 0, 0, 0, 0, loc)
+		next = start.AddDate(0, 3, 0)
+	default:
+		start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, loc)
+		next = start.AddDate(1, 0, 0)
+	}
+
+	// Shift by one period for "next"/"last"
+	switch which {
+	case "next":
+		start, next = next, shiftPeriod(next, period, 1)
+	case "last":
+		start, next = shiftPeriod(start, period, -1), start
+	}
+
+	if end {
+		return next.Add(-time.Second)
+	}
+	return start
+}
+
+// shiftPeriod moves a period start forward or back by n periods
+func shiftPeriod(t time.Time, period string, n int) time.Time {
+	switch period {
+	case "day":
+		return t.AddDate(0, 0, n)
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	case "quarter":
+		return t.AddDate(0, 3*n, 0)
+	}
+	return t.AddDate(n, 0, 0)
+}
+
+// addMonths adds months, clamping to the last day of the target month
+func addMonths(t time.Time, months int) time.Time {
+	firstOfTarget := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), day, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek returns midnight of the first day of the week containing t,
+// for weeks starting on firstDay (Monday for date expressions, Sunday for recurrences)
+func StartOfWeek(t time.Time, firstDay time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(firstDay) + 7) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+func withClock(t time.Time, hour, minute int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+}
+
+func parseCount(s string) int {
+	if s == "a" || s == "an" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// ParseDays parses a day count such as "3", "+3d", "2w" or "1 month" into a number of days
+func ParseDays(expr string) (int, error) {
+	s := strings.ToLower(strings.TrimSpace(expr))
+	if s == "" {
+		return 0, fmt.Errorf("empty day count")
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+
+	m := daysPattern.FindStringSubmatch(strings.TrimPrefix(s, "in "))
+	if m == nil {
+		return 0, fmt.Errorf("invalid day count '%s' (expected e.g. 3, 3d, 2w, 1mo)", expr)
+	}
+	n, _ := strconv.Atoi(m[1])
+	switch strings.TrimSuffix(m[2], "s") {
+	case "", "d", "day":
+		return n, nil
+	case "w", "wk", "week":
+		return 7 * n, nil
+	case "mo", "month":
+		return 30 * n, nil
+	case "m":
+		return 0, fmt.Errorf("unit 'm' in '%s' is ambiguous; use 'mo' for months", expr)
+	}
+	return 0, fmt.Errorf("invalid day count '%s' (expected e.g. 3, 3d, 2w, 1mo)", expr)
+}
+
+// daysValue is a flag.Value that accepts plain integers or day expressions
+type daysValue int
+
+func (d *daysValue) String() string { return strconv.Itoa(int(*d)) }
+
+func (d *daysValue) Set(s string) error {
+	n, err := ParseDays(s)
+	if err != nil {
+		return err
+	}
+	*d = daysValue(n)
+	return nil
+}
+
+// DaysFlag defines an int flag that also accepts day expressions like "3d" or "2w"
+func DaysFlag(fs *flag.FlagSet, name string, value int, usage string) *int {
+	p := new(int)
+	*p = value
+	fs.Var((*daysValue)(p), name, usage)
+	return p
+}
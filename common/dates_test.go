@@ -0,0 +1,110 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateAt(t *testing.T) {
+	loc := time.UTC
+	// Wednesday
+	now := time.Date(2025, 3, 12, 10, 30, 0, 0, loc)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		expr    string
+		want    time.Time
+		hasTime bool
+	}{
+		{"2025-03-01", day(2025, 3, 1), false},
+		{"2025-03-01T17:00:00Z", time.Date(2025, 3, 1, 17, 0, 0, 0, loc), true},
+		{"2025-03-01 17:00", time.Date(2025, 3, 1, 17, 0, 0, 0, loc), true},
+		{"2025-03-01 5pm", time.Date(2025, 3, 1, 17, 0, 0, 0, loc), true},
+		{"today", day(2025, 3, 12), false},
+		{"now", now, true},
+		{"tomorrow", day(2025, 3, 13), false},
+		{"yesterday", day(2025, 3, 11), false},
+		{"tomorrow 9:30am", time.Date(2025, 3, 13, 9, 30, 0, 0, loc), true},
+		{"+3d", day(2025, 3, 15), false},
+		{"-1w", day(2025, 3, 5), false},
+		{"+2mo", day(2025, 5, 12), false},
+		{"+1y", day(2026, 3, 12), false},
+		{"+30min", now.Add(30 * time.Minute), true},
+		{"+2h", now.Add(2 * time.Hour), true},
+		{"in 2 weeks", day(2025, 3, 26), false},
+		{"in a month", day(2025, 4, 12), false},
+		{"3 days ago", day(2025, 3, 9), false},
+		{"friday", day(2025, 3, 14), false},
+		{"next wednesday", day(2025, 3, 19), false},
+		{"last monday", day(2025, 3, 10), false},
+		{"next week", day(2025, 3, 17), false},
+		{"last month", day(2025, 2, 1), false},
+		{"start of week", day(2025, 3, 10), false},
+		{"end of month", time.Date(2025, 3, 31, 23, 59, 59, 0, loc), true},
+		{"end of next quarter", time.Date(2025, 6, 30, 23, 59, 59, 0, loc), true},
+		{"start of last year", day(2024, 1, 1), false},
+		{"in 2 weeks 17:00", time.Date(2025, 3, 26, 17, 0, 0, 0, loc), true},
+	}
+	for _, tt := range tests {
+		got, hasTime, err := ParseDateAt(tt.expr, now)
+		if err != nil {
+			t.Errorf("ParseDateAt(%q): unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if !got.Equal(tt.want) || hasTime != tt.hasTime {
+			t.Errorf("ParseDateAt(%q) = %v, %v; want %v, %v", tt.expr, got, hasTime, tt.want, tt.hasTime)
+		}
+	}
+}
+
+func TestParseDateAtErrors(t *testing.T) {
+	now := time.Date(2025, 3, 12, 10, 30, 0, 0, time.UTC)
+	for _, expr := range []string{"", "+30m", "someday", "+3 fortnights", "13pm", "next blursday"} {
+		if got, _, err := ParseDateAt(expr, now); err == nil {
+			t.Errorf("ParseDateAt(%q) = %v; want an error", expr, got)
+		}
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"3", 3},
+		{"-1", -1},
+		{"3d", 3},
+		{"+3d", 3},
+		{"2w", 14},
+		{"1 month", 30},
+		{"2mo", 60},
+		{"in 5 days", 5},
+	}
+	for _, tt := range tests {
+		got, err := ParseDays(tt.expr)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDays(%q) = %d, %v; want %d", tt.expr, got, err, tt.want)
+		}
+	}
+	for _, expr := range []string{"", "1m", "3 fortnights", "soon"} {
+		if _, err := ParseDays(expr); err == nil {
+			t.Errorf("ParseDays(%q): want an error", expr)
+		}
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	wednesday := time.Date(2025, 3, 12, 10, 30, 0, 0, time.UTC)
+	if got := StartOfWeek(wednesday, time.Monday); !got.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartOfWeek(Monday) = %v", got)
+	}
+	if got := StartOfWeek(wednesday, time.Sunday); !got.Equal(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartOfWeek(Sunday) = %v", got)
+	}
+	sunday := time.Date(2025, 3, 16, 8, 0, 0, 0, time.UTC)
+	if got := StartOfWeek(sunday, time.Monday); !got.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartOfWeek(Sunday, Monday) = %v", got)
+	}
+}
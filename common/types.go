@@ -175,12 +175,13 @@ type CustomField struct {
 
 // CustomFieldOption represents an option for select-type custom fields
 type CustomFieldOption struct {
-	ID                   string `json:"id"`
-	Title                string `json:"title"`
-	ButtonType           string `json:"buttonType,omitempty"`
-	ButtonConfirmText    string `json:"buttonConfirmText,omitempty"`
-	Color                string `json:"color,omitempty"`
-	CurrencyConversionTo string `json:"currencyConversionTo,omitempty"`
+	ID                     string `json:"id"`
+	Title                  string `json:"title"`
+	ButtonType             string `json:"buttonType,omitempty"`
+	ButtonConfirmText      string `json:"buttonConfirmText,omitempty"`
+	Color                  string `json:"color,omitempty"`
+	CurrencyConversionFrom string `json:"currencyConversionFrom,omitempty"`
+	CurrencyConversionTo   string `json:"currencyConversionTo,omitempty"`
 }
 
 // CustomFieldValue represents a value assigned to a custom field
@@ -266,8 +267,10 @@ type CreateCustomFieldInput struct {
 
 // CustomFieldOptionInput for select field options
 type CustomFieldOptionInput struct {
-	Title string `json:"title"`
-	Color string `json:"color,omitempty"`
+	Title                  string `json:"title"`
+	Color                  string `json:"color,omitempty"`
+	CurrencyConversionFrom string `json:"currencyConversionFrom,omitempty"`
+	CurrencyConversionTo   string `json:"currencyConversionTo,omitempty"`
 }
 
 // ConversionRateFieldInput for currency conversion from fields
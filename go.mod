@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/joho/godotenv v1.5.1
 	github.com/manifoldco/promptui v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -19,6 +19,8 @@ func printUsage() {
 	fmt.Println("  read-projects               List all projects")
 	fmt.Println("  read-lists                  List todo lists in a project")
 	fmt.Println("  read-record                 Get detailed record information")
+	fmt.Println("  read-references             List records a record references and records referencing it")
+	fmt.Println("  read-record-history         Show a record's activity log or its state at a past time")
 	fmt.Println("  read-records                Query records with advanced filtering and statistics")
 	fmt.Println("  read-list-records           List records in a specific list")
 	fmt.Println("  read-project-records        List all records in a project by list")
@@ -28,15 +30,22 @@ func printUsage() {
 	fmt.Println("  read-project-custom-fields  List custom fields in a project")
 	fmt.Println("  read-custom-fields          Enhanced custom fields reference for record operations")
 	fmt.Println("  read-field-groups           View custom field groups/folders organization")
+	fmt.Println("  custom-field-usage          Report custom field fill rates and unused fields/options")
 	fmt.Println("  read-automations            List automations in a project")
 	fmt.Println("  read-user-profiles          List user profiles in a company")
 	fmt.Println("  read-project-user-roles     List custom user roles in projects")
 	fmt.Println("  read-checklists             List checklists from a record")
+	fmt.Println("  read-recurrence             Show a record's repeat schedule and next occurrences")
 	fmt.Println("  download-files              Download files from a project and create zip archive")
+	fmt.Println("  download-field-files        Download the files in FILE custom fields, organised by record")
+	fmt.Println("  attach-field-file           Upload files and attach them to a record's FILE custom field")
 	fmt.Println()
 	fmt.Println("CREATE operations:")
 	fmt.Println("  create-project              Create a new project")
+	fmt.Println("  copy-project                Copy a project (optionally into several companies)")
 	fmt.Println("  create-list                 Create a new todo list")
+	fmt.Println("  copy-list                   Duplicate a list, optionally with its records")
+	fmt.Println("  copy-custom-fields          Copy custom fields, options and groups to another project")
 	fmt.Println("  create-record               Create a new record/todo")
 	fmt.Println("  create-comment              Create a comment on a record")
 	fmt.Println("  create-checklist            Create a checklist on a record")
@@ -48,27 +57,53 @@ func printUsage() {
 	fmt.Println("  create-automation           Create a new automation")
 	fmt.Println("  create-automation-multi     Create automation with multiple actions")
 	fmt.Println("  invite-user                 Invite a user to the company or project")
+	fmt.Println("  project-users               Add, remove or change access of project users (bulk via CSV), join or leave")
+	fmt.Println("  invitations                 List, resend or cancel pending invitations")
 	fmt.Println()
 	fmt.Println("UPDATE operations:")
 	fmt.Println("  update-project              Update project settings")
+	fmt.Println("  project-features            List or toggle project features (one project or by search)")
+	fmt.Println("  archive-project             Archive a project")
+	fmt.Println("  unarchive-project           Restore an archived project")
+	fmt.Println("  make-template               Convert a project into a template")
+	fmt.Println("  unmake-template             Turn a template back into a regular project")
+	fmt.Println("  reorder-projects            Put projects in a given order")
+	fmt.Println("  move-project-to-folder      Move a project into a folder or to the top level")
+	fmt.Println("  folders                     Manage project folders (tree/create/rename/move/delete)")
 	fmt.Println("  update-record               Update a record/todo")
 	fmt.Println("  update-comment              Update a comment")
 	fmt.Println("  update-custom-field         Update custom field properties")
+	fmt.Println("  migrate-custom-field        Convert a custom field to another type, keeping its values")
+	fmt.Println("  update-custom-field-option  Rename, recolour or move a custom field option")
+	fmt.Println("  reorder-custom-field-options Set the order of a custom field's options")
 	fmt.Println("  update-list                 Update list properties")
-	fmt.Println("  manage-field-groups         Manage custom field groups (create/delete/rename/move)")
+	fmt.Println("  reorder-lists               Put the lists of a project in a given order")
+	fmt.Println("  complete-list               Mark every record in a list as done")
+	fmt.Println("  reopen-list                 Mark every record in a list as not done")
+	fmt.Println("  manage-field-groups         Manage custom field groups (create/delete/rename/move/layout)")
 	fmt.Println("  update-automation           Update an existing automation")
 	fmt.Println("  update-automation-multi     Update automation with multiple actions")
 	fmt.Println("  update-checklist-item       Update a checklist item")
 	fmt.Println("  move-record                 Move a record to a different list/project")
+	fmt.Println("  copy-record                 Copy a record (optionally N times) to a list/project")
+	fmt.Println("  set-recurrence              Make a record repeat on a schedule")
 	fmt.Println()
 	fmt.Println("DELETE operations:")
 	fmt.Println("  delete-project              Delete a project")
 	fmt.Println("  delete-record               Delete a record/todo")
 	fmt.Println("  delete-custom-field         Delete a custom field")
 	fmt.Println("  delete-custom-field-options Delete options from custom fields")
+	fmt.Println("  merge-custom-field-options  Move records to another option and delete the old ones")
 	fmt.Println("  delete-automation           Delete an automation")
 	fmt.Println("  delete-checklist            Delete a checklist")
 	fmt.Println("  delete-checklist-item       Delete a checklist item")
+	fmt.Println("  clear-recurrence            Stop a record from repeating")
+	fmt.Println()
+	fmt.Println("PROJECT SPEC operations:")
+	fmt.Println("  plan                        Show the changes needed to make a project match a spec")
+	fmt.Println("  apply                       Apply a YAML/JSON project spec (safe to re-run)")
+	fmt.Println("  export-project              Export a project's configuration as a YAML/JSON spec")
+	fmt.Println("  diff-projects               Compare the configuration of two projects (drift detection)")
 	fmt.Println()
 	fmt.Println("Testing:")
 	fmt.Println("  e2e                         Run end-to-end tests")
@@ -102,6 +137,10 @@ func main() {
 		err = tools.RunReadLists(args)
 	case "read-record":
 		err = tools.RunReadRecord(args)
+	case "read-references":
+		err = tools.RunReadReferences(args)
+	case "read-record-history":
+		err = tools.RunReadRecordHistory(args)
 	case "read-records":
 		err = tools.RunReadRecords(args)
 	case "read-list-records":
@@ -118,6 +157,8 @@ func main() {
 		err = tools.RunReadCustomFields(args)
 	case "read-field-groups":
 		err = tools.RunReadCustomFieldGroups(args)
+	case "custom-field-usage":
+		err = tools.RunCustomFieldUsage(args)
 	case "read-automations":
 		err = tools.RunReadAutomations(args)
 	case "read-user-profiles":
@@ -126,14 +167,26 @@ func main() {
 		err = tools.RunReadProjectUserRoles(args)
 	case "read-checklists":
 		err = tools.RunReadChecklists(args)
+	case "read-recurrence":
+		err = tools.RunReadRecurrence(args)
 	case "download-files":
 		err = tools.RunDownloadFiles(args)
+	case "download-field-files":
+		err = tools.RunDownloadFieldFiles(args)
+	case "attach-field-file":
+		err = tools.RunAttachFieldFile(args)
 
 	// CREATE operations
 	case "create-project":
 		err = tools.RunCreateProject(args)
+	case "copy-project":
+		err = tools.RunCopyProject(args)
 	case "create-list":
 		err = tools.RunCreateList(args)
+	case "copy-list":
+		err = tools.RunCopyList(args)
+	case "copy-custom-fields":
+		err = tools.RunCopyCustomFields(args)
 	case "create-record":
 		err = tools.RunCreateRecord(args)
 	case "create-comment":
@@ -156,18 +209,50 @@ func main() {
 		err = tools.RunCreateAutomationMulti(args)
 	case "invite-user":
 		err = tools.RunInviteUser(args)
+	case "project-users":
+		err = tools.RunProjectUsers(args)
+	case "invitations":
+		err = tools.RunInvitations(args)
 	
 	// UPDATE operations
 	case "update-project":
 		err = tools.RunUpdateProject(args)
+	case "project-features":
+		err = tools.RunProjectFeatures(args)
+	case "archive-project":
+		err = tools.RunArchiveProject(args)
+	case "unarchive-project":
+		err = tools.RunUnarchiveProject(args)
+	case "make-template":
+		err = tools.RunMakeTemplate(args)
+	case "unmake-template":
+		err = tools.RunUnmakeTemplate(args)
+	case "reorder-projects":
+		err = tools.RunReorderProjects(args)
+	case "move-project-to-folder":
+		err = tools.RunMoveProjectToFolder(args)
+	case "folders":
+		err = tools.RunFolders(args)
 	case "update-record":
 		err = tools.RunUpdateRecord(args)
 	case "update-comment":
 		err = tools.RunUpdateComment(args)
 	case "update-custom-field":
 		err = tools.RunUpdateCustomField(args)
+	case "migrate-custom-field":
+		err = tools.RunMigrateCustomField(args)
+	case "update-custom-field-option":
+		err = tools.RunUpdateCustomFieldOption(args)
+	case "reorder-custom-field-options":
+		err = tools.RunReorderCustomFieldOptions(args)
 	case "update-list":
 		err = tools.RunUpdateList(args)
+	case "reorder-lists":
+		err = tools.RunReorderLists(args)
+	case "complete-list":
+		err = tools.RunCompleteList(args)
+	case "reopen-list":
+		err = tools.RunReopenList(args)
 	case "update-automation":
 		err = tools.RunUpdateAutomation(args)
 	case "update-automation-multi":
@@ -176,6 +261,10 @@ func main() {
 		err = tools.RunUpdateChecklistItem(args)
 	case "move-record":
 		err = tools.RunMoveRecord(args)
+	case "copy-record":
+		err = tools.RunCopyRecord(args)
+	case "set-recurrence":
+		err = tools.RunSetRecurrence(args)
 	case "test-custom-fields":
 		err = tools.RunTestCustomFields(args)
 	case "manage-field-groups":
@@ -190,6 +279,8 @@ func main() {
 		err = tools.RunDeleteCustomField(args)
 	case "delete-custom-field-options":
 		err = tools.RunDeleteCustomFieldOptions(args)
+	case "merge-custom-field-options":
+		err = tools.RunMergeCustomFieldOptions(args)
 	case "delete-list":
 		err = tools.RunDeleteList(args)
 	case "delete-automation":
@@ -198,6 +289,18 @@ func main() {
 		err = tools.RunDeleteChecklist(args)
 	case "delete-checklist-item":
 		err = tools.RunDeleteChecklistItem(args)
+	case "clear-recurrence":
+		err = tools.RunClearRecurrence(args)
+
+	// Project spec operations
+	case "plan":
+		err = tools.RunPlan(args)
+	case "apply":
+		err = tools.RunApply(args)
+	case "export-project":
+		err = tools.RunExportProject(args)
+	case "diff-projects":
+		err = tools.RunDiffProjects(args)
 
 	// Testing
 	case "e2e":
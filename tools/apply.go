@@ -0,0 +1,324 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// ProjectUserRoleInput is the input for creating or updating a custom project role
+type ProjectUserRoleInput struct {
+	RoleID                    string `json:"roleId,omitempty"`
+	ProjectID                 string `json:"projectId"`
+	Name                      string `json:"name"`
+	Description               string `json:"description,omitempty"`
+	AllowInviteOthers         *bool  `json:"allowInviteOthers,omitempty"`
+	AllowMarkRecordsAsDone    *bool  `json:"allowMarkRecordsAsDone,omitempty"`
+	ShowOnlyAssignedTodos     *bool  `json:"showOnlyAssignedTodos,omitempty"`
+	ShowOnlyMentionedComments *bool  `json:"showOnlyMentionedComments,omitempty"`
+	CanDeleteRecords          *bool  `json:"canDeleteRecords,omitempty"`
+	IsActivityEnabled         *bool  `json:"isActivityEnabled,omitempty"`
+	IsChatEnabled             *bool  `json:"isChatEnabled,omitempty"`
+	IsDocsEnabled             *bool  `json:"isDocsEnabled,omitempty"`
+	IsFormsEnabled            *bool  `json:"isFormsEnabled,omitempty"`
+	IsWikiEnabled             *bool  `json:"isWikiEnabled,omitempty"`
+	IsFilesEnabled            *bool  `json:"isFilesEnabled,omitempty"`
+	IsRecordsEnabled          *bool  `json:"isRecordsEnabled,omitempty"`
+	IsPeopleEnabled           *bool  `json:"isPeopleEnabled,omitempty"`
+}
+
+// Execute GraphQL mutation to create a custom project role
+func executeCreateProjectUserRole(client *common.Client, input ProjectUserRoleInput) error {
+	mutation := `
+		mutation CreateProjectUserRole($input: CreateProjectUserRoleInput!) {
+			createProjectUserRole(input: $input) {
+				id
+				name
+			}
+		}
+	`
+
+	input.RoleID = ""
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response map[string]interface{}
+	return client.ExecuteQueryWithResult(mutation, variables, &response)
+}
+
+// Execute GraphQL mutation to update a custom project role
+func executeUpdateProjectUserRole(client *common.Client, input ProjectUserRoleInput) error {
+	mutation := `
+		mutation UpdateProjectUserRole($input: UpdateProjectUserRoleInput!) {
+			updateProjectUserRole(input: $input) {
+				id
+				name
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response map[string]interface{}
+	return client.ExecuteQueryWithResult(mutation, variables, &response)
+}
+
+// Execute GraphQL mutation to delete a custom project role
+func executeDeleteProjectUserRole(client *common.Client, projectID, roleID string) error {
+	mutation := `
+		mutation DeleteProjectUserRole($input: DeleteProjectUserRoleInput!) {
+			deleteProjectUserRole(input: $input)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"roleId":    roleID,
+			"projectId": projectID,
+		},
+	}
+
+	var response map[string]interface{}
+	return client.ExecuteQueryWithResult(mutation, variables, &response)
+}
+
+// Execute GraphQL mutation to rename or recolour a tag
+func executeEditTag(client *common.Client, tagID, title, color string) error {
+	mutation := `
+		mutation EditTag($input: EditTagInput!) {
+			editTag(input: $input) {
+				id
+				title
+				color
+			}
+		}
+	`
+
+	input := map[string]interface{}{"id": tagID}
+	if title != "" {
+		input["title"] = title
+	}
+	if color != "" {
+		input["color"] = color
+	}
+
+	var response map[string]interface{}
+	return client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response)
+}
+
+// Execute GraphQL mutation to delete a tag
+func executeDeleteTag(client *common.Client, tagID string) error {
+	mutation := `
+		mutation DeleteTag($id: String!) {
+			deleteTag(id: $id)
+		}
+	`
+
+	var response map[string]interface{}
+	return client.ExecuteQueryWithResult(mutation, map[string]interface{}{"id": tagID}, &response)
+}
+
+// titledRecord is a record's ID and title
+type titledRecord struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// fetchListRecordTitles returns the normalised titles of the records in a list
+func fetchListRecordTitles(client *common.Client, listID string) (map[string]bool, error) {
+	todos, err := fetchListTodos[titledRecord](client, listID, "id title")
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string]bool)
+	for _, todo := range todos {
+		titles[specKey(todo.Title)] = true
+	}
+	return titles, nil
+}
+
+// createSpecRecord creates a seed record, removing it again if a later step fails
+func createSpecRecord(ctx *applyContext, record RecordSpec) error {
+	listID, err := ctx.listID(record.List)
+	if err != nil {
+		return err
+	}
+
+	input := CreateRecordInput{
+		TodoListID:  listID,
+		Title:       record.Title,
+		Description: record.Description,
+		Placement:   "BOTTOM",
+	}
+	if input.StartedAt, err = common.ParseDateFlag(record.Start, ""); err != nil {
+		return fmt.Errorf("invalid start date: %v", err)
+	}
+	if input.DuedAt, err = common.ParseDateFlag(record.Due, ""); err != nil {
+		return fmt.Errorf("invalid due date: %v", err)
+	}
+	tagIDs, err := ctx.tagIDs(record.Tags)
+	if err != nil {
+		return err
+	}
+	for _, id := range tagIDs {
+		input.Tags = append(input.Tags, CreateRecordTagInput{ID: id})
+	}
+	var checklistTitles []string
+	var checklistItems [][]string
+	for _, checklist := range record.Checklists {
+		checklistTitles = append(checklistTitles, checklist.Title)
+		checklistItems = append(checklistItems, checklist.Items)
+	}
+	input.Checklists = recordChecklistInputs(checklistTitles)
+
+	var values []common.CustomFieldValue
+	for name, value := range record.Fields {
+		field, err := ctx.field(name)
+		if err != nil {
+			return err
+		}
+		encoded, err := encodeCustomFieldValue(field, value)
+		if err != nil {
+			return err
+		}
+		values = append(values, common.CustomFieldValue{CustomFieldID: field.ID, Value: encoded})
+	}
+
+	response, err := executeCreateRecord(ctx.client, input)
+	if err != nil {
+		return err
+	}
+	recordID := response.CreateTodo.ID
+
+	rollback := func(step string, stepErr error) error {
+		if delErr := executeDeleteRecord(ctx.client, recordID); delErr != nil {
+			return fmt.Errorf("failed to %s: %v (rollback failed, record %s was left in place: %v)", step, stepErr, recordID, delErr)
+		}
+		return fmt.Errorf("failed to %s: %v (record creation rolled back)", step, stepErr)
+	}
+
+	if record.Color != "" {
+		if _, err := executeEditTodo(ctx.client, UpdateRecordInput{TodoID: recordID, Color: record.Color}); err != nil {
+			return rollback("set color", err)
+		}
+	}
+	if err := executeSetCustomFields(ctx.client, recordID, values); err != nil {
+		return rollback("set custom fields", err)
+	}
+	if _, err := createRecordChecklistItems(ctx.client, response.CreateTodo.Checklists, input.Checklists, checklistItems); err != nil {
+		return rollback("create checklist items", err)
+	}
+	for _, text := range record.Comments {
+		if _, err := executeCreateComment(ctx.client, CreateCommentInput{
+			Text:       text,
+			HTML:       strings.ReplaceAll(text, "\n", "<br>"),
+			Category:   "TODO",
+			CategoryID: recordID,
+		}); err != nil {
+			return rollback("create comment", err)
+		}
+	}
+
+	return nil
+}
+
+// RunApply makes a project match a spec, creating the project if needed
+func RunApply(args []string) error {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	specFile := fs.String("file", "", "Project spec file, YAML or JSON (required)")
+	projectID := fs.String("project", "", "Project ID or slug (default: find the project by the spec's name, or create it)")
+	prune := fs.Bool("prune", false, "Delete items that exist only in the project (requires -confirm)")
+	confirm := fs.Bool("confirm", false, "Confirm deletions when using -prune")
+	dryRun := fs.Bool("dry-run", false, "Show the plan without applying it")
+	validateOnly := fs.Bool("validate-only", false, "Only check the seed records' custom field values and report every problem")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *specFile == "" {
+		fmt.Println("Usage: go run . apply -file SPEC.yaml [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . apply -file demo.yaml")
+		fmt.Println("  go run . apply -file demo.yaml -project my-project -prune -confirm")
+		fmt.Println("  go run . apply -file demo.yaml -validate-only")
+		fmt.Println("\nApplying the same spec again is safe: only the remaining differences are changed.")
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, spec, state, err := loadSpecAndState(*specFile, *projectID)
+	if err != nil {
+		return err
+	}
+
+	if *validateOnly {
+		var problems []string
+		for _, change := range checkSpecRecordValues(spec, state) {
+			problems = append(problems, fmt.Sprintf("record %q: %s", change.name, change.detail))
+		}
+		if len(problems) > 0 {
+			return printValidationProblems(problems, *simple)
+		}
+		if !*simple {
+			fmt.Printf("✅ Custom field values of all %d record(s) are valid\n", len(spec.Records))
+		}
+		return nil
+	}
+
+	changes, err := planProjectSpec(client, spec, state, *prune)
+	if err != nil {
+		return err
+	}
+
+	actionable := printPlan(spec.Project.Name, changes, *simple)
+
+	var deletions int
+	for _, change := range changes {
+		if change.op == "!" {
+			return fmt.Errorf("the plan has conflicts; resolve them before applying")
+		}
+		if change.op == "-" {
+			deletions++
+		}
+	}
+	if *dryRun || actionable == 0 {
+		return nil
+	}
+	if deletions > 0 && !*confirm {
+		fmt.Println("\n⚠️  This plan deletes items. Re-run with -confirm to proceed.")
+		return fmt.Errorf("confirmation required for deletion")
+	}
+
+	if !*simple {
+		fmt.Printf("\n=== Applying %d change(s) ===\n", actionable)
+	}
+
+	ctx := newApplyContext(client, state)
+	applied := 0
+	for _, change := range changes {
+		if change.apply == nil {
+			continue
+		}
+		if err := change.apply(ctx); err != nil {
+			fmt.Printf("❌ %s %s %q: %v\n", change.op, change.kind, change.name, err)
+			return fmt.Errorf("apply stopped after %d of %d change(s); re-run apply to continue", applied, actionable)
+		}
+		applied++
+		if !*simple {
+			fmt.Printf("✅ %s %s %q\n", change.op, change.kind, change.name)
+		}
+	}
+
+	if *simple {
+		fmt.Printf("Applied %d change(s) to %s\n", applied, ctx.projectID)
+	} else {
+		fmt.Printf("\n✅ Applied %d change(s). Project ID: %s\n", applied, ctx.projectID)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+
+	"demo-builder/common"
+)
+
+// executeSetProjectArchived archives or unarchives a project
+func executeSetProjectArchived(client *common.Client, projectID string, archived bool) error {
+	mutation := `
+		mutation ArchiveProject($id: String!) {
+			archiveProject(id: $id)
+		}
+	`
+	field := "archiveProject"
+	if !archived {
+		mutation = `
+			mutation UnarchiveProject($id: String!) {
+				unarchiveProject(id: $id)
+			}
+		`
+		field = "unarchiveProject"
+	}
+
+	var response map[string]bool
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"id": projectID}, &response); err != nil {
+		return err
+	}
+	if !response[field] {
+		return fmt.Errorf("the API reported that the project was not changed")
+	}
+	return nil
+}
+
+// runSetProjectArchived implements archive-project and unarchive-project
+func runSetProjectArchived(command string, archived bool, args []string) error {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" {
+		fmt.Printf("Usage: go run . %s -project PROJECT [flags]\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Printf("  go run . %s -project my-project\n", command)
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	project, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+
+	action := "archive"
+	if !archived {
+		action = "unarchive"
+	}
+	if err := executeSetProjectArchived(client, project.ID, archived); err != nil {
+		return fmt.Errorf("failed to %s project: %v", action, err)
+	}
+
+	if *simple {
+		fmt.Println(project.ID)
+	} else {
+		fmt.Printf("✅ Project '%s' %sd\n", project.Name, action)
+	}
+	return nil
+}
+
+// RunArchiveProject archives a project
+func RunArchiveProject(args []string) error {
+	return runSetProjectArchived("archive-project", true, args)
+}
+
+// RunUnarchiveProject restores an archived project
+func RunUnarchiveProject(args []string) error {
+	return runSetProjectArchived("unarchive-project", false, args)
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// RunAttachFieldFile uploads local files and attaches them to a record's FILE custom field
+func RunAttachFieldFile(args []string) error {
+	fs := flag.NewFlagSet("attach-field-file", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID (required)")
+	fieldRef := fs.String("field", "", "FILE custom field ID or name (required)")
+	projectID := fs.String("project", "", "Project ID (optional, looked up from the record)")
+	replace := fs.Bool("replace", false, "Remove the files already in the field once the new ones are attached")
+	remove := fs.String("remove", "", "Comma-separated file names or UIDs to remove from the field")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	paths := fs.Args()
+	if *recordID == "" || *fieldRef == "" || (len(paths) == 0 && *remove == "" && !*replace) {
+		fmt.Println("Usage: go run . attach-field-file -record RECORD_ID -field FIELD [flags] path...")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Attach a signed contract to a record")
+		fmt.Println("  go run . attach-field-file -record RECORD_ID -field \"Contract\" ./contract.pdf")
+		fmt.Println("")
+		fmt.Println("  # Replace the photos of a record")
+		fmt.Println("  go run . attach-field-file -record RECORD_ID -field \"Photos\" -replace front.jpg back.jpg")
+		fmt.Println("")
+		fmt.Println("  # Remove one file from the field")
+		fmt.Println("  go run . attach-field-file -record RECORD_ID -field \"Photos\" -remove \"back.jpg\"")
+		return fmt.Errorf("required flags missing")
+	}
+	if *replace && *remove != "" {
+		return fmt.Errorf("use either -replace or -remove, not both")
+	}
+
+	// Check every file before uploading anything
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("cannot read '%s': %v", path, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("'%s' is a directory", path)
+		}
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+
+	if *projectID == "" {
+		if *projectID, err = getProjectIDFromRecord(client, *recordID); err != nil {
+			return err
+		}
+	}
+	client.SetProject(*projectID)
+
+	fields, err := fetchProjectCustomFields(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	field := findCustomField(fields, *fieldRef)
+	if field == nil {
+		return fmt.Errorf("custom field '%s' not found in project", *fieldRef)
+	}
+	if field.Type != "FILE" {
+		return fmt.Errorf("'%s' is a %s field, not a FILE field", field.Name, field.Type)
+	}
+
+	record, err := fetchFileFieldRecord(client, *recordID)
+	if err != nil {
+		return err
+	}
+	var current []common.File
+	if recordField := record.findRecordFileField(field.ID); recordField != nil {
+		current = recordField.Files
+	}
+
+	// Work out which attached files go away
+	var detach []common.File
+	if *replace {
+		detach = current
+	} else if *remove != "" {
+		for _, ref := range strings.Split(*remove, ",") {
+			if strings.TrimSpace(ref) == "" {
+				continue
+			}
+			file := findAttachedFile(current, ref)
+			if file == nil {
+				return fmt.Errorf("no file '%s' in '%s' of this record", strings.TrimSpace(ref), field.Name)
+			}
+			detach = append(detach, *file)
+		}
+	}
+
+	if !*simple {
+		fmt.Printf("=== %s → %s ===\n", record.Title, field.Name)
+	}
+
+	var removed, attached, failed int
+	for _, path := range paths {
+		file, err := uploadProjectFile(client, *projectID, path)
+		if err != nil {
+			failed++
+			fmt.Printf("❌ Could not upload %s: %v\n", path, err)
+			continue
+		}
+		if err := attachFieldFile(client, record.ID, field.ID, file.UID); err != nil {
+			failed++
+			fmt.Printf("❌ Uploaded %s but could not attach it: %v\n", path, err)
+			continue
+		}
+		attached++
+		if !*simple {
+			fmt.Printf("✅ Attached %s (%d bytes) [%s]\n", fileNameWithExtension(*file), file.Size, file.UID)
+		}
+	}
+
+	// Old files only go once every new file is in place, so a failed upload never empties the field
+	if failed > 0 && len(detach) > 0 {
+		fmt.Printf("⚠️  Kept the %d file(s) that were to be removed because not every file was attached\n", len(detach))
+		detach = nil
+	}
+	for _, file := range detach {
+		if err := detachFieldFile(client, record.ID, field.ID, file.UID); err != nil {
+			failed++
+			fmt.Printf("❌ Could not remove %s: %v\n", fileNameWithExtension(file), err)
+			continue
+		}
+		removed++
+		if !*simple {
+			fmt.Printf("➖ Removed %s\n", fileNameWithExtension(file))
+		}
+	}
+
+	if *simple {
+		fmt.Printf("✅ %s %s: %d attached, %d removed, %d failed\n", record.ID, field.ID, attached, removed, failed)
+	} else {
+		fmt.Printf("\n=== Summary ===\n")
+		fmt.Printf("Attached: %d\n", attached)
+		fmt.Printf("Removed: %d\n", removed)
+		if failed > 0 {
+			fmt.Printf("Failed: %d\n", failed)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d file operation(s) failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+
+	"demo-builder/common"
+)
+
+// DeleteRepeatingTodoResponse represents the response from deleteRepeatingTodo
+type DeleteRepeatingTodoResponse struct {
+	DeleteRepeatingTodo bool `json:"deleteRepeatingTodo"`
+}
+
+// RunClearRecurrence stops a record from repeating
+func RunClearRecurrence(args []string) error {
+	fs := flag.NewFlagSet("clear-recurrence", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID (required)")
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *recordID == "" || *projectID == "" {
+		fmt.Println("Error: -record and -project flags are required")
+		fmt.Println("\nUsage:")
+		fmt.Println("  go run . clear-recurrence -record RECORD_ID -project PROJECT_ID")
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	record, err := fetchRecurrenceRecord(client, *recordID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record: %v", err)
+	}
+
+	if !record.IsRepeating {
+		fmt.Printf("Record '%s' is not repeating - nothing to clear.\n", record.Title)
+		return nil
+	}
+
+	mutation := `
+		mutation DeleteRepeatingTodo($id: String!) {
+			deleteRepeatingTodo(id: $id)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": record.ID,
+	}
+
+	var response DeleteRepeatingTodoResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return fmt.Errorf("failed to clear recurrence: %v", err)
+	}
+
+	if !response.DeleteRepeatingTodo {
+		return fmt.Errorf("failed to clear recurrence: deleteRepeatingTodo returned false")
+	}
+
+	if *simple {
+		fmt.Printf("Recurrence cleared on record %s\n", record.ID)
+	} else {
+		fmt.Printf("✅ Recurrence cleared for '%s' (%s)\n", record.Title, record.ID)
+		fmt.Printf("Existing copies are kept; no new occurrences will be created.\n")
+	}
+
+	return nil
+}
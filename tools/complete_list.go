@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+
+	"demo-builder/common"
+)
+
+// executeMarkTodoListDone marks every record in a list as done, or as not done
+func executeMarkTodoListDone(client *common.Client, listID string, done bool) error {
+	mutation := `
+		mutation MarkTodoListAsDone($todoListId: String!) {
+			markTodoListAsDone(todoListId: $todoListId)
+		}
+	`
+	field := "markTodoListAsDone"
+	if !done {
+		mutation = `
+			mutation MarkTodoListAsUndone($todoListId: String!) {
+				markTodoListAsUndone(todoListId: $todoListId)
+			}
+		`
+		field = "markTodoListAsUndone"
+	}
+
+	var response map[string]*bool
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"todoListId": listID}, &response); err != nil {
+		return err
+	}
+	if result := response[field]; result != nil && !*result {
+		return fmt.Errorf("the API reported that the list was not changed")
+	}
+	return nil
+}
+
+// runMarkList implements complete-list and reopen-list
+func runMarkList(command string, done bool, args []string) error {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	listRef := fs.String("list", "", "List ID or title (required)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" || *listRef == "" {
+		fmt.Printf("Usage: go run . %s -project PROJECT -list LIST [flags]\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Printf("  go run . %s -project my-project -list \"Sprint 12\"\n", command)
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	lists, err := fetchProjectLists(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lists: %v", err)
+	}
+	list := findListByIDOrTitle(lists, *listRef)
+	if list == nil {
+		return fmt.Errorf("list '%s' not found in project %s", *listRef, *projectID)
+	}
+
+	if err := executeMarkTodoListDone(client, list.ID, done); err != nil {
+		return fmt.Errorf("failed to update list: %v", err)
+	}
+
+	switch {
+	case *simple:
+		fmt.Println(list.ID)
+	case done:
+		fmt.Printf("✅ All records in '%s' marked as done\n", list.Title)
+	default:
+		fmt.Printf("✅ All records in '%s' reopened\n", list.Title)
+	}
+	return nil
+}
+
+// RunCompleteList marks every record in a list as done
+func RunCompleteList(args []string) error {
+	return runMarkList("complete-list", true, args)
+}
+
+// RunReopenList marks every record in a list as not done
+func RunReopenList(args []string) error {
+	return runMarkList("reopen-list", false, args)
+}
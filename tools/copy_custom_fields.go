@@ -0,0 +1,810 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// CopyableCustomField is a custom field with every setting needed to recreate it
+type CopyableCustomField struct {
+	common.CustomField
+	ReferenceMultiple bool `json:"referenceMultiple"`
+	ReferenceProject  *struct {
+		ID string `json:"id"`
+	} `json:"referenceProject"`
+	LookupOption           *CustomFieldLookupOptionInput `json:"lookupOption"`
+	TimeDurationDisplay    string                        `json:"timeDurationDisplay"`
+	TimeDurationTargetTime *float64                      `json:"timeDurationTargetTime"`
+	TimeDurationStart      *CopyableTimeDuration         `json:"timeDurationStart"`
+	TimeDurationEnd        *CopyableTimeDuration         `json:"timeDurationEnd"`
+	UseSequenceUniqueID    bool                          `json:"useSequenceUniqueId"`
+	SequenceDigits         *int                          `json:"sequenceDigits"`
+	SequenceStartingNumber *int                          `json:"sequenceStartingNumber"`
+}
+
+// CopyableTimeDuration is the start or end condition of a TIME_DURATION field
+type CopyableTimeDuration struct {
+	Type        string `json:"type"`
+	Condition   string `json:"condition"`
+	CustomField *struct {
+		ID string `json:"id"`
+	} `json:"customField"`
+	CustomFieldOptions []struct {
+		ID string `json:"id"`
+	} `json:"customFieldOptions"`
+	TodoList *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"todoList"`
+	Tag *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"tag"`
+}
+
+// timeDurationSelection is the selection of a TIME_DURATION start or end condition
+const timeDurationSelection = `{
+					type
+					condition
+					customField {
+						id
+					}
+					customFieldOptions {
+						id
+					}
+					todoList {
+						id
+						title
+					}
+					tag {
+						id
+						title
+					}
+				}`
+
+// fetchCopyableCustomFields returns a project's custom fields with all their settings, in position order
+func fetchCopyableCustomFields(client *common.Client, projectID string) ([]CopyableCustomField, error) {
+	var fields []CopyableCustomField
+	for skip := 0; ; skip += 100 {
+		query := fmt.Sprintf(`query {
+			customFields(filter: { projectId: "%s" }, skip: %d, take: 100) {
+				items {
+					id
+					name
+					type
+					position
+					description
+					min
+					max
+					currency
+					prefix
+					isDueDate
+					formula
+					regionCode
+					countryCodes
+					timezone
+					buttonType
+					buttonConfirmText
+					currencyFieldId
+					conversionDateType
+					conversionDate
+					referenceMultiple
+					referenceProject {
+						id
+					}
+					lookupOption {
+						referenceId
+						lookupId
+						lookupType
+					}
+					timeDurationDisplay
+					timeDurationTargetTime
+					timeDurationStart %s
+					timeDurationEnd %s
+					useSequenceUniqueId
+					sequenceDigits
+					sequenceStartingNumber
+					customFieldOptions {
+						id
+						title
+						color
+						currencyConversionFrom
+						currencyConversionTo
+					}
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}`, projectID, skip, timeDurationSelection, timeDurationSelection)
+
+		var response struct {
+			CustomFields struct {
+				Items    []CopyableCustomField `json:"items"`
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"customFields"`
+		}
+		if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+			return nil, err
+		}
+		fields = append(fields, response.CustomFields.Items...)
+		if !response.CustomFields.PageInfo.HasNextPage || len(response.CustomFields.Items) == 0 {
+			break
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Position < fields[j].Position
+	})
+	return fields, nil
+}
+
+// fieldCopyContext maps the source project's fields, options, lists and tags to the target project
+type fieldCopyContext struct {
+	sourceProjectID string
+	targetProjectID string
+	sourceFields    []CopyableCustomField
+	fieldIDs        map[string]string
+	optionIDs       map[string]string
+	targetLists     []common.TodoList
+	targetTags      []common.Tag
+}
+
+// referencesProject reports whether the REFERENCE field with the given ID points to the project
+func referencesProject(fields []CopyableCustomField, referenceID, projectID string) bool {
+	for _, field := range fields {
+		if field.ID == referenceID {
+			return field.ReferenceProject != nil && field.ReferenceProject.ID == projectID
+		}
+	}
+	return false
+}
+
+// dependencies returns the IDs of the source fields a field's settings point to.
+// A lookup only depends on its target field when its reference field points back to
+// the source project; targets in other projects are left as they are.
+func (f *CopyableCustomField) dependencies(sourceFields []CopyableCustomField, sourceProjectID string) []string {
+	var deps []string
+	if f.CurrencyFieldID != "" {
+		deps = append(deps, f.CurrencyFieldID)
+	}
+	if f.LookupOption != nil && f.LookupOption.ReferenceID != "" {
+		deps = append(deps, f.LookupOption.ReferenceID)
+		if f.LookupOption.LookupID != "" && referencesProject(sourceFields, f.LookupOption.ReferenceID, sourceProjectID) {
+			deps = append(deps, f.LookupOption.LookupID)
+		}
+	}
+	for _, duration := range []*CopyableTimeDuration{f.TimeDurationStart, f.TimeDurationEnd} {
+		if duration != nil && duration.CustomField != nil {
+			deps = append(deps, duration.CustomField.ID)
+		}
+	}
+	if text := storedFormulaText(f.Formula); text != "" {
+		for _, match := range formulaReferencePattern.FindAllStringSubmatch(text, -1) {
+			deps = append(deps, strings.TrimSpace(match[1]))
+		}
+	}
+	return deps
+}
+
+// storedFormulaText returns the logic text of a stored formula
+func storedFormulaText(formula interface{}) string {
+	if formula == nil {
+		return ""
+	}
+	var stored FormulaInput
+	data, err := json.Marshal(formula)
+	if err != nil || json.Unmarshal(data, &stored) != nil {
+		return ""
+	}
+	return stored.Logic.Text
+}
+
+// remapFormula returns a stored formula with its field references pointing to the copies
+func remapFormula(formula interface{}, fieldIDs map[string]string) (*FormulaInput, error) {
+	var stored FormulaInput
+	data, err := json.Marshal(formula)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("unreadable formula: %v", err)
+	}
+	for sourceID, targetID := range fieldIDs {
+		stored.Logic.Text = strings.ReplaceAll(stored.Logic.Text, sourceID, targetID)
+		stored.Logic.HTML = strings.ReplaceAll(stored.Logic.HTML, sourceID, targetID)
+	}
+	if stored.Display.Type == "" {
+		stored.Display.Type = "NUMBER"
+	}
+	return &stored, nil
+}
+
+// remapTimeDuration returns a TIME_DURATION condition pointing to the target project's fields, lists and tags
+func (c *fieldCopyContext) remapTimeDuration(duration *CopyableTimeDuration) (*CustomFieldTimeDurationInput, error) {
+	if duration == nil {
+		return nil, nil
+	}
+	input := &CustomFieldTimeDurationInput{Type: duration.Type, Condition: duration.Condition}
+	if duration.CustomField != nil {
+		input.CustomFieldID = c.fieldIDs[duration.CustomField.ID]
+	}
+	for _, option := range duration.CustomFieldOptions {
+		id, ok := c.optionIDs[option.ID]
+		if !ok {
+			return nil, fmt.Errorf("time duration option %s has no copy", option.ID)
+		}
+		input.CustomFieldOptionIDs = append(input.CustomFieldOptionIDs, id)
+	}
+	if duration.TodoList != nil {
+		list := findListByIDOrTitle(c.targetLists, duration.TodoList.Title)
+		if list == nil {
+			return nil, fmt.Errorf("the target project has no list '%s'", duration.TodoList.Title)
+		}
+		input.TodoListID = list.ID
+	}
+	if duration.Tag != nil {
+		var tagID string
+		for _, tag := range c.targetTags {
+			if strings.EqualFold(tag.Title, duration.Tag.Title) {
+				tagID = tag.ID
+			}
+		}
+		if tagID == "" {
+			return nil, fmt.Errorf("the target project has no tag '%s'", duration.Tag.Title)
+		}
+		input.TagID = tagID
+	}
+	return input, nil
+}
+
+// createInput builds the input that recreates a field in the target project
+func (c *fieldCopyContext) createInput(field *CopyableCustomField) (LocalCreateCustomFieldInput, error) {
+	input := LocalCreateCustomFieldInput{
+		Name:                   field.Name,
+		Type:                   field.Type,
+		Description:            field.Description,
+		ButtonType:             field.ButtonType,
+		ButtonConfirmText:      field.ButtonConfirmText,
+		ConversionDate:         field.ConversionDate,
+		ConversionDateType:     field.ConversionDateType,
+		Min:                    field.Min,
+		Max:                    field.Max,
+		Currency:               field.Currency,
+		Prefix:                 field.Prefix,
+		IsDueDate:              boolPtr(field.IsDueDate),
+		TimeDurationDisplay:    field.TimeDurationDisplay,
+		TimeDurationTargetTime: field.TimeDurationTargetTime,
+		ReferenceMultiple:      boolPtr(field.ReferenceMultiple),
+		UseSequenceUniqueID:    boolPtr(field.UseSequenceUniqueID),
+		SequenceDigits:         field.SequenceDigits,
+		SequenceStartingNumber: field.SequenceStartingNumber,
+	}
+
+	if field.CurrencyFieldID != "" {
+		input.CurrencyFieldID = c.fieldIDs[field.CurrencyFieldID]
+	}
+
+	// A reference to the source project itself becomes a reference to the target project
+	if field.ReferenceProject != nil {
+		input.ReferenceProjectID = field.ReferenceProject.ID
+		if field.ReferenceProject.ID == c.sourceProjectID {
+			input.ReferenceProjectID = c.targetProjectID
+		}
+	}
+
+	if field.LookupOption != nil {
+		lookup := *field.LookupOption
+		lookup.ReferenceID = c.fieldIDs[lookup.ReferenceID]
+		if mapped, ok := c.fieldIDs[lookup.LookupID]; ok && referencesProject(c.sourceFields, field.LookupOption.ReferenceID, c.sourceProjectID) {
+			lookup.LookupID = mapped
+		}
+		input.LookupOption = &lookup
+	}
+
+	var err error
+	if input.TimeDurationStartInput, err = c.remapTimeDuration(field.TimeDurationStart); err != nil {
+		return input, err
+	}
+	if input.TimeDurationEndInput, err = c.remapTimeDuration(field.TimeDurationEnd); err != nil {
+		return input, err
+	}
+
+	if field.Type == "FORMULA" && field.Formula != nil {
+		formula, err := remapFormula(field.Formula, c.fieldIDs)
+		if err != nil {
+			return input, err
+		}
+		input.Formula = formula
+	}
+	return input, nil
+}
+
+// copyOrder orders new fields so each comes after the fields it depends on. Fields whose
+// dependencies have no copy and are not being created are returned as blocked.
+func copyOrder(pending []*CopyableCustomField, copies map[string]string, sourceFields []CopyableCustomField, sourceProjectID string) ([]*CopyableCustomField, []*CopyableCustomField) {
+	known := make(map[string]bool)
+	for sourceID := range copies {
+		known[sourceID] = true
+	}
+	var ordered []*CopyableCustomField
+	for len(pending) > 0 {
+		var waiting []*CopyableCustomField
+		for _, field := range pending {
+			ready := true
+			for _, dep := range field.dependencies(sourceFields, sourceProjectID) {
+				if !known[dep] {
+					ready = false
+				}
+			}
+			if !ready {
+				waiting = append(waiting, field)
+				continue
+			}
+			known[field.ID] = true
+			ordered = append(ordered, field)
+		}
+		if len(waiting) == len(pending) {
+			return ordered, waiting
+		}
+		pending = waiting
+	}
+	return ordered, nil
+}
+
+// missingDependencies returns the names of the fields a field depends on that have no copy
+func (c *fieldCopyContext) missingDependencies(field *CopyableCustomField) []string {
+	var missing []string
+	for _, dep := range field.dependencies(c.sourceFields, c.sourceProjectID) {
+		if _, ok := c.fieldIDs[dep]; ok {
+			continue
+		}
+		name := dep
+		for _, source := range c.sourceFields {
+			if source.ID == dep {
+				name = source.Name
+			}
+		}
+		missing = append(missing, name)
+	}
+	return missing
+}
+
+// matchesNamePatterns reports whether a name matches any of the glob patterns (case-insensitive)
+func matchesNamePatterns(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// splitNamePatterns splits a comma-separated list of name patterns
+func splitNamePatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// fieldUpdateInput returns the changes needed to bring an existing field in line with its source
+func fieldUpdateInput(source *CopyableCustomField, target *common.CustomField) (UpdateCustomFieldInput, []string) {
+	input := UpdateCustomFieldInput{CustomFieldID: target.ID}
+	var diffs []string
+	if source.Description != target.Description {
+		input.Description = source.Description
+		diffs = append(diffs, "description")
+	}
+	if source.Min != nil && !sameFloat(source.Min, target.Min) {
+		input.Min = source.Min
+		diffs = append(diffs, fmt.Sprintf("min %s → %s", floatValue(target.Min), floatValue(source.Min)))
+	}
+	if source.Max != nil && !sameFloat(source.Max, target.Max) {
+		input.Max = source.Max
+		diffs = append(diffs, fmt.Sprintf("max %s → %s", floatValue(target.Max), floatValue(source.Max)))
+	}
+	if source.Currency != "" && !strings.EqualFold(source.Currency, target.Currency) {
+		input.Currency = source.Currency
+		diffs = append(diffs, fmt.Sprintf("currency %s → %s", target.Currency, source.Currency))
+	}
+	if source.Prefix != target.Prefix {
+		input.Prefix = source.Prefix
+		diffs = append(diffs, fmt.Sprintf("prefix %q → %q", target.Prefix, source.Prefix))
+	}
+	return input, diffs
+}
+
+// planFieldOptions records the mapping of the source options the target field already has
+// and returns the options to create and the edits that bring the existing ones in line
+func planFieldOptions(ctx *fieldCopyContext, source *CopyableCustomField, target *common.CustomField) ([]common.CustomFieldOptionInput, []EditCustomFieldOptionInput) {
+	var missing []common.CustomFieldOptionInput
+	var edits []EditCustomFieldOptionInput
+	for _, option := range source.Options {
+		existing := findOption(target, option.Title)
+		if existing == nil {
+			missing = append(missing, common.CustomFieldOptionInput{
+				Title:                  option.Title,
+				Color:                  option.Color,
+				CurrencyConversionFrom: option.CurrencyConversionFrom,
+				CurrencyConversionTo:   option.CurrencyConversionTo,
+			})
+			continue
+		}
+		ctx.optionIDs[option.ID] = existing.ID
+		edit := EditCustomFieldOptionInput{CustomFieldID: target.ID, OptionID: existing.ID}
+		if option.Color != "" && !strings.EqualFold(option.Color, existing.Color) {
+			edit.Color = option.Color
+		}
+		if option.CurrencyConversionFrom != "" && !strings.EqualFold(option.CurrencyConversionFrom, existing.CurrencyConversionFrom) {
+			edit.CurrencyConversionFrom = option.CurrencyConversionFrom
+		}
+		if option.CurrencyConversionTo != "" && !strings.EqualFold(option.CurrencyConversionTo, existing.CurrencyConversionTo) {
+			edit.CurrencyConversionTo = option.CurrencyConversionTo
+		}
+		if edit.Color != "" || edit.CurrencyConversionFrom != "" || edit.CurrencyConversionTo != "" {
+			edits = append(edits, edit)
+		}
+	}
+	return missing, edits
+}
+
+// copyFieldOptions creates the options missing from the target field, updates the colour
+// and currency conversion of the others and records the option mapping
+func copyFieldOptions(client *common.Client, ctx *fieldCopyContext, source *CopyableCustomField, target *common.CustomField, dryRun bool) (int, error) {
+	if len(source.Options) == 0 {
+		return 0, nil
+	}
+	if !dryRun {
+		details, err := getCustomFieldDetails(client, target.ID)
+		if err != nil {
+			return 0, err
+		}
+		target = details
+	}
+
+	missing, edits := planFieldOptions(ctx, source, target)
+	changed := len(edits)
+	if !dryRun {
+		for _, edit := range edits {
+			if _, err := executeEditCustomFieldOption(client, edit); err != nil {
+				return changed, fmt.Errorf("failed to update option %s: %v", edit.OptionID, err)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return changed, nil
+	}
+	if dryRun {
+		for _, option := range source.Options {
+			if _, ok := ctx.optionIDs[option.ID]; !ok {
+				ctx.optionIDs[option.ID] = "new:" + option.Title
+			}
+		}
+		return changed + len(missing), nil
+	}
+
+	if err := createCustomFieldOptions(client, target.ID, missing); err != nil {
+		return changed, fmt.Errorf("failed to create options: %v", err)
+	}
+	details, err := getCustomFieldDetails(client, target.ID)
+	if err != nil {
+		return changed, err
+	}
+	for _, option := range source.Options {
+		if created := findOption(details, option.Title); created != nil {
+			ctx.optionIDs[option.ID] = created.ID
+		}
+	}
+	return changed + len(missing), nil
+}
+
+// copyFieldGroups recreates the source groups in the target project and moves the copied fields into them
+func copyFieldGroups(client *common.Client, ctx *fieldCopyContext, sourceLayout []common.TodoField, copied map[string]bool) (int, error) {
+	layout, err := fetchProjectTodoFields(client, ctx.targetProjectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch field groups: %v", err)
+	}
+
+	moved := 0
+	for _, group := range sourceLayout {
+		if group.Type != "CUSTOM_FIELD_GROUP" || group.Name == nil {
+			continue
+		}
+		var members []string
+		for _, nested := range group.TodoFields {
+			if nested.CustomFieldID != nil && copied[*nested.CustomFieldID] {
+				members = append(members, ctx.fieldIDs[*nested.CustomFieldID])
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		if findGroupByName(layout, *group.Name) == -1 {
+			layout = append(layout, common.TodoField{
+				Type:          "CUSTOM_FIELD_GROUP",
+				CustomFieldID: strPtr(generateGroupID()),
+				Name:          strPtr(*group.Name),
+				Color:         group.Color,
+				TodoFields:    []common.TodoField{},
+			})
+		}
+		for _, fieldID := range members {
+			layout = moveFieldToGroup(layout, fieldID, *group.Name)
+			moved++
+		}
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+	if err := updateProjectTodoFields(client, ctx.targetProjectID, convertToInput(layout)); err != nil {
+		return 0, fmt.Errorf("failed to update field groups: %v", err)
+	}
+	return moved, nil
+}
+
+// RunCopyCustomFields recreates custom fields, options and groups of one project in another
+func RunCopyCustomFields(args []string) error {
+	fs := flag.NewFlagSet("copy-custom-fields", flag.ExitOnError)
+	from := fs.String("from", "", "Source project ID or slug (required)")
+	to := fs.String("to", "", "Target project ID or slug (required)")
+	include := fs.String("include", "", "Comma-separated field names to copy; * and ? wildcards allowed (default: all)")
+	exclude := fs.String("exclude", "", "Comma-separated field names to leave out; * and ? wildcards allowed")
+	existing := fs.String("existing", "skip", "Fields that already exist by name: skip or update")
+	noGroups := fs.Bool("no-groups", false, "Don't recreate field groups")
+	dryRun := fs.Bool("dry-run", false, "Show what would be copied without changing anything")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *from == "" || *to == "" {
+		fmt.Println("Usage: go run . copy-custom-fields -from PROJECT -to PROJECT [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Copy every field and group from a template project")
+		fmt.Println("  go run . copy-custom-fields -from client-template -to acme-onboarding")
+		fmt.Println("")
+		fmt.Println("  # Copy only the deal fields, updating ones that already exist")
+		fmt.Println("  go run . copy-custom-fields -from client-template -to acme-onboarding -include \"Deal*,Stage\" -existing update")
+		fmt.Println("")
+		fmt.Println("  # Preview the copy")
+		fmt.Println("  go run . copy-custom-fields -from client-template -to acme-onboarding -exclude \"Internal*\" -dry-run")
+		return fmt.Errorf("required flags missing")
+	}
+	if *existing != "skip" && *existing != "update" {
+		return fmt.Errorf("invalid -existing '%s' (valid: skip, update)", *existing)
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+
+	sourceProject, err := getCurrentProject(client, *from)
+	if err != nil {
+		return fmt.Errorf("failed to fetch source project: %v", err)
+	}
+	targetProject, err := getCurrentProject(client, *to)
+	if err != nil {
+		return fmt.Errorf("failed to fetch target project: %v", err)
+	}
+	if sourceProject.ID == targetProject.ID {
+		return fmt.Errorf("source and target are the same project")
+	}
+
+	client.SetProjectID(sourceProject.ID)
+	sourceFields, err := fetchCopyableCustomFields(client, sourceProject.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch source custom fields: %v", err)
+	}
+	var sourceLayout []common.TodoField
+	if !*noGroups {
+		if sourceLayout, err = fetchProjectTodoFields(client, sourceProject.ID); err != nil {
+			return fmt.Errorf("failed to fetch source field groups: %v", err)
+		}
+	}
+
+	client.SetProjectID(targetProject.ID)
+	targetFields, err := fetchProjectCustomFields(client, targetProject.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch target custom fields: %v", err)
+	}
+	ctx := &fieldCopyContext{
+		sourceProjectID: sourceProject.ID,
+		targetProjectID: targetProject.ID,
+		sourceFields:    sourceFields,
+		fieldIDs:        make(map[string]string),
+		optionIDs:       make(map[string]string),
+	}
+	if ctx.targetLists, err = fetchProjectLists(client, targetProject.ID); err != nil {
+		return fmt.Errorf("failed to fetch target lists: %v", err)
+	}
+	if ctx.targetTags, err = fetchProjectTags(client, targetProject.ID); err != nil {
+		return fmt.Errorf("failed to fetch target tags: %v", err)
+	}
+
+	// Fields that exist in the target by name are reused, whether they are copied or only depended on
+	includePatterns, excludePatterns := splitNamePatterns(*include), splitNamePatterns(*exclude)
+	var selected []*CopyableCustomField
+	for i := range sourceFields {
+		field := &sourceFields[i]
+		if target := findCustomField(targetFields, field.Name); target != nil && target.Type == field.Type {
+			ctx.fieldIDs[field.ID] = target.ID
+			for _, option := range field.Options {
+				if existing := findOption(target, option.Title); existing != nil {
+					ctx.optionIDs[option.ID] = existing.ID
+				}
+			}
+		}
+		if len(includePatterns) > 0 && !matchesNamePatterns(field.Name, includePatterns) {
+			continue
+		}
+		if matchesNamePatterns(field.Name, excludePatterns) {
+			continue
+		}
+		selected = append(selected, field)
+	}
+
+	if !*simple {
+		fmt.Printf("=== Copying custom fields from '%s' to '%s' ===\n", sourceProject.Name, targetProject.Name)
+		fmt.Printf("Fields selected: %d of %d\n\n", len(selected), len(sourceFields))
+	}
+	report := func(marker, name, detail string) {
+		if *simple {
+			fmt.Printf("%s\t%s\t%s\n", marker, name, detail)
+		} else {
+			fmt.Printf("%s %s (%s)\n", marker, name, detail)
+		}
+	}
+
+	var created, updated, skipped, failed int
+	copied := make(map[string]bool)
+
+	// Existing fields are skipped or updated in place
+	var pending []*CopyableCustomField
+	for _, field := range selected {
+		target := findCustomField(targetFields, field.Name)
+		if target == nil {
+			pending = append(pending, field)
+			continue
+		}
+		if target.Type != field.Type {
+			failed++
+			report("❌", field.Name, fmt.Sprintf("exists as %s in the target, source is %s", target.Type, field.Type))
+			continue
+		}
+		if *existing == "skip" {
+			skipped++
+			report("⏭️ ", field.Name, "exists, skipped")
+			continue
+		}
+		input, diffs := fieldUpdateInput(field, target)
+		if len(diffs) > 0 && !*dryRun {
+			if _, err := executeUpdateCustomField(client, input); err != nil {
+				failed++
+				report("❌", field.Name, err.Error())
+				continue
+			}
+		}
+		optionChanges, err := copyFieldOptions(client, ctx, field, target, *dryRun)
+		if err != nil {
+			failed++
+			report("❌", field.Name, err.Error())
+			continue
+		}
+		if optionChanges > 0 {
+			diffs = append(diffs, fmt.Sprintf("%d option change(s)", optionChanges))
+		}
+		copied[field.ID] = true
+		if len(diffs) == 0 {
+			skipped++
+			report("✅", field.Name, "up to date")
+			continue
+		}
+		updated++
+		report("~ ", field.Name, strings.Join(diffs, ", "))
+	}
+
+	// New fields are created once the fields they depend on have copies
+	ordered, blocked := copyOrder(pending, ctx.fieldIDs, sourceFields, sourceProject.ID)
+	for _, field := range ordered {
+		// A dependency that failed to copy leaves nothing to point to
+		if missing := ctx.missingDependencies(field); len(missing) > 0 {
+			failed++
+			report("❌", field.Name, "depends on fields that weren't copied: "+strings.Join(missing, ", "))
+			continue
+		}
+
+		input, err := ctx.createInput(field)
+		if err != nil {
+			failed++
+			report("❌", field.Name, err.Error())
+			continue
+		}
+		detail := field.Type
+		if len(field.Options) > 0 {
+			detail += fmt.Sprintf(", %d option(s)", len(field.Options))
+		}
+		if *dryRun {
+			ctx.fieldIDs[field.ID] = "new:" + field.Name
+			copyFieldOptions(client, ctx, field, &common.CustomField{}, true)
+			copied[field.ID] = true
+			created++
+			report("+ ", field.Name, detail)
+			continue
+		}
+
+		result, err := executeCreateCustomField(client, input)
+		if err != nil {
+			failed++
+			report("❌", field.Name, err.Error())
+			continue
+		}
+		ctx.fieldIDs[field.ID] = result.ID
+		copied[field.ID] = true
+		position := field.Position
+		if _, err := executeUpdateCustomField(client, UpdateCustomFieldInput{CustomFieldID: result.ID, Position: &position}); err != nil {
+			report("⚠️ ", field.Name, fmt.Sprintf("created, but could not be positioned: %v", err))
+		}
+		if _, err := copyFieldOptions(client, ctx, field, &common.CustomField{ID: result.ID}, false); err != nil {
+			failed++
+			report("❌", field.Name, "created, but "+err.Error())
+			continue
+		}
+		created++
+		report("✅", field.Name, detail)
+	}
+	// The remaining fields depend on fields that weren't selected or can't be copied
+	for _, field := range blocked {
+		failed++
+		report("❌", field.Name, "depends on fields that weren't copied: "+strings.Join(ctx.missingDependencies(field), ", "))
+	}
+
+	groupMoves := 0
+	if !*noGroups && len(copied) > 0 {
+		if *dryRun {
+			for _, group := range sourceLayout {
+				for _, nested := range group.TodoFields {
+					if nested.CustomFieldID != nil && copied[*nested.CustomFieldID] {
+						groupMoves++
+					}
+				}
+			}
+		} else if groupMoves, err = copyFieldGroups(client, ctx, sourceLayout, copied); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+	}
+
+	if *simple {
+		fmt.Printf("created=%d updated=%d skipped=%d failed=%d grouped=%d\n", created, updated, skipped, failed, groupMoves)
+	} else {
+		fmt.Printf("\n=== Summary ===\n")
+		if *dryRun {
+			fmt.Println("Dry run: nothing was changed")
+		}
+		fmt.Printf("Created: %d\n", created)
+		fmt.Printf("Updated: %d\n", updated)
+		fmt.Printf("Skipped: %d\n", skipped)
+		fmt.Printf("Failed: %d\n", failed)
+		fmt.Printf("Fields placed in groups: %d\n", groupMoves)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d field(s) could not be copied", failed)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"demo-builder/common"
+)
+
+func TestCopyCurrencyConversionField(t *testing.T) {
+	// A CURRENCY_CONVERSION field as fetchCopyableCustomFields selects it
+	data := `{
+		"id": "conv", "name": "Budget (EUR)", "type": "CURRENCY_CONVERSION", "position": 2,
+		"currencyFieldId": "budget", "conversionDateType": "CUSTOM", "conversionDate": "2025-01-01",
+		"customFieldOptions": [
+			{"id": "o1", "title": "USD to EUR", "currencyConversionFrom": "USD", "currencyConversionTo": "EUR"},
+			{"id": "o2", "title": "GBP to EUR", "currencyConversionFrom": "GBP", "currencyConversionTo": "EUR"}
+		]
+	}`
+	var field CopyableCustomField
+	if err := json.Unmarshal([]byte(data), &field); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &fieldCopyContext{
+		sourceProjectID: "p1",
+		targetProjectID: "p2",
+		sourceFields:    []CopyableCustomField{field},
+		fieldIDs:        map[string]string{"budget": "budget-copy"},
+		optionIDs:       make(map[string]string),
+	}
+
+	if deps := field.dependencies(ctx.sourceFields, ctx.sourceProjectID); !reflect.DeepEqual(deps, []string{"budget"}) {
+		t.Errorf("dependencies = %q; want [budget]", deps)
+	}
+	input, err := ctx.createInput(&field)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.Type != "CURRENCY_CONVERSION" || input.CurrencyFieldID != "budget-copy" || input.ConversionDateType != "CUSTOM" || input.ConversionDate != "2025-01-01" {
+		t.Errorf("createInput = %+v; want the conversion settings pointing to budget-copy", input)
+	}
+
+	// A new field gets every option with its conversion
+	missing, edits := planFieldOptions(ctx, &field, &common.CustomField{})
+	want := []common.CustomFieldOptionInput{
+		{Title: "USD to EUR", CurrencyConversionFrom: "USD", CurrencyConversionTo: "EUR"},
+		{Title: "GBP to EUR", CurrencyConversionFrom: "GBP", CurrencyConversionTo: "EUR"},
+	}
+	if !reflect.DeepEqual(missing, want) || len(edits) != 0 {
+		t.Errorf("planFieldOptions on a new field = %+v, %+v; want %+v and no edits", missing, edits, want)
+	}
+	sent, _ := json.Marshal(missing[0])
+	if !strings.Contains(string(sent), `"currencyConversionFrom":"USD"`) || !strings.Contains(string(sent), `"currencyConversionTo":"EUR"`) {
+		t.Errorf("option input is sent as %s; want the conversion currencies", sent)
+	}
+
+	// An existing field keeps matching options and has the others corrected
+	target := &common.CustomField{ID: "conv-copy", Options: []common.CustomFieldOption{
+		{ID: "t1", Title: "USD to EUR", CurrencyConversionFrom: "USD", CurrencyConversionTo: "EUR"},
+		{ID: "t2", Title: "GBP to EUR", CurrencyConversionFrom: "GBP", CurrencyConversionTo: "USD"},
+	}}
+	missing, edits = planFieldOptions(ctx, &field, target)
+	wantEdits := []EditCustomFieldOptionInput{{CustomFieldID: "conv-copy", OptionID: "t2", CurrencyConversionTo: "EUR"}}
+	if len(missing) != 0 || !reflect.DeepEqual(edits, wantEdits) {
+		t.Errorf("planFieldOptions on an existing field = %+v, %+v; want no new options and %+v", missing, edits, wantEdits)
+	}
+	if want := map[string]string{"o1": "t1", "o2": "t2"}; !reflect.DeepEqual(ctx.optionIDs, want) {
+		t.Errorf("option mapping = %v; want %v", ctx.optionIDs, want)
+	}
+}
+
+func TestCopyOrder(t *testing.T) {
+	reference := func(id, projectID string) CopyableCustomField {
+		field := CopyableCustomField{CustomField: common.CustomField{ID: id, Name: id, Type: "REFERENCE"}}
+		field.ReferenceProject = &struct {
+			ID string `json:"id"`
+		}{ID: projectID}
+		return field
+	}
+	lookup := func(id, referenceID, lookupID string) CopyableCustomField {
+		return CopyableCustomField{
+			CustomField:  common.CustomField{ID: id, Name: id, Type: "LOOKUP"},
+			LookupOption: &CustomFieldLookupOptionInput{ReferenceID: referenceID, LookupID: lookupID, LookupType: "TODO_CUSTOM_FIELD"},
+		}
+	}
+	formula := func(id, text string) CopyableCustomField {
+		return CopyableCustomField{CustomField: common.CustomField{ID: id, Name: id, Type: "FORMULA", Formula: map[string]interface{}{"logic": map[string]interface{}{"text": text}}}}
+	}
+	number := func(id string) CopyableCustomField {
+		return CopyableCustomField{CustomField: common.CustomField{ID: id, Name: id, Type: "NUMBER"}}
+	}
+
+	sourceFields := []CopyableCustomField{
+		formula("total", "{{ price }} * {{qty}}"),
+		lookup("own-lookup", "own-ref", "price"),
+		lookup("other-lookup", "other-ref", "remote"),
+		reference("own-ref", "p1"),
+		reference("other-ref", "p9"),
+		number("price"),
+		lookup("orphan", "missing-ref", ""),
+	}
+	var pending []*CopyableCustomField
+	for i := range sourceFields {
+		pending = append(pending, &sourceFields[i])
+	}
+	copies := map[string]string{"qty": "qty-copy"}
+
+	ordered, blocked := copyOrder(pending, copies, sourceFields, "p1")
+	var orderedIDs, blockedIDs []string
+	for _, field := range ordered {
+		orderedIDs = append(orderedIDs, field.ID)
+	}
+	for _, field := range blocked {
+		blockedIDs = append(blockedIDs, field.ID)
+	}
+
+	// The own-project lookup waits for its target; the other project's lookup only for its reference
+	if want := []string{"own-ref", "other-ref", "price", "total", "own-lookup", "other-lookup"}; !reflect.DeepEqual(orderedIDs, want) {
+		t.Errorf("ordered = %q; want %q", orderedIDs, want)
+	}
+	if want := []string{"orphan"}; !reflect.DeepEqual(blockedIDs, want) {
+		t.Errorf("blocked = %q; want %q", blockedIDs, want)
+	}
+	if len(copies) != 1 {
+		t.Errorf("copyOrder changed the known copies: %v", copies)
+	}
+}
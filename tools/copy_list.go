@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// fetchListRecordIDs returns the IDs of the records in a list in position order
+func fetchListRecordIDs(client *common.Client, listID string) ([]string, error) {
+	todos, err := fetchListTodos[struct {
+		ID string `json:"id"`
+	}](client, listID, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	return ids, nil
+}
+
+// RunCopyList duplicates a list, optionally with its records, in the same or another project
+func RunCopyList(args []string) error {
+	fs := flag.NewFlagSet("copy-list", flag.ExitOnError)
+	projectID := fs.String("project", "", "Source project ID or slug (required)")
+	listRef := fs.String("list", "", "List ID or title to copy (required)")
+	title := fs.String("title", "", "Title of the new list (default: \"<title> (Copy)\")")
+	toProject := fs.String("to-project", "", "Destination project ID or slug (default: source project)")
+	withRecords := fs.Bool("with-records", false, "Also copy the list's records")
+	include := fs.String("include", "all", "Record parts to copy: description, due-dates, assignees, tags, comments, checklists, custom-fields, all, none")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" || *listRef == "" {
+		fmt.Println("Usage: go run . copy-list -project PROJECT -list LIST [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . copy-list -project my-project -list \"Sprint 12\" -title \"Sprint 13\"")
+		fmt.Println("  go run . copy-list -project templates -list \"Onboarding\" -to-project acme -with-records")
+		fmt.Println("  go run . copy-list -project my-project -list \"Checklist\" -with-records -include checklists,tags")
+		return fmt.Errorf("required flags missing")
+	}
+
+	options, err := parseCopyRecordOptions(*include)
+	if err != nil {
+		return err
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	source, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+	lists, err := fetchProjectLists(client, source.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lists: %v", err)
+	}
+	list := findListByIDOrTitle(lists, *listRef)
+	if list == nil {
+		return fmt.Errorf("list '%s' not found in project %s", *listRef, *projectID)
+	}
+
+	newTitle := *title
+	if newTitle == "" {
+		newTitle = list.Title + " (Copy)"
+	}
+
+	// Standard increment is 65535.0 as per Blue's implementation
+	increment := 65535.0
+	target := source
+	var position float64
+	if *toProject == "" {
+		// Place the copy right after the original
+		position = list.Position + increment
+		for i := range lists {
+			if lists[i].ID == list.ID && i+1 < len(lists) {
+				position = (list.Position + lists[i+1].Position) / 2
+			}
+		}
+	} else {
+		client.SetProject(*toProject)
+		if target, err = getCurrentProject(client, *toProject); err != nil {
+			return fmt.Errorf("failed to fetch destination project: %v", err)
+		}
+		maxPos, err := getMaxPosition(client, target.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get max position: %v", err)
+		}
+		position = maxPos + increment
+	}
+
+	client.SetProjectID(target.ID)
+	created, err := createTodoList(client, CreateTodoListInput{
+		ProjectID: target.ID,
+		Title:     newTitle,
+		Position:  position,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create list: %v", err)
+	}
+	if list.IsLocked {
+		locked := true
+		if _, err := executeUpdateTodoList(client, UpdateTodoListInput{TodoListID: created.ID, IsLocked: &locked}); err != nil {
+			fmt.Printf("⚠️  Could not lock the new list: %v\n", err)
+		}
+	}
+
+	if !*simple {
+		fmt.Printf("✅ List '%s' created in '%s'\n", created.Title, target.Name)
+		fmt.Printf("   ID: %s\n", created.ID)
+	}
+
+	copied := 0
+	if *withRecords {
+		client.SetProjectID(source.ID)
+		recordIDs, err := fetchListRecordIDs(client, list.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch records of '%s': %v", list.Title, err)
+		}
+
+		if !*simple && len(recordIDs) > 0 {
+			if len(options) > 0 {
+				fmt.Printf("\nCopying %d record(s), including: %s\n", len(recordIDs), strings.ToLower(strings.Join(options, ", ")))
+			} else {
+				fmt.Printf("\nCopying %d record(s), title only\n", len(recordIDs))
+			}
+		}
+		for i, recordID := range recordIDs {
+			if err := executeCopyRecord(client, CopyRecordInput{TodoID: recordID, TodoListID: created.ID, Options: options}); err != nil {
+				return fmt.Errorf("failed to copy record %s (%d of %d, %d copied): %v", recordID, i+1, len(recordIDs), copied, err)
+			}
+			copied++
+			if !*simple {
+				fmt.Printf("\r   %d/%d", copied, len(recordIDs))
+			}
+		}
+		if !*simple && len(recordIDs) > 0 {
+			fmt.Println()
+		}
+	}
+
+	if *simple {
+		fmt.Println(created.ID)
+	} else if *withRecords {
+		fmt.Printf("\n✅ Copied %d record(s)\n", copied)
+	}
+	return nil
+}
@@ -0,0 +1,403 @@
+package tools
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"demo-builder/common"
+)
+
+// CopyProjectInput is the input for the copyProject mutation
+type CopyProjectInput struct {
+	ProjectID   string          `json:"projectId"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	CompanyID   string          `json:"companyId,omitempty"`
+	Options     map[string]bool `json:"options"`
+}
+
+// CopyProjectStatus is the progress of the current user's project copy
+type CopyProjectStatus struct {
+	OldProject *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"oldProject"`
+	NewCompany *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"newCompany"`
+	NewProjectName string `json:"newProjectName"`
+	IsActive       bool   `json:"isActive"`
+	QueuePosition  int    `json:"queuePosition"`
+	TotalQueues    int    `json:"totalQueues"`
+}
+
+// copyProjectOptions maps -include names to CopyProjectOptionsInput fields
+var copyProjectOptions = map[string]string{
+	"assignees":              "assignees",
+	"automations":            "automations",
+	"checklists":             "checklists",
+	"custom-fields":          "customFields",
+	"discussions":            "discussions",
+	"discussion-comments":    "discussionComments",
+	"due-dates":              "dueDates",
+	"files":                  "files",
+	"forms":                  "forms",
+	"people":                 "people",
+	"roles":                  "projectUserRoles",
+	"status-updates":         "statusUpdates",
+	"status-update-comments": "statusUpdateComments",
+	"tags":                   "tags",
+	"todo-actions":           "todoActions",
+	"comments":               "todoComments",
+	"lists":                  "todoLists",
+	"records":                "todos",
+	"cover":                  "coverConfig",
+}
+
+// copyProjectOptionNames lists the -include names in help order
+var copyProjectOptionNames = []string{
+	"lists", "records", "assignees", "due-dates", "tags", "checklists", "comments", "custom-fields",
+	"automations", "todo-actions", "roles", "people", "forms", "files", "discussions",
+	"discussion-comments", "status-updates", "status-update-comments", "cover",
+}
+
+// parseCopyProjectOptions converts -include and -exclude to CopyProjectOptionsInput values
+func parseCopyProjectOptions(include, exclude string) (map[string]bool, error) {
+	options := make(map[string]bool)
+	include = strings.TrimSpace(strings.ToLower(include))
+	all := include == "" || include == "all"
+	for _, field := range copyProjectOptions {
+		options[field] = all
+	}
+
+	parse := func(value string, enabled bool) error {
+		for _, part := range strings.Split(value, ",") {
+			name := strings.ReplaceAll(strings.TrimSpace(strings.ToLower(part)), "_", "-")
+			if name == "" || name == "all" || name == "none" {
+				continue
+			}
+			field, ok := copyProjectOptions[name]
+			if !ok {
+				return fmt.Errorf("unknown copy option '%s' (valid: %s, all, none)", part, strings.Join(copyProjectOptionNames, ", "))
+			}
+			options[field] = enabled
+		}
+		return nil
+	}
+
+	if !all {
+		if err := parse(include, true); err != nil {
+			return nil, err
+		}
+	}
+	if err := parse(exclude, false); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// readCompanyList reads company IDs from a comma-separated flag and/or a file
+// with one company per line. Blank lines and lines starting with # are skipped.
+func readCompanyList(companies, file string) ([]string, error) {
+	var result []string
+	for _, company := range strings.Split(companies, ",") {
+		if company = strings.TrimSpace(company); company != "" {
+			result = append(result, company)
+		}
+	}
+
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open companies file: %v", err)
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			// Allow CSV files: the company is the first column
+			result = append(result, strings.TrimSpace(strings.Split(line, ",")[0]))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read companies file: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// executeCopyProject starts an asynchronous project copy
+func executeCopyProject(client *common.Client, input CopyProjectInput) error {
+	mutation := `
+		mutation CopyProject($input: CopyProjectInput!) {
+			copyProject(input: $input)
+		}
+	`
+
+	var response struct {
+		CopyProject bool `json:"copyProject"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return err
+	}
+	if !response.CopyProject {
+		return fmt.Errorf("the API did not accept the copy request")
+	}
+	return nil
+}
+
+// fetchCopyProjectStatus returns the current user's copy in progress, or nil when none is running
+func fetchCopyProjectStatus(client *common.Client) (*CopyProjectStatus, error) {
+	query := `
+		query CopyProjectStatus {
+			copyProjectStatus {
+				oldProject {
+					id
+					name
+				}
+				newCompany {
+					id
+					name
+				}
+				newProjectName
+				isActive
+				queuePosition
+				totalQueues
+			}
+		}
+	`
+
+	var response struct {
+		CopyProjectStatus *CopyProjectStatus `json:"copyProjectStatus"`
+	}
+	if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.CopyProjectStatus, nil
+}
+
+// copyProgressBar renders a queue position as a text progress bar
+func copyProgressBar(status *CopyProjectStatus) string {
+	const width = 30
+	if status.IsActive || status.TotalQueues == 0 {
+		return fmt.Sprintf("[%s] copying...", strings.Repeat("#", width))
+	}
+	done := status.TotalQueues - status.QueuePosition
+	if done < 0 {
+		done = 0
+	}
+	filled := width * done / status.TotalQueues
+	return fmt.Sprintf("[%s%s] queued %d/%d", strings.Repeat("#", filled), strings.Repeat(".", width-filled),
+		status.QueuePosition, status.TotalQueues)
+}
+
+// waitForProjectCopy polls copyProjectStatus until the copy has been reported and is no longer,
+// then returns the new project (nil if it cannot be found). A copy that finishes before its status
+// is ever seen is detected by the new project appearing in the company.
+// The API also offers onCopyProjectFinished, but this client has no websocket support.
+func waitForProjectCopy(client *common.Client, companyID, name string, started time.Time, interval, timeout time.Duration, simple bool) (*common.Project, error) {
+	deadline := time.Now().Add(timeout)
+	last := ""
+	seen := false
+	for {
+		status, err := fetchCopyProjectStatus(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check copy status: %v", err)
+		}
+		if status == nil {
+			project, err := findCopiedProject(client, companyID, name, started)
+			if seen || (err == nil && project != nil) {
+				if !simple && last != "" {
+					fmt.Printf("\r%s\n", strings.Repeat(" ", len(last)))
+				}
+				return project, nil
+			}
+		} else {
+			seen = true
+			if !simple {
+				line := "   " + copyProgressBar(status)
+				fmt.Printf("\r%-*s", len(last), line)
+				last = line
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if !simple && last != "" {
+				fmt.Println()
+			}
+			return nil, fmt.Errorf("copy still running after %s; check again later with read-projects", timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// findCopiedProject returns the newest project with this name in a company created after started
+func findCopiedProject(client *common.Client, companyID, name string, started time.Time) (*common.Project, error) {
+	query := buildProjectQuery(companyID, false, 0, 20, name, false, true, "createdAt_DESC", "")
+
+	var response ProjectListResponse
+	if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+		return nil, err
+	}
+	for i := range response.ProjectList.Items {
+		project := &response.ProjectList.Items[i]
+		if project.Name != name {
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, project.CreatedAt)
+		if err != nil || createdAt.Before(started) {
+			continue
+		}
+		return project, nil
+	}
+	return nil, nil
+}
+
+// RunCopyProject copies a project, optionally into several companies
+func RunCopyProject(args []string) error {
+	fs := flag.NewFlagSet("copy-project", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug to copy (required)")
+	name := fs.String("name", "", "Name of the new project (default: \"<source name> (Copy)\")")
+	description := fs.String("description", "", "Description of the new project")
+	company := fs.String("company", "", "Target company ID, or a comma-separated list (default: the source project's company)")
+	companiesFile := fs.String("companies-file", "", "File with one target company ID per line (first CSV column)")
+	include := fs.String("include", "all", "What to copy: "+strings.Join(copyProjectOptionNames, ", ")+", all, none")
+	exclude := fs.String("exclude", "", "Parts to leave out, applied after -include")
+	noWait := fs.Bool("no-wait", false, "Start the copy and return without waiting for it to finish")
+	interval := fs.Duration("interval", 3*time.Second, "How often to poll the copy status")
+	timeout := fs.Duration("timeout", 30*time.Minute, "How long to wait for each copy")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" {
+		fmt.Println("Usage: go run . copy-project -project PROJECT [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . copy-project -project golden-template -name \"Acme Onboarding\"")
+		fmt.Println("  go run . copy-project -project golden-template -include lists,custom-fields,automations,tags")
+		fmt.Println("  go run . copy-project -project golden-template -exclude records,comments -company company_123")
+		fmt.Println("  go run . copy-project -project golden-template -companies-file clients.txt")
+		return fmt.Errorf("required flags missing")
+	}
+
+	options, err := parseCopyProjectOptions(*include, *exclude)
+	if err != nil {
+		return err
+	}
+	companies, err := readCompanyList(*company, *companiesFile)
+	if err != nil {
+		return err
+	}
+	if len(companies) > 1 && *noWait {
+		return fmt.Errorf("-no-wait cannot be used with several companies: copies are queued one at a time")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	source, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+
+	newName := *name
+	if newName == "" {
+		newName = source.Name + " (Copy)"
+	}
+	if len(companies) == 0 {
+		// An empty company lets the API use the source project's company
+		companies = []string{""}
+	}
+
+	if running, err := fetchCopyProjectStatus(client); err == nil && running != nil {
+		return fmt.Errorf("a project copy is already in progress ('%s'); wait for it to finish first", running.NewProjectName)
+	}
+
+	var failed []string
+	for i, companyID := range companies {
+		target := companyID
+		if target == "" {
+			target = "source company"
+		}
+		if !*simple {
+			if len(companies) > 1 {
+				fmt.Printf("\n[%d/%d] ", i+1, len(companies))
+			}
+			fmt.Printf("Copying '%s' to '%s' (%s)...\n", source.Name, newName, target)
+		}
+
+		input := CopyProjectInput{
+			ProjectID:   source.ID,
+			Name:        newName,
+			Description: *description,
+			CompanyID:   companyID,
+			Options:     options,
+		}
+		lookupCompany := companyID
+		if lookupCompany == "" {
+			lookupCompany = client.GetCompanyID()
+		}
+		started := time.Now()
+		if err := executeCopyProject(client, input); err != nil {
+			if len(companies) == 1 {
+				return fmt.Errorf("failed to copy project: %v", err)
+			}
+			fmt.Printf("❌ %s: %v\n", target, err)
+			failed = append(failed, target)
+			continue
+		}
+
+		if *noWait {
+			if *simple {
+				fmt.Println("started")
+			} else {
+				fmt.Println("✅ Copy started; it runs in the background on the server")
+			}
+			return nil
+		}
+
+		project, err := waitForProjectCopy(client, lookupCompany, newName, started, *interval, *timeout, *simple)
+		if err != nil {
+			if len(companies) == 1 {
+				return err
+			}
+			fmt.Printf("❌ %s: %v\n", target, err)
+			failed = append(failed, target)
+			continue
+		}
+
+		switch {
+		case project == nil:
+			if *simple {
+				fmt.Printf("%s\tdone\n", target)
+			} else {
+				fmt.Printf("✅ Copy finished, but the new project could not be found in %s\n", target)
+			}
+		case *simple:
+			fmt.Printf("%s\t%s\t%s\n", target, project.ID, project.Slug)
+		default:
+			fmt.Printf("✅ Copy finished: %s\n", project.Name)
+			fmt.Printf("   ID: %s\n", project.ID)
+			fmt.Printf("   Slug: %s\n", project.Slug)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d copies failed: %s", len(failed), len(companies), strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package tools
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"demo-builder/common"
+)
+
+// CopyRecordInput represents the input for the copyTodo mutation
+type CopyRecordInput struct {
+	Title      string   `json:"title,omitempty"`
+	TodoID     string   `json:"todoId"`
+	TodoListID string   `json:"todoListId"`
+	Options    []string `json:"options"`
+}
+
+// CopyRecordResponse represents the response from the copyTodo mutation
+type CopyRecordResponse struct {
+	CopyTodo bool `json:"copyTodo"`
+}
+
+// CopyTitleData is the data available to -title templates
+type CopyTitleData struct {
+	N     int
+	Total int
+	Title string
+}
+
+// copyRecordOptions maps -include names to CopyTodoOption values
+var copyRecordOptions = map[string]string{
+	"description":   "DESCRIPTION",
+	"due-date":      "DUE_DATE",
+	"due-dates":     "DUE_DATE",
+	"dates":         "DUE_DATE",
+	"assignees":     "ASSIGNEES",
+	"tags":          "TAGS",
+	"comments":      "COMMENTS",
+	"checklists":    "CHECKLISTS",
+	"custom-fields": "CUSTOM_FIELDS",
+	"customfields":  "CUSTOM_FIELDS",
+}
+
+// allCopyRecordOptions lists every CopyTodoOption in schema order
+var allCopyRecordOptions = []string{"DESCRIPTION", "DUE_DATE", "ASSIGNEES", "TAGS", "COMMENTS", "CHECKLISTS", "CUSTOM_FIELDS"}
+
+// parseCopyRecordOptions converts the -include flag to CopyTodoOption values
+func parseCopyRecordOptions(include string) ([]string, error) {
+	include = strings.TrimSpace(strings.ToLower(include))
+	if include == "" || include == "all" {
+		return allCopyRecordOptions, nil
+	}
+	if include == "none" {
+		return []string{}, nil
+	}
+
+	seen := make(map[string]bool)
+	options := []string{}
+	for _, part := range strings.Split(include, ",") {
+		name := strings.ReplaceAll(strings.TrimSpace(part), "_", "-")
+		if name == "" {
+			continue
+		}
+		option, ok := copyRecordOptions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown -include value '%s' (valid: description, due-dates, assignees, tags, comments, checklists, custom-fields, all, none)", part)
+		}
+		if !seen[option] {
+			seen[option] = true
+			options = append(options, option)
+		}
+	}
+	return options, nil
+}
+
+// fetchProjectLists returns the lists of a project sorted by position
+func fetchProjectLists(client *common.Client, projectID string) ([]common.TodoList, error) {
+	variables := map[string]interface{}{
+		"projectId": projectID,
+	}
+
+	var response TodoListsResponse
+	if err := client.ExecuteQueryWithResult(detailedQuery, variables, &response); err != nil {
+		return nil, err
+	}
+
+	lists := response.TodoLists
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Position < lists[j].Position
+	})
+	return lists, nil
+}
+
+// findListByIDOrTitle looks up a list by exact ID or case-insensitive title
+func findListByIDOrTitle(lists []common.TodoList, value string) *common.TodoList {
+	for i := range lists {
+		if lists[i].ID == value {
+			return &lists[i]
+		}
+	}
+	for i := range lists {
+		if strings.EqualFold(lists[i].Title, value) {
+			return &lists[i]
+		}
+	}
+	return nil
+}
+
+// fetchListByID looks up a list anywhere by ID, returning nil when it doesn't exist
+func fetchListByID(client *common.Client, listID string) (*common.TodoList, error) {
+	query := `
+		query GetTodoList($todoListId: String!) {
+			todoList(id: $todoListId) {
+				id
+				title
+			}
+		}
+	`
+
+	var response struct {
+		TodoList *common.TodoList `json:"todoList"`
+	}
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"todoListId": listID}, &response); err != nil {
+		// The API reports a missing list as an error rather than null
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if response.TodoList == nil || response.TodoList.ID == "" {
+		return nil, nil
+	}
+	return response.TodoList, nil
+}
+
+// executeCopyRecord copies a record using the copyTodo mutation
+func executeCopyRecord(client *common.Client, input CopyRecordInput) error {
+	mutation := `
+		mutation CopyTodo($input: CopyTodoInput!) {
+			copyTodo(input: $input)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response CopyRecordResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+
+	if !response.CopyTodo {
+		return fmt.Errorf("copyTodo returned false")
+	}
+
+	return nil
+}
+
+func RunCopyRecord(args []string) error {
+	fs := flag.NewFlagSet("copy-record", flag.ExitOnError)
+
+	// Required flags
+	recordID := fs.String("record", "", "Record ID to copy (required)")
+	projectID := fs.String("project", "", "Source project ID or slug where the record exists (required)")
+
+	// Optional flags
+	toList := fs.String("to-list", "", "Destination list ID or title (default: the record's current list)")
+	toProject := fs.String("to-project", "", "Destination project ID or slug (default: source project)")
+	title := fs.String("title", "", "Title for the copy; supports {{.N}}, {{.Total}} and {{.Title}} (default: original title)")
+	include := fs.String("include", "all", "Parts to copy: description, due-dates, assignees, tags, comments, checklists, custom-fields, all, none")
+	count := fs.Int("count", 1, "Number of copies to create")
+	simple := fs.Bool("simple", false, "Simple output format")
+
+	fs.Parse(args)
+
+	if *recordID == "" || *projectID == "" {
+		fmt.Println("Error: -record and -project flags are required")
+		fmt.Println("\nUsage:")
+		fmt.Println("  go run . copy-record -record RECORD_ID -project PROJECT_ID [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Duplicate a record in its own list")
+		fmt.Println("  go run . copy-record -record rec_123456 -project proj_abc")
+		fmt.Println("")
+		fmt.Println("  # Copy a template record into another project's list, without comments")
+		fmt.Println("  go run . copy-record -record rec_123456 -project templates -to-project acme -to-list \"Onboarding\" -include checklists,assignees,tags,custom-fields")
+		fmt.Println("")
+		fmt.Println("  # Create five numbered copies")
+		fmt.Println("  go run . copy-record -record rec_123456 -project proj_abc -count 5 -title \"Onboarding {{.N}}\"")
+		return fmt.Errorf("required flags missing")
+	}
+
+	if *count < 1 {
+		return fmt.Errorf("-count must be at least 1")
+	}
+
+	options, err := parseCopyRecordOptions(*include)
+	if err != nil {
+		return err
+	}
+
+	var titleTemplate *template.Template
+	if *title != "" {
+		titleTemplate, err = template.New("title").Option("missingkey=error").Parse(*title)
+		if err != nil {
+			return fmt.Errorf("invalid -title template: %v", err)
+		}
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	// Look up the source record for its title and current list
+	source, err := fetchRecurrenceRecord(client, *recordID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record: %v", err)
+	}
+
+	// Resolve the destination list
+	destListID := source.TodoList.ID
+	destListTitle := source.TodoList.Title
+	if *toList != "" || *toProject != "" {
+		listsProject := *toProject
+		if listsProject == "" {
+			listsProject = *projectID
+		}
+
+		lists, err := fetchProjectLists(client, listsProject)
+		if err != nil {
+			return fmt.Errorf("failed to fetch lists for project %s: %v", listsProject, err)
+		}
+
+		switch {
+		case *toList == "":
+			if len(lists) == 0 {
+				return fmt.Errorf("project %s has no lists to copy into", listsProject)
+			}
+			destListID, destListTitle = lists[0].ID, lists[0].Title
+		case findListByIDOrTitle(lists, *toList) != nil:
+			list := findListByIDOrTitle(lists, *toList)
+			destListID, destListTitle = list.ID, list.Title
+		case *toProject == "":
+			// Not in the source project - it may still be the ID of a list elsewhere
+			list, err := fetchListByID(client, *toList)
+			if err != nil {
+				return fmt.Errorf("failed to look up list '%s': %v", *toList, err)
+			}
+			if list == nil {
+				return fmt.Errorf("list '%s' not found in project %s or by ID", *toList, listsProject)
+			}
+			destListID, destListTitle = list.ID, list.Title
+		default:
+			return fmt.Errorf("list '%s' not found in project %s", *toList, listsProject)
+		}
+	}
+
+	if !*simple {
+		fmt.Printf("=== Copying Record '%s' ===\n", source.Title)
+		if destListTitle != "" {
+			fmt.Printf("Destination list: %s (%s)\n", destListTitle, destListID)
+		} else {
+			fmt.Printf("Destination list: %s\n", destListID)
+		}
+		if len(options) > 0 {
+			fmt.Printf("Including: %s\n", strings.Join(options, ", "))
+		} else {
+			fmt.Printf("Including: title only\n")
+		}
+		fmt.Println()
+	}
+
+	copied := 0
+	for n := 1; n <= *count; n++ {
+		input := CopyRecordInput{
+			TodoID:     source.ID,
+			TodoListID: destListID,
+			Options:    options,
+		}
+
+		if titleTemplate != nil {
+			var buf bytes.Buffer
+			data := CopyTitleData{N: n, Total: *count, Title: source.Title}
+			if err := titleTemplate.Execute(&buf, data); err != nil {
+				return fmt.Errorf("failed to render -title template: %v", err)
+			}
+			input.Title = buf.String()
+		}
+
+		if err := executeCopyRecord(client, input); err != nil {
+			if copied > 0 {
+				fmt.Printf("⚠️  %d of %d copies were created before the failure\n", copied, *count)
+			}
+			return fmt.Errorf("failed to copy record (copy %d of %d): %v", n, *count, err)
+		}
+		copied++
+
+		copyTitle := input.Title
+		if copyTitle == "" {
+			copyTitle = source.Title
+		}
+		if *simple {
+			fmt.Printf("Copied record %s as '%s'\n", source.ID, copyTitle)
+		} else {
+			fmt.Printf("✅ Copy %d/%d created: %s\n", n, *count, copyTitle)
+		}
+	}
+
+	if !*simple {
+		fmt.Printf("\n=== %d Copy(ies) Created Successfully ===\n", copied)
+	}
+
+	return nil
+}
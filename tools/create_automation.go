@@ -43,6 +43,7 @@ type CreateAutomationInput struct {
 type CreateAutomationTriggerInput struct {
 	Type                  string                             `json:"type"`
 	TodoListID            string                             `json:"todoListId,omitempty"`
+	CustomFieldID         string                             `json:"customFieldId,omitempty"`
 	Color                 *string                            `json:"color,omitempty"`
 	Metadata              *AutomationTriggerMetadataInput    `json:"metadata"`
 	TagIDs                []string                           `json:"tagIds,omitempty"`
@@ -61,6 +62,7 @@ type CreateAutomationActionInput struct {
 	Color                 *string                          `json:"color,omitempty"`
 	AssigneeTriggerer     *string                          `json:"assigneeTriggerer,omitempty"`
 	TodoListID            string                           `json:"todoListId,omitempty"`
+	CustomFieldID         string                           `json:"customFieldId,omitempty"`
 	TagIDs                []string                         `json:"tagIds,omitempty"`
 	AssigneeIDs           []string                         `json:"assigneeIds,omitempty"`
 	CustomFieldOptionIDs  []string                         `json:"customFieldOptionIds,omitempty"`
@@ -389,7 +391,7 @@ func RunCreateAutomation(args []string) error {
 	
 	// Action options
 	actionType := fs.String("action-type", "", "Action type (required)")
-	actionDueIn := fs.Int("action-due-in", 0, "Due in days for action")
+	actionDueIn := DaysFlag(fs, "action-due-in", 0, "Due in days for action, e.g. 3, 3d, 2w")
 	actionColor := fs.String("action-color", "", "Action color")
 	actionTodoList := fs.String("action-todo-list", "", "Todo list ID for action")
 	actionTags := fs.String("action-tags", "", "Comma-separated tag IDs")
@@ -204,6 +204,10 @@ func buildOptionalFields(input LocalCreateCustomFieldInput) string {
 		}
 	}
 
+	if formula, ok := input.Formula.(*FormulaInput); ok && formula != nil {
+		fields = append(fields, "formula: "+formulaGraphQL(formula))
+	}
+
 	if input.LookupOption != nil {
 		lookupFields := buildLookupOptionInput(input.LookupOption)
 		if lookupFields != "" {
@@ -342,12 +346,26 @@ func RunCreateCustomField(args []string) error {
 	timeDurationTargetTime := fs.Float64("time-duration-target", 0, "Time duration target time")
 	referenceProjectID := fs.String("reference-project", "", "Reference project ID for REFERENCE type")
 	referenceMultiple := fs.Bool("reference-multiple", false, "Allow multiple references")
+	lookup := fs.String("lookup", "", "Lookup for LOOKUP fields as \"Reference Field.Target\" (target: a field of the referenced project, or due date, created at, updated at, tags, assignees, description, list)")
 	useSequenceUniqueID := fs.Bool("use-sequence", false, "Use sequence unique ID")
 	sequenceDigits := fs.Int("sequence-digits", 6, "Number of digits in sequence")
 	sequenceStartingNumber := fs.Int("sequence-start", 1, "Starting number for sequence")
+	formula := fs.String("formula", "", "Formula for FORMULA fields, fields by name in braces: \"{Deal Size} * {Probability} / 100\"")
+	formulaDisplay := fs.String("formula-display", "NUMBER", "How the formula result is shown: NUMBER, CURRENCY (uses -currency) or PERCENTAGE")
+	formulaPrecision := fs.Int("formula-precision", -1, "Decimal places of the formula result (default: as computed)")
+	preview := fs.Int("preview", 5, "Preview the formula on this many existing records (0 to skip)")
+	sample := fs.String("sample", "", "Preview the formula on sample values instead: \"Deal Size=1000;Probability=40\"")
+	dryRun := fs.Bool("dry-run", false, "Check and preview the field without creating it")
 	listOptions := fs.Bool("list", false, "List available options")
 	fs.Parse(args)
 
+	if *fieldType == "" && *formula != "" {
+		*fieldType = "FORMULA"
+	}
+	if *fieldType == "" && *lookup != "" {
+		*fieldType = "LOOKUP"
+	}
+
 	// Show available options if requested
 	if *listOptions {
 		fmt.Println("\n=== Available Custom Field Types ===")
@@ -394,6 +412,12 @@ func RunCreateCustomField(args []string) error {
 	if !validType {
 		return fmt.Errorf("invalid field type '%s'. Use -list flag to see available types", *fieldType)
 	}
+	if *formula != "" && *fieldType != "FORMULA" {
+		return fmt.Errorf("-formula can only be used with -type FORMULA")
+	}
+	if *lookup != "" && *fieldType != "LOOKUP" {
+		return fmt.Errorf("-lookup can only be used with -type LOOKUP")
+	}
 
 	// Load configuration
 	config, err := common.LoadConfig()
@@ -434,6 +458,56 @@ func RunCreateCustomField(args []string) error {
 		SequenceStartingNumber: sequenceStartingNumber,
 	}
 
+	// Compile the formula against the project's fields and preview it before anything is created
+	if *formula != "" {
+		display, err := newFormulaDisplay(*formulaDisplay, *currency, *formulaPrecision)
+		if err != nil {
+			return err
+		}
+		fields, err := fetchProjectCustomFields(client, *projectID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch custom fields: %v", err)
+		}
+		compiled, node, err := compileFormula(*formula, fields, display)
+		if err != nil {
+			return err
+		}
+		input.Formula = compiled
+
+		fmt.Printf("Formula: %s\n", node.render(func(field *common.CustomField) string {
+			return "{" + field.Name + "}"
+		}))
+		var samples []FormulaSample
+		if *sample != "" {
+			sampleValues, err := parseFormulaSample(*sample, fields)
+			if err != nil {
+				return err
+			}
+			samples = []FormulaSample{{Title: "Sample", Values: sampleValues}}
+		} else if *preview > 0 {
+			if samples, err = fetchFormulaSamples(client, *projectID, *preview); err != nil {
+				return fmt.Errorf("failed to fetch records for the preview: %v", err)
+			}
+		}
+		if *sample != "" || *preview > 0 {
+			fmt.Println("Preview:")
+			previewFormula(node, display, samples)
+		}
+	}
+	// Resolve the lookup expression against the reference field and the project it points to
+	if *lookup != "" {
+		lookupOption, resolved, err := buildLookupOption(client, *projectID, *lookup)
+		if err != nil {
+			return err
+		}
+		input.LookupOption = lookupOption
+		fmt.Printf("Lookup: %s (%s)\n", resolved, lookupOption.LookupType)
+	}
+	if *dryRun {
+		fmt.Printf("\nDry run: custom field '%s' of type '%s' was not created\n", input.Name, input.Type)
+		return nil
+	}
+
 	// Handle numeric fields - only set if non-default values
 	if *min != 0 {
 		input.Min = min
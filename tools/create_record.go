@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"strings"
@@ -8,7 +9,50 @@ import (
 	"demo-builder/common"
 )
 
-// CustomFieldValue and CreateTodoInput are already defined in common/types.go
+// CustomFieldValue is already defined in common/types.go
+
+// CreateRecordInput represents the createTodo input used by create-record
+type CreateRecordInput struct {
+	TodoListID  string                       `json:"todoListId"`
+	Title       string                       `json:"title"`
+	Description string                       `json:"description,omitempty"`
+	Placement   string                       `json:"placement,omitempty"`
+	Position    *float64                     `json:"position,omitempty"`
+	StartedAt   string                       `json:"startedAt,omitempty"`
+	DuedAt      string                       `json:"duedAt,omitempty"`
+	AssigneeIds []string                     `json:"assigneeIds,omitempty"`
+	Tags        []CreateRecordTagInput       `json:"tags,omitempty"`
+	Checklists  []CreateRecordChecklistInput `json:"checklists,omitempty"`
+}
+
+// CreateRecordChecklistInput creates an empty checklist together with the record
+type CreateRecordChecklistInput struct {
+	Title    string  `json:"title"`
+	Position float64 `json:"position"`
+}
+
+// CreatedChecklist is a checklist returned by createTodo
+type CreatedChecklist struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Position float64 `json:"position"`
+}
+
+// CreateRecordTagInput connects an existing tag by ID or creates one by title
+type CreateRecordTagInput struct {
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// ChecklistSpec is a checklist parsed from the -checklist flag
+type ChecklistSpec struct {
+	Title string
+	Items []string
+}
+
+// defaultTagColor is used for tags auto-created from -tag-titles
+const defaultTagColor = "#3B82F6"
 
 // CreateTodoResponse represents the GraphQL response
 type CreateTodoResponse struct {
@@ -20,6 +64,7 @@ type CreateTodoResponse struct {
 			ID    string `json:"id"`
 			Title string `json:"title"`
 		} `json:"todoList"`
+		Checklists []CreatedChecklist `json:"checklists"`
 	} `json:"createTodo"`
 }
 
@@ -44,8 +89,16 @@ func parseCustomFieldValues(customFieldsStr string) ([]common.CustomFieldValue,
 		fieldID := strings.TrimSpace(parts[0])
 		valueStr := strings.TrimSpace(parts[1])
 
-		// For now, always use string values (API might expect strings even for numbers)
-		value := valueStr
+		// Values are kept as text and encoded later from the field's type;
+		// a JSON array lists several options, countries or records
+		var value interface{} = valueStr
+		if strings.HasPrefix(valueStr, "[") && strings.HasSuffix(valueStr, "]") {
+			var items []string
+			if err := json.Unmarshal([]byte(valueStr), &items); err != nil {
+				return nil, fmt.Errorf("invalid list for custom field %s: %v", fieldID, err)
+			}
+			value = items
+		}
 
 		customFieldValues = append(customFieldValues, common.CustomFieldValue{
 			CustomFieldID: fieldID,
@@ -56,36 +109,414 @@ func parseCustomFieldValues(customFieldsStr string) ([]common.CustomFieldValue,
 	return customFieldValues, nil
 }
 
+// parseChecklistSpecs parses "Title:item1|item2;Other:itemA" into checklist specs
+func parseChecklistSpecs(spec string) ([]ChecklistSpec, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	var checklists []ChecklistSpec
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		title, itemsStr, _ := strings.Cut(part, ":")
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return nil, fmt.Errorf("invalid checklist '%s' (expected Title:item1|item2)", part)
+		}
+
+		checklist := ChecklistSpec{Title: title}
+		for _, item := range strings.Split(itemsStr, "|") {
+			if item = strings.TrimSpace(item); item != "" {
+				checklist.Items = append(checklist.Items, item)
+			}
+		}
+		checklists = append(checklists, checklist)
+	}
+
+	return checklists, nil
+}
+
+// fetchProjectTags returns every tag of a project, a page at a time
+func fetchProjectTags(client *common.Client, projectID string) ([]common.Tag, error) {
+	query := `
+		query ListTags($projectId: String!, $first: Int, $skip: Int) {
+			tagList(filter: { projectIds: [$projectId] }, first: $first, skip: $skip, orderBy: title_ASC) {
+				items {
+					id
+					uid
+					title
+					color
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}
+	`
+
+	const pageSize = 500
+	var tags []common.Tag
+	for skip := 0; ; skip += pageSize {
+		variables := map[string]interface{}{
+			"projectId": projectID,
+			"first":     pageSize,
+			"skip":      skip,
+		}
+
+		var response struct {
+			TagList struct {
+				Items    []common.Tag `json:"items"`
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"tagList"`
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, response.TagList.Items...)
+		if !response.TagList.PageInfo.HasNextPage || len(response.TagList.Items) == 0 {
+			return tags, nil
+		}
+	}
+}
+
+// resolveRecordTags builds tag inputs, reusing existing tags by title and creating the rest
+func resolveRecordTags(client *common.Client, projectID string, tagIDs, tagTitles []string) ([]CreateRecordTagInput, error) {
+	var tags []CreateRecordTagInput
+	for _, id := range tagIDs {
+		tags = append(tags, CreateRecordTagInput{ID: id})
+	}
+
+	if len(tagTitles) == 0 {
+		return tags, nil
+	}
+
+	existing, err := fetchProjectTags(client, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project tags: %v", err)
+	}
+
+	for _, title := range tagTitles {
+		var match *common.Tag
+		for i := range existing {
+			if strings.EqualFold(existing[i].Title, title) {
+				match = &existing[i]
+				break
+			}
+		}
+		if match != nil {
+			tags = append(tags, CreateRecordTagInput{ID: match.ID})
+		} else {
+			tags = append(tags, CreateRecordTagInput{Title: title, Color: defaultTagColor})
+		}
+	}
+
+	return tags, nil
+}
+
+// createdTagTitles returns the titles of tags that createTodo will create rather than connect
+func createdTagTitles(tags []CreateRecordTagInput) []string {
+	var titles []string
+	for _, tag := range tags {
+		if tag.ID == "" && tag.Title != "" {
+			titles = append(titles, tag.Title)
+		}
+	}
+	return titles
+}
+
+// removeCreatedTags deletes tags created along with a record that was rolled back,
+// returning the titles of any that are still left in the project
+func removeCreatedTags(client *common.Client, projectID string, titles []string) []string {
+	if len(titles) == 0 {
+		return nil
+	}
+	existing, err := fetchProjectTags(client, projectID)
+	if err != nil {
+		return titles
+	}
+	var left []string
+	for _, title := range titles {
+		removed := false
+		for _, tag := range existing {
+			if strings.EqualFold(tag.Title, title) && executeDeleteTag(client, tag.ID) == nil {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			left = append(left, title)
+		}
+	}
+	return left
+}
+
+// recordChecklistInputs numbers checklists for createTodo
+func recordChecklistInputs(titles []string) []CreateRecordChecklistInput {
+	var inputs []CreateRecordChecklistInput
+	for i, title := range titles {
+		inputs = append(inputs, CreateRecordChecklistInput{Title: title, Position: float64(i+1) * 1000.0})
+	}
+	return inputs
+}
+
+// createRecordChecklistItems adds items to the checklists createTodo created,
+// matching each checklist by its position; it returns the number of items added
+func createRecordChecklistItems(client *common.Client, created []CreatedChecklist, inputs []CreateRecordChecklistInput, items [][]string) (int, error) {
+	count := 0
+	for i, input := range inputs {
+		if len(items[i]) == 0 {
+			continue
+		}
+		var checklistID string
+		for _, checklist := range created {
+			if checklist.Position == input.Position && strings.EqualFold(checklist.Title, input.Title) {
+				checklistID = checklist.ID
+				break
+			}
+		}
+		if checklistID == "" {
+			return count, fmt.Errorf("checklist '%s' was not created", input.Title)
+		}
+		for j, item := range items[i] {
+			if _, err := executeCreateChecklistItem(client, CreateChecklistItemInput{
+				ChecklistID: checklistID,
+				Title:       item,
+				Position:    float64(j+1) * 1000.0,
+			}); err != nil {
+				return count, fmt.Errorf("checklist item '%s': %v", item, err)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// getPositionAfterRecord returns a position between a record and the one that follows it
+func getPositionAfterRecord(client *common.Client, recordID string) (string, float64, error) {
+	query := `
+		query GetRecordPosition($id: String!) {
+			todo(id: $id) {
+				id
+				position
+				todoList {
+					id
+				}
+			}
+		}
+	`
+
+	var recordResponse struct {
+		Todo struct {
+			ID       string  `json:"id"`
+			Position float64 `json:"position"`
+			TodoList struct {
+				ID string `json:"id"`
+			} `json:"todoList"`
+		} `json:"todo"`
+	}
+
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"id": recordID}, &recordResponse); err != nil {
+		return "", 0, err
+	}
+	if recordResponse.Todo.ID == "" {
+		return "", 0, fmt.Errorf("record not found: %s", recordID)
+	}
+
+	anchor := recordResponse.Todo
+
+	nextQuery := `
+		query GetNextRecord($listId: String!, $position: Float!) {
+			todoList(id: $listId) {
+				todos(where: { position_gt: $position }, orderBy: position_ASC, first: 1) {
+					id
+					position
+				}
+			}
+		}
+	`
+
+	var nextResponse struct {
+		TodoList struct {
+			Todos []struct {
+				ID       string  `json:"id"`
+				Position float64 `json:"position"`
+			} `json:"todos"`
+		} `json:"todoList"`
+	}
+
+	variables := map[string]interface{}{
+		"listId":   anchor.TodoList.ID,
+		"position": anchor.Position,
+	}
+	if err := client.ExecuteQueryWithResult(nextQuery, variables, &nextResponse); err != nil {
+		return "", 0, err
+	}
+
+	if len(nextResponse.TodoList.Todos) == 0 {
+		return anchor.TodoList.ID, anchor.Position + 65535, nil
+	}
+	return anchor.TodoList.ID, (anchor.Position + nextResponse.TodoList.Todos[0].Position) / 2, nil
+}
+
+// executeCreateRecord creates a record with the createTodo mutation
+func executeCreateRecord(client *common.Client, input CreateRecordInput) (*CreateTodoResponse, error) {
+	mutation := `
+		mutation CreateTodo($input: CreateTodoInput!) {
+			createTodo(input: $input) {
+				id
+				title
+				position
+				todoList {
+					id
+					title
+				}
+				checklists {
+					id
+					title
+					position
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response CreateTodoResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// executeDeleteRecord removes a record (used to roll back a partially created record)
+func executeDeleteRecord(client *common.Client, recordID string) error {
+	mutation := `
+		mutation DeleteTodo($input: DeleteTodoInput!) {
+			deleteTodo(input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": common.DeleteTodoInput{
+			TodoID: recordID,
+		},
+	}
+
+	var response struct {
+		DeleteTodo struct {
+			Success bool `json:"success"`
+		} `json:"deleteTodo"`
+	}
+
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+	if !response.DeleteTodo.Success {
+		return fmt.Errorf("deleteTodo returned success=false")
+	}
+	return nil
+}
+
+// splitAndTrim splits a comma-separated flag value, dropping empty entries
+func splitAndTrim(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
 
 func RunCreateRecord(args []string) error {
 	fs := flag.NewFlagSet("create-record", flag.ExitOnError)
 	projectID := fs.String("project", "", "Project ID or Project slug (required)")
-	listID := fs.String("list", "", "List ID to create the record in (required)")
+	listID := fs.String("list", "", "List ID to create the record in (required unless -after is used)")
 	title := fs.String("title", "", "Title of the record (required)")
 	description := fs.String("description", "", "Description of the record")
 	placement := fs.String("placement", "", "Placement in list: TOP or BOTTOM")
+	after := fs.String("after", "", "Place the new record directly after this record ID (in that record's list)")
 	assignees := fs.String("assignees", "", "Comma-separated assignee IDs")
-	customFields := fs.String("custom-fields", "", "Custom field values in format: field_id1:value1;field_id2:value2")
+	tagIDs := fs.String("tags", "", "Comma-separated tag IDs")
+	tagTitles := fs.String("tag-titles", "", "Comma-separated tag titles (missing tags are created)")
+	color := fs.String("color", "", "Record color (name like 'blue' or hex)")
+	checklist := fs.String("checklist", "", "Checklists in format: Title:item1|item2 (separate multiple checklists with ;)")
+	customFields := fs.String("custom-fields", "", "Custom field values in format: field1:value1;field2:value2 (fields by ID or name)")
+	start := fs.String("start", "", "Start date: "+common.DateExamples)
+	due := fs.String("due", "", "Due date: "+common.DateExamples)
+	timezone := fs.String("timezone", "", "Timezone for relative dates, e.g. Europe/London (default: local timezone)")
+	validateOnly := fs.Bool("validate-only", false, "Check the custom field values and report every problem without creating the record")
 	simple := fs.Bool("simple", false, "Simple output format")
 	fs.Parse(args)
 
-	if *projectID == "" || *listID == "" || *title == "" {
+	if *projectID == "" || (*listID == "" && *after == "") || *title == "" {
 		fmt.Println("Error: -project, -list and -title flags are required")
 		fmt.Println("\nUsage:")
-		fmt.Println("  go run auth.go create-record.go -project PROJECT_ID_OR_SLUG -list LIST_ID -title \"Record Title\" [flags]")
+		fmt.Println("  go run . create-record -project PROJECT_ID_OR_SLUG -list LIST_ID -title \"Record Title\" [flags]")
 		fmt.Println("\nFlags:")
 		fs.PrintDefaults()
 		fmt.Println("\nCustom Fields Format:")
-		fmt.Println("  -custom-fields \"field_id1:value1;field_id2:value2\"")
+		fmt.Println("  -custom-fields \"field1:value1;field2:value2\" (fields by ID or name)")
+		fmt.Println("  Values are checked against each field's type:")
+		printCustomFieldValueFormats()
 		fmt.Println("  Examples:")
 		fmt.Println("    Text field: -custom-fields \"cf123:Hello World\"")
-		fmt.Println("    Number field: -custom-fields \"cf456:42.5\"")
+		fmt.Println("    Number field: -custom-fields \"Budget:42.5\"")
 		fmt.Println("    Boolean field: -custom-fields \"cf789:true\"")
 		fmt.Println("    Multi-select: -custom-fields 'cf000:[\"option1\",\"option2\"]'")
+		fmt.Println("    Date range: -custom-fields \"Launch:2024-03-01..2024-03-15\"")
 		fmt.Println("    Multiple fields: -custom-fields \"cf123:Hello;cf456:42;cf789:true\"")
+		fmt.Println("\nDates:")
+		fmt.Println("  -start today -due \"next friday 17:00\"")
+		fmt.Println("  -due \"+3d\" -timezone America/New_York")
+		fmt.Println("\nEverything in one call:")
+		fmt.Println("  go run . create-record -project proj -list list123 -title \"Kickoff\" -tag-titles \"Onboarding,Q3\" \\")
+		fmt.Println("    -due +1w -color green -checklist \"Prep:Agenda|Invite team;Follow-up:Send notes\"")
+		fmt.Println("\nIf any step fails after the record is created, the record is deleted again.")
 		return fmt.Errorf("required flags missing")
 	}
 
+	if *after != "" && *placement != "" {
+		return fmt.Errorf("use either -after or -placement, not both")
+	}
+
+	startedAt, err := common.ParseDateFlag(*start, *timezone)
+	if err != nil {
+		return fmt.Errorf("invalid -start: %v", err)
+	}
+	duedAt, err := common.ParseDateFlag(*due, *timezone)
+	if err != nil {
+		return fmt.Errorf("invalid -due: %v", err)
+	}
+
+	checklists, err := parseChecklistSpecs(*checklist)
+	if err != nil {
+		return err
+	}
+
+	// Parse custom field values
+	customFieldValues, err := parseCustomFieldValues(*customFields)
+	if err != nil {
+		return fmt.Errorf("failed to parse custom fields: %v", err)
+	}
+
+	recordColor := strings.TrimSpace(*color)
+	if hex, ok := common.ProjectColors[strings.ToLower(recordColor)]; ok {
+		recordColor = hex
+	}
+
 	config, err := common.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %v", err)
@@ -96,95 +527,105 @@ func RunCreateRecord(args []string) error {
 	// Set project context from the provided flag (auto-detects ID vs slug)
 	client.SetProject(*projectID)
 
-	input := common.CreateTodoInput{
-		TodoListID: *listID,
-		Title:      *title,
+	// Custom field values are checked against their field types before anything is created
+	if len(customFieldValues) > 0 || *validateOnly {
+		fields, err := fetchProjectCustomFields(client, *projectID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch custom fields: %v", err)
+		}
+		// REFERENCE values may name records by title
+		if customFieldValues, err = resolveReferenceValues(client, fields, customFieldValues); err != nil {
+			return err
+		}
+		var problems []string
+		if customFieldValues, problems = validateCustomFieldValues(fields, customFieldValues); len(problems) > 0 {
+			return printValidationProblems(problems, *simple)
+		}
+	}
+	if *validateOnly {
+		if !*simple {
+			fmt.Printf("✅ All %d custom field value(s) are valid; no record was created\n", len(customFieldValues))
+		}
+		return nil
 	}
 
-	if *description != "" {
-		input.Description = *description
+	input := CreateRecordInput{
+		TodoListID:  *listID,
+		Title:       *title,
+		Description: *description,
+		Placement:   *placement,
+		StartedAt:   startedAt,
+		DuedAt:      duedAt,
+		AssigneeIds: splitAndTrim(*assignees),
 	}
 
-	if *placement != "" {
-		input.TodoListPlacement = *placement
+	// Everything that can fail is resolved before the record is created
+	input.Tags, err = resolveRecordTags(client, *projectID, splitAndTrim(*tagIDs), splitAndTrim(*tagTitles))
+	if err != nil {
+		return err
 	}
 
-	if *assignees != "" {
-		assigneeList := strings.Split(*assignees, ",")
-		for i, assignee := range assigneeList {
-			assigneeList[i] = strings.TrimSpace(assignee)
-		}
-		input.AssigneeIds = assigneeList
+	// Checklists are created with the record; their items are added afterwards
+	var checklistTitles []string
+	var checklistItems [][]string
+	for _, spec := range checklists {
+		checklistTitles = append(checklistTitles, spec.Title)
+		checklistItems = append(checklistItems, spec.Items)
 	}
+	input.Checklists = recordChecklistInputs(checklistTitles)
 
-	// Parse custom field values
-	if *customFields != "" {
-		customFieldValues, err := parseCustomFieldValues(*customFields)
+	if *after != "" {
+		afterListID, position, err := getPositionAfterRecord(client, *after)
 		if err != nil {
-			return fmt.Errorf("failed to parse custom fields: %v", err)
+			return fmt.Errorf("failed to resolve -after record: %v", err)
+		}
+		if *listID != "" && *listID != afterListID {
+			return fmt.Errorf("record %s is not in list %s", *after, *listID)
 		}
-		input.CustomFieldValues = customFieldValues
+		input.TodoListID = afterListID
+		input.Position = &position
 	}
 
-	// Build the mutation with optional fields
-	var descriptionField string
-	if input.Description != "" {
-		descriptionField = fmt.Sprintf(`description: "%s"`, strings.ReplaceAll(input.Description, `"`, `\"`))
+	response, err := executeCreateRecord(client, input)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
 	}
 
-	var placementField string
-	if input.TodoListPlacement != "" {
-		placementField = fmt.Sprintf(`placement: %s`, input.TodoListPlacement)
-	}
+	record := response.CreateTodo
 
-	var assigneesField string
-	if len(input.AssigneeIds) > 0 {
-		var assigneeStrings []string
-		for _, assigneeID := range input.AssigneeIds {
-			assigneeStrings = append(assigneeStrings, fmt.Sprintf(`"%s"`, assigneeID))
+	// Apply the remaining parts; on failure remove the record, and the tags it
+	// created, so nothing is left half-built
+	rollback := func(step string, stepErr error) error {
+		if delErr := executeDeleteRecord(client, record.ID); delErr != nil {
+			return fmt.Errorf("failed to %s: %v (rollback failed, record %s was left in place: %v)", step, stepErr, record.ID, delErr)
+		}
+		if left := removeCreatedTags(client, *projectID, createdTagTitles(input.Tags)); len(left) > 0 {
+			return fmt.Errorf("failed to %s: %v (record creation rolled back, but new tags were left in the project: %s)", step, stepErr, strings.Join(left, ", "))
 		}
-		assigneesField = fmt.Sprintf(`assigneeIds: [%s]`, strings.Join(assigneeStrings, ", "))
+		return fmt.Errorf("failed to %s: %v (record creation rolled back)", step, stepErr)
 	}
 
-	// Create the basic mutation without custom fields
-	mutation := fmt.Sprintf(`
-		mutation CreateTodo {
-			createTodo(input: {
-				todoListId: "%s"
-				title: "%s"
-				%s
-				%s
-				%s
-			}) {
-				id
-				title
-				position
-				todoList {
-					id
-					title
-				}
-			}
+	if recordColor != "" {
+		if _, err := executeEditTodo(client, UpdateRecordInput{TodoID: record.ID, Color: recordColor}); err != nil {
+			return rollback("set color", err)
 		}
-	`, input.TodoListID, strings.ReplaceAll(input.Title, `"`, `\"`), descriptionField, placementField, assigneesField)
-
-	var response CreateTodoResponse
-	if err := client.ExecuteQueryWithResult(mutation, nil, &response); err != nil {
-		return fmt.Errorf("request failed: %v", err)
 	}
 
-	record := response.CreateTodo
-
-	// Set custom fields if provided
-	if len(input.CustomFieldValues) > 0 {
-		if err := executeSetCustomFields(client, record.ID, input.CustomFieldValues); err != nil {
-			return fmt.Errorf("record created but failed to set custom fields: %v", err)
+	if len(customFieldValues) > 0 {
+		if err := executeSetCustomFields(client, record.ID, customFieldValues); err != nil {
+			return rollback("set custom fields", err)
 		}
 	}
 
+	itemCount, err := createRecordChecklistItems(client, record.Checklists, input.Checklists, checklistItems)
+	if err != nil {
+		return rollback("create checklist items", err)
+	}
+
 	if *simple {
 		fmt.Printf("Created record: %s (ID: %s)\n", record.Title, record.ID)
-		if len(input.CustomFieldValues) > 0 {
-			fmt.Printf("Custom fields set: %d\n", len(input.CustomFieldValues))
+		if len(customFieldValues) > 0 {
+			fmt.Printf("Custom fields set: %d\n", len(customFieldValues))
 		}
 	} else {
 		fmt.Printf("=== Record Created Successfully ===\n")
@@ -192,9 +633,23 @@ func RunCreateRecord(args []string) error {
 		fmt.Printf("Title: %s\n", record.Title)
 		fmt.Printf("Position: %.0f\n", record.Position)
 		fmt.Printf("List: %s (%s)\n", record.TodoList.Title, record.TodoList.ID)
-
-		if len(input.CustomFieldValues) > 0 {
-			fmt.Printf("Custom fields set: %d\n", len(input.CustomFieldValues))
+		if startedAt != "" {
+			fmt.Printf("Start: %s\n", startedAt)
+		}
+		if duedAt != "" {
+			fmt.Printf("Due: %s\n", duedAt)
+		}
+		if recordColor != "" {
+			fmt.Printf("Color: %s\n", recordColor)
+		}
+		if len(input.Tags) > 0 {
+			fmt.Printf("Tags set: %d\n", len(input.Tags))
+		}
+		if len(customFieldValues) > 0 {
+			fmt.Printf("Custom fields set: %d\n", len(customFieldValues))
+		}
+		if len(checklists) > 0 {
+			fmt.Printf("Checklists created: %d (%d items)\n", len(checklists), itemCount)
 		}
 	}
 
@@ -10,6 +10,38 @@ import (
 
 // Tag is already defined in common/types.go
 
+// Execute GraphQL mutation to create a tag in the client's project context
+func executeCreateTag(client *common.Client, title, color string) (*common.Tag, error) {
+	mutation := `
+		mutation CreateTag($input: CreateTagInput!) {
+			createTag(input: $input) {
+				id
+				uid
+				title
+				color
+				createdAt
+				updatedAt
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"title": title,
+			"color": color,
+		},
+	}
+
+	var tagResponse struct {
+		CreateTag common.Tag `json:"createTag"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, variables, &tagResponse); err != nil {
+		return nil, err
+	}
+
+	return &tagResponse.CreateTag, nil
+}
+
 func RunCreateTags(args []string) error {
 	fs := flag.NewFlagSet("create-tags", flag.ExitOnError)
 	projectID := fs.String("project", "", "Project ID (required)")
@@ -39,46 +71,21 @@ func RunCreateTags(args []string) error {
 	// Set project context for tag creation
 	client.SetProjectID(*projectID)
 
-	// GraphQL mutation for creating a tag
-	mutation := `
-		mutation CreateTag($input: CreateTagInput!) {
-			createTag(input: $input) {
-				id
-				uid
-				title
-				color
-				createdAt
-				updatedAt
-			}
-		}
-	`
-
-	// Variables
-	variables := map[string]interface{}{
-		"input": map[string]interface{}{
-			"title": strings.TrimSpace(*title),
-			"color": strings.TrimSpace(*color),
-		},
-	}
-
 	// Execute mutation
 	fmt.Printf("=== Creating Tag ===\n")
 
-	var tagResponse struct {
-		CreateTag common.Tag `json:"createTag"`
-	}
-
-	if err := client.ExecuteQueryWithResult(mutation, variables, &tagResponse); err != nil {
+	tag, err := executeCreateTag(client, strings.TrimSpace(*title), strings.TrimSpace(*color))
+	if err != nil {
 		return fmt.Errorf("failed to create tag: %v", err)
 	}
 
 	// Display results
 	fmt.Printf("✅ Tag created successfully!\n\n")
-	fmt.Printf("Title: %s\n", tagResponse.CreateTag.Title)
-	fmt.Printf("ID: %s\n", tagResponse.CreateTag.ID)
-	fmt.Printf("UID: %s\n", tagResponse.CreateTag.UID)
-	fmt.Printf("Color: %s\n", tagResponse.CreateTag.Color)
-	fmt.Printf("Created: %s\n", tagResponse.CreateTag.CreatedAt)
+	fmt.Printf("Title: %s\n", tag.Title)
+	fmt.Printf("ID: %s\n", tag.ID)
+	fmt.Printf("UID: %s\n", tag.UID)
+	fmt.Printf("Color: %s\n", tag.Color)
+	fmt.Printf("Created: %s\n", tag.CreatedAt)
 
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// PositionedFieldOption is a custom field option with its position in the field
+type PositionedFieldOption struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Color    string  `json:"color"`
+	Position float64 `json:"position"`
+}
+
+// OptionField is a select field with its options in display order
+type OptionField struct {
+	ID      string                  `json:"id"`
+	Name    string                  `json:"name"`
+	Type    string                  `json:"type"`
+	Options []PositionedFieldOption `json:"customFieldOptions"`
+}
+
+// EditCustomFieldOptionInput changes the title, colour or position of an option
+type EditCustomFieldOptionInput struct {
+	CustomFieldID          string   `json:"customFieldId"`
+	OptionID               string   `json:"optionId"`
+	Title                  string   `json:"title,omitempty"`
+	Color                  string   `json:"color,omitempty"`
+	CurrencyConversionFrom string   `json:"currencyConversionFrom,omitempty"`
+	CurrencyConversionTo   string   `json:"currencyConversionTo,omitempty"`
+	Position               *float64 `json:"position,omitempty"`
+}
+
+// fetchOptionField resolves a select field by ID, or by name within the project,
+// and returns it with its options sorted by position
+func fetchOptionField(client *common.Client, fieldRef, projectID string) (*OptionField, error) {
+	fieldID := fieldRef
+	if projectID != "" {
+		fields, err := fetchProjectCustomFields(client, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch custom fields: %v", err)
+		}
+		if field := findCustomField(fields, fieldRef); field != nil {
+			fieldID = field.ID
+		}
+	}
+
+	query := `
+		query GetCustomFieldOptions($customFieldId: String!) {
+			customField(id: $customFieldId) {
+				id
+				name
+				type
+				customFieldOptions {
+					id
+					title
+					color
+					position
+				}
+			}
+		}
+	`
+
+	var response struct {
+		CustomField OptionField `json:"customField"`
+	}
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"customFieldId": fieldID}, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch custom field: %v", err)
+	}
+	field := &response.CustomField
+	if field.ID == "" {
+		return nil, fmt.Errorf("custom field '%s' not found", fieldRef)
+	}
+	if field.Type != "SELECT_SINGLE" && field.Type != "SELECT_MULTI" {
+		return nil, fmt.Errorf("'%s' is a %s field; only SELECT_SINGLE and SELECT_MULTI fields have options", field.Name, field.Type)
+	}
+	sort.SliceStable(field.Options, func(i, j int) bool {
+		return field.Options[i].Position < field.Options[j].Position
+	})
+	return field, nil
+}
+
+// findFieldOption finds an option by ID or (case-insensitive) title
+func findFieldOption(field *OptionField, ref string) (*PositionedFieldOption, error) {
+	ref = strings.TrimSpace(ref)
+	for i := range field.Options {
+		if field.Options[i].ID == ref {
+			return &field.Options[i], nil
+		}
+	}
+	for i := range field.Options {
+		if strings.EqualFold(field.Options[i].Title, ref) {
+			return &field.Options[i], nil
+		}
+	}
+	var titles []string
+	for _, option := range field.Options {
+		titles = append(titles, option.Title)
+	}
+	return nil, fmt.Errorf("'%s' has no option '%s' (options: %s)", field.Name, ref, strings.Join(titles, ", "))
+}
+
+// executeEditCustomFieldOption edits a single custom field option
+func executeEditCustomFieldOption(client *common.Client, input EditCustomFieldOptionInput) (*PositionedFieldOption, error) {
+	mutation := `
+		mutation EditCustomFieldOption($input: EditCustomFieldOptionInput!) {
+			editCustomFieldOption(input: $input) {
+				id
+				title
+				color
+				position
+			}
+		}
+	`
+
+	var response struct {
+		EditCustomFieldOption PositionedFieldOption `json:"editCustomFieldOption"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return nil, err
+	}
+	return &response.EditCustomFieldOption, nil
+}
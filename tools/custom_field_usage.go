@@ -0,0 +1,418 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// Custom field types whose values are calculated rather than filled in,
+// so an empty one is not a sign that nobody uses it
+var calculatedFieldTypes = map[string]bool{
+	"FORMULA": true, "LOOKUP": true, "UNIQUE_ID": true, "BUTTON": true,
+	"TIME_DURATION": true, "CURRENCY_CONVERSION": true,
+}
+
+// OptionUsage is how many records have an option of a select field selected
+type OptionUsage struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Records int    `json:"records"`
+}
+
+// FieldUsage summarises how a custom field is filled in across a project
+type FieldUsage struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Type          string        `json:"type"`
+	Calculated    bool          `json:"calculated,omitempty"`
+	Filled        int           `json:"filled"`
+	FillRate      float64       `json:"fillRate"`
+	Distinct      int           `json:"distinctValues"`
+	LastUpdated   string        `json:"lastUpdated,omitempty"`
+	Options       []OptionUsage `json:"options,omitempty"`
+	Unused        bool          `json:"unused"`
+	UnusedOptions []OptionUsage `json:"unusedOptions,omitempty"`
+}
+
+// CustomFieldUsageReport is the usage of every custom field of a project
+type CustomFieldUsageReport struct {
+	ProjectID   string       `json:"projectId"`
+	ProjectName string       `json:"projectName"`
+	Records     int          `json:"records"`
+	Complete    bool         `json:"complete"`
+	Fields      []FieldUsage `json:"fields"`
+}
+
+// usageFieldValue is the raw value of a custom field on a record, covering every settable type
+type usageFieldValue struct {
+	ID             string   `json:"id"`
+	Text           *string  `json:"text"`
+	Number         *float64 `json:"number"`
+	Checked        *bool    `json:"checked"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	StartDate      string   `json:"startDate"`
+	EndDate        string   `json:"endDate"`
+	CountryCodes   []string `json:"countryCodes"`
+	SelectedOption *struct {
+		ID string `json:"id"`
+	} `json:"selectedOption"`
+	SelectedOptions []struct {
+		ID string `json:"id"`
+	} `json:"selectedOptions"`
+	SelectedTodos []struct {
+		ID string `json:"id"`
+	} `json:"selectedTodos"`
+	Files []struct {
+		UID string `json:"uid"`
+	} `json:"files"`
+}
+
+// usageRecord is a record's custom field values and when each was last changed
+type usageRecord struct {
+	Values  []usageFieldValue
+	Updated map[string]string
+}
+
+// key returns a comparable form of the value and the option IDs it selects,
+// with an empty key when the field is not filled in
+func (v usageFieldValue) key() (string, []string) {
+	switch {
+	case v.SelectedOption != nil:
+		return v.SelectedOption.ID, []string{v.SelectedOption.ID}
+	case len(v.SelectedOptions) > 0:
+		var ids []string
+		for _, option := range v.SelectedOptions {
+			ids = append(ids, option.ID)
+		}
+		sort.Strings(ids)
+		return strings.Join(ids, ","), ids
+	case len(v.SelectedTodos) > 0:
+		var ids []string
+		for _, todo := range v.SelectedTodos {
+			ids = append(ids, todo.ID)
+		}
+		sort.Strings(ids)
+		return strings.Join(ids, ","), nil
+	case len(v.Files) > 0:
+		var uids []string
+		for _, file := range v.Files {
+			uids = append(uids, file.UID)
+		}
+		sort.Strings(uids)
+		return strings.Join(uids, ","), nil
+	case len(v.CountryCodes) > 0:
+		return strings.Join(v.CountryCodes, ","), nil
+	case v.Number != nil:
+		return strconv.FormatFloat(*v.Number, 'f', -1, 64), nil
+	case v.Checked != nil && *v.Checked:
+		return "true", nil
+	case v.Latitude != nil && v.Longitude != nil:
+		return fmt.Sprintf("%g,%g", *v.Latitude, *v.Longitude), nil
+	case v.StartDate != "" || v.EndDate != "":
+		return v.StartDate + "/" + v.EndDate, nil
+	case v.Text != nil && strings.TrimSpace(*v.Text) != "":
+		return strings.TrimSpace(*v.Text), nil
+	}
+	return "", nil
+}
+
+// usageScan is the result of reading every record's custom field values
+type usageScan struct {
+	Records []usageRecord
+	// WithUpdated is false when the API refused per-value update times
+	WithUpdated bool
+	// Complete is false when a list returned fewer records than it reports holding
+	Complete bool
+}
+
+// fetchUsageRecords returns the custom field values of every record of a project.
+// Per-value update times come from todoCustomFields; when the API refuses that
+// selection the values are fetched without them and the report leaves them out.
+func fetchUsageRecords(client *common.Client, projectID string) (*usageScan, error) {
+	lists, err := fetchProjectLists(client, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lists: %v", err)
+	}
+
+	valueSelection := `
+		customFields {
+			id
+			text
+			number
+			checked
+			latitude
+			longitude
+			startDate
+			endDate
+			countryCodes
+			selectedOption {
+				id
+			}
+			selectedOptions {
+				id
+			}
+			selectedTodos {
+				id
+			}
+			files {
+				uid
+			}
+		}
+	`
+	updatedSelection := `
+		todoCustomFields {
+			updatedAt
+			customField {
+				id
+			}
+		}
+	`
+	type usageTodo struct {
+		ID               string            `json:"id"`
+		CustomFields     []usageFieldValue `json:"customFields"`
+		TodoCustomFields []struct {
+			UpdatedAt   string `json:"updatedAt"`
+			CustomField struct {
+				ID string `json:"id"`
+			} `json:"customField"`
+		} `json:"todoCustomFields"`
+	}
+
+	scan := &usageScan{WithUpdated: true, Complete: true}
+	for _, list := range lists {
+		todos, err := fetchListTodos[usageTodo](client, list.ID, "id\n"+valueSelection+updatedSelection)
+		if err != nil && scan.WithUpdated {
+			scan.WithUpdated = false
+			todos, err = fetchListTodos[usageTodo](client, list.ID, "id\n"+valueSelection)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch records of '%s': %v", list.Title, err)
+		}
+		if len(todos) < list.TodosCount {
+			scan.Complete = false
+		}
+		for _, todo := range todos {
+			record := usageRecord{Values: todo.CustomFields, Updated: make(map[string]string)}
+			for _, value := range todo.TodoCustomFields {
+				if value.UpdatedAt > record.Updated[value.CustomField.ID] {
+					record.Updated[value.CustomField.ID] = value.UpdatedAt
+				}
+			}
+			scan.Records = append(scan.Records, record)
+		}
+	}
+	return scan, nil
+}
+
+// buildCustomFieldUsage tallies the values of each field across the records
+func buildCustomFieldUsage(fields []common.CustomField, records []usageRecord) []FieldUsage {
+	var usages []FieldUsage
+	for _, field := range fields {
+		usage := FieldUsage{ID: field.ID, Name: field.Name, Type: field.Type, Calculated: calculatedFieldTypes[field.Type]}
+		distinct := make(map[string]bool)
+		optionCounts := make(map[string]int)
+		for _, record := range records {
+			for _, value := range record.Values {
+				if value.ID != field.ID {
+					continue
+				}
+				key, optionIDs := value.key()
+				if key == "" {
+					continue
+				}
+				usage.Filled++
+				distinct[key] = true
+				for _, id := range optionIDs {
+					optionCounts[id]++
+				}
+			}
+			if updated := record.Updated[field.ID]; updated > usage.LastUpdated {
+				usage.LastUpdated = updated
+			}
+		}
+		usage.Distinct = len(distinct)
+		if len(records) > 0 {
+			usage.FillRate = float64(usage.Filled) / float64(len(records))
+		}
+		for _, option := range field.Options {
+			optionUsage := OptionUsage{ID: option.ID, Title: option.Title, Records: optionCounts[option.ID]}
+			usage.Options = append(usage.Options, optionUsage)
+			if optionUsage.Records == 0 {
+				usage.UnusedOptions = append(usage.UnusedOptions, optionUsage)
+			}
+		}
+		usage.Unused = usage.Filled == 0 && !usage.Calculated
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+// printCustomFieldUsage prints the report as a table followed by the cleanup candidates
+func printCustomFieldUsage(report *CustomFieldUsageReport, unusedOnly, withUpdated bool) {
+	fmt.Printf("=== Custom field usage: %s (%d records) ===\n\n", report.ProjectName, report.Records)
+	fmt.Printf("%-30s %-16s %10s %6s %8s  %s\n", "FIELD", "TYPE", "FILLED", "RATE", "DISTINCT", "LAST UPDATED")
+	for _, usage := range report.Fields {
+		if unusedOnly && !usage.Unused && len(usage.UnusedOptions) == 0 {
+			continue
+		}
+		name := usage.Name
+		if len(name) > 30 {
+			name = name[:27] + "..."
+		}
+		updated := "-"
+		if !withUpdated {
+			updated = "n/a"
+		} else if len(usage.LastUpdated) >= 10 {
+			updated = usage.LastUpdated[:10]
+		}
+		if usage.Calculated {
+			fmt.Printf("%-30s %-16s %10s %6s %8s  %s\n", name, usage.Type, "calculated", "", "", updated)
+		} else {
+			fmt.Printf("%-30s %-16s %10s %5.0f%% %8d  %s\n", name, usage.Type,
+				fmt.Sprintf("%d/%d", usage.Filled, report.Records), usage.FillRate*100, usage.Distinct, updated)
+		}
+		for _, option := range usage.Options {
+			if unusedOnly && option.Records > 0 {
+				continue
+			}
+			fmt.Printf("    • %-40s %d\n", option.Title, option.Records)
+		}
+	}
+
+	var unusedFields []FieldUsage
+	var withUnusedOptions []FieldUsage
+	for _, usage := range report.Fields {
+		if usage.Unused {
+			unusedFields = append(unusedFields, usage)
+		} else if len(usage.UnusedOptions) > 0 {
+			withUnusedOptions = append(withUnusedOptions, usage)
+		}
+	}
+
+	fmt.Printf("\n=== Cleanup candidates ===\n")
+	if len(unusedFields) == 0 && len(withUnusedOptions) == 0 {
+		fmt.Println("✅ Every field and option is in use")
+		return
+	}
+	if !report.Complete {
+		// A record that was not read may still use what looks unused
+		fmt.Println("⚠️  Not every record could be read, so no delete commands are suggested. Unused here:")
+		for _, usage := range unusedFields {
+			fmt.Printf("    - field '%s'\n", usage.Name)
+		}
+		for _, usage := range withUnusedOptions {
+			for _, option := range usage.UnusedOptions {
+				fmt.Printf("    - option '%s' of '%s'\n", option.Title, usage.Name)
+			}
+		}
+		return
+	}
+	for _, usage := range unusedFields {
+		fmt.Printf("⚠️  Field '%s' is empty on every record\n", usage.Name)
+		fmt.Printf("    go run . delete-custom-field -project %s -field %s -confirm\n", report.ProjectID, usage.ID)
+	}
+	for _, usage := range withUnusedOptions {
+		var titles []string
+		for _, option := range usage.UnusedOptions {
+			titles = append(titles, option.Title)
+		}
+		fmt.Printf("⚠️  Options of '%s' that no record uses: %s\n", usage.Name, strings.Join(titles, ", "))
+		var ids []string
+		for _, option := range usage.UnusedOptions {
+			ids = append(ids, option.ID)
+		}
+		fmt.Printf("    go run . delete-custom-field-options -project %s -field %s -option-ids \"%s\" -confirm\n", report.ProjectID, usage.ID, strings.Join(ids, ","))
+	}
+}
+
+// RunCustomFieldUsage reports how well each custom field of a project is filled in
+func RunCustomFieldUsage(args []string) error {
+	fs := flag.NewFlagSet("custom-field-usage", flag.ExitOnError)
+	projectRef := fs.String("project", "", "Project ID or slug (required)")
+	format := fs.String("format", "table", "Output format: table or json")
+	unusedOnly := fs.Bool("unused", false, "Only show fields and options that no record uses")
+	fs.Parse(args)
+
+	if *projectRef == "" {
+		fmt.Println("Usage: go run . custom-field-usage -project PROJECT [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Fill rates and option usage of every field")
+		fmt.Println("  go run . custom-field-usage -project PROJECT_ID")
+		fmt.Println("")
+		fmt.Println("  # Only the fields and options that could be deleted")
+		fmt.Println("  go run . custom-field-usage -project PROJECT_ID -unused")
+		fmt.Println("")
+		fmt.Println("  # Machine-readable report")
+		fmt.Println("  go run . custom-field-usage -project PROJECT_ID -format json")
+		return fmt.Errorf("required flags missing")
+	}
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("invalid format '%s' (valid: table, json)", *format)
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+	client.SetProject(*projectRef)
+
+	project, err := getCurrentProject(client, *projectRef)
+	if err != nil {
+		return fmt.Errorf("failed to find project: %v", err)
+	}
+	client.SetProjectID(project.ID)
+
+	fields, err := fetchProjectCustomFields(client, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	scan, err := fetchUsageRecords(client, project.ID)
+	if err != nil {
+		return err
+	}
+
+	report := &CustomFieldUsageReport{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Records:     len(scan.Records),
+		Complete:    scan.Complete,
+		Fields:      buildCustomFieldUsage(fields, scan.Records),
+	}
+
+	if *format == "json" {
+		if *unusedOnly {
+			var flagged []FieldUsage
+			for _, usage := range report.Fields {
+				if usage.Unused || len(usage.UnusedOptions) > 0 {
+					flagged = append(flagged, usage)
+				}
+			}
+			report.Fields = flagged
+		}
+		if report.Fields == nil {
+			report.Fields = []FieldUsage{}
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %v", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printCustomFieldUsage(report, *unusedOnly, scan.WithUpdated)
+	return nil
+}
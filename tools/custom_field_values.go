@@ -0,0 +1,425 @@
+package tools
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"demo-builder/common"
+)
+
+var (
+	phonePattern    = regexp.MustCompile(`^(?:([A-Za-z]{2})\s*:\s*)?(\+?[0-9][0-9 ()./-]{3,})$`)
+	currencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+	countryPattern  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+)
+
+// customFieldValueFormats documents the value format of each settable field type
+var customFieldValueFormats = []string{
+	"TEXT_SINGLE, TEXT_MULTI  any text",
+	"EMAIL                    ann@example.com",
+	"URL                      https://example.com",
+	"PHONE                    +44 20 7946 0958, or with a region: GB:020 7946 0958",
+	"NUMBER                   42.5 (within the field's min/max)",
+	"PERCENT                  45 or 45%",
+	"RATING                   4 (within the field's min/max, default 0-5)",
+	"CURRENCY                 125.50, 125.50 EUR or EUR 125.50",
+	"CHECKBOX                 true/false, yes/no, 1/0",
+	"SELECT_SINGLE            option title or ID",
+	"SELECT_MULTI             option titles or IDs separated by commas",
+	"DATE                     2024-03-01, or a range: 2024-03-01..2024-03-15",
+	"LOCATION                 latitude,longitude: 51.5072,-0.1276",
+	"COUNTRY                  ISO country codes separated by commas: GB,IE",
+	"REFERENCE                record IDs or titles separated by commas",
+}
+
+// printCustomFieldValueFormats prints the value format of each settable field type
+func printCustomFieldValueFormats() {
+	for _, format := range customFieldValueFormats {
+		fmt.Println("    " + format)
+	}
+}
+
+// customFieldValueStrings flattens a value from the command line or a spec into strings
+func customFieldValueStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, customFieldValueStrings(item)...)
+		}
+		return values
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// splitCustomFieldList splits comma-separated values, dropping empty items
+func splitCustomFieldList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// findCustomField finds a custom field by ID or (case-insensitive) name
+func findCustomField(fields []common.CustomField, ref string) *common.CustomField {
+	for i := range fields {
+		if fields[i].ID == ref {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, ref) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// findCustomFieldOption finds an option of a select field by ID or title
+func findCustomFieldOption(field *common.CustomField, ref string) (*common.CustomFieldOption, error) {
+	for i := range field.Options {
+		if field.Options[i].ID == ref {
+			return &field.Options[i], nil
+		}
+	}
+	if option := findOption(field, ref); option != nil {
+		return option, nil
+	}
+	var titles []string
+	for _, option := range field.Options {
+		titles = append(titles, option.Title)
+	}
+	return nil, fmt.Errorf("'%s' has no option '%s' (options: %s)", field.Name, ref, strings.Join(titles, ", "))
+}
+
+// checkFieldBounds rejects numbers outside a field's min and max
+func checkFieldBounds(field *common.CustomField, number float64, min, max *float64) error {
+	if min != nil && number < *min {
+		return fmt.Errorf("'%s' must be at least %g, got %g", field.Name, *min, number)
+	}
+	if max != nil && number > *max {
+		return fmt.Errorf("'%s' must be at most %g, got %g", field.Name, *max, number)
+	}
+	return nil
+}
+
+// encodeCustomFieldValue validates a value against its field's type and returns the
+// matching setTodoCustomField input fields. An empty value clears the field.
+func encodeCustomFieldValue(field *common.CustomField, value interface{}) (map[string]interface{}, error) {
+	values := customFieldValueStrings(value)
+	raw := strings.TrimSpace(strings.Join(values, ","))
+	if len(values) > 1 {
+		switch field.Type {
+		case "SELECT_MULTI", "COUNTRY", "REFERENCE", "LOCATION":
+		default:
+			return nil, fmt.Errorf("'%s' is a %s field and takes a single value, got %d", field.Name, field.Type, len(values))
+		}
+	}
+
+	parseNumber := func(text string) (float64, error) {
+		number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return 0, fmt.Errorf("'%s' is a %s field and expects a number, got '%s'", field.Name, field.Type, text)
+		}
+		return number, nil
+	}
+
+	switch field.Type {
+	case "TEXT_SINGLE", "TEXT_MULTI":
+		return map[string]interface{}{"text": raw}, nil
+
+	case "EMAIL":
+		if raw != "" {
+			if address, err := mail.ParseAddress(raw); err != nil || address.Address != raw {
+				return nil, fmt.Errorf("'%s' expects an email address, got '%s'", field.Name, raw)
+			}
+		}
+		return map[string]interface{}{"text": raw}, nil
+
+	case "URL":
+		if raw != "" {
+			if parsed, err := url.Parse(raw); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				return nil, fmt.Errorf("'%s' expects a URL such as https://example.com, got '%s'", field.Name, raw)
+			}
+		}
+		return map[string]interface{}{"text": raw}, nil
+
+	case "PHONE":
+		if raw == "" {
+			return map[string]interface{}{"text": "", "regionCode": nil}, nil
+		}
+		match := phonePattern.FindStringSubmatch(raw)
+		if match == nil {
+			return nil, fmt.Errorf("'%s' expects a phone number such as +44 20 7946 0958 or GB:020 7946 0958, got '%s'", field.Name, raw)
+		}
+		encoded := map[string]interface{}{"text": strings.TrimSpace(match[2])}
+		region := strings.ToUpper(match[1])
+		if region == "" && !strings.HasPrefix(match[2], "+") {
+			region = field.RegionCode
+		}
+		if region != "" {
+			if !common.IsCountryCode(region) {
+				return nil, fmt.Errorf("'%s': '%s' is not an ISO country code", field.Name, region)
+			}
+			encoded["regionCode"] = region
+		} else if !strings.HasPrefix(match[2], "+") {
+			return nil, fmt.Errorf("'%s': '%s' needs a +country prefix or a region such as GB:", field.Name, raw)
+		}
+		return encoded, nil
+
+	case "NUMBER", "PERCENT", "RATING":
+		if raw == "" {
+			return map[string]interface{}{"number": nil}, nil
+		}
+		text := raw
+		if field.Type == "PERCENT" {
+			text = strings.TrimSpace(strings.TrimSuffix(text, "%"))
+		}
+		number, err := parseNumber(text)
+		if err != nil {
+			return nil, err
+		}
+		min, max := field.Min, field.Max
+		if field.Type == "RATING" {
+			lowest, highest := 0.0, 5.0
+			if min == nil {
+				min = &lowest
+			}
+			if max == nil {
+				max = &highest
+			}
+		}
+		if err := checkFieldBounds(field, number, min, max); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"number": number}, nil
+
+	case "CURRENCY":
+		if raw == "" {
+			return map[string]interface{}{"number": nil}, nil
+		}
+		var amount, code string
+		for _, part := range strings.Fields(raw) {
+			if currencyPattern.MatchString(part) && code == "" {
+				if !common.IsCurrencyCode(part) {
+					return nil, fmt.Errorf("'%s': '%s' is not an ISO 4217 currency code", field.Name, part)
+				}
+				code = strings.ToUpper(part)
+			} else if amount == "" {
+				amount = part
+			} else {
+				return nil, fmt.Errorf("'%s' expects an amount and an optional currency code such as 125.50 EUR, got '%s'", field.Name, raw)
+			}
+		}
+		if amount == "" {
+			return nil, fmt.Errorf("'%s' expects an amount and an optional currency code such as 125.50 EUR, got '%s'", field.Name, raw)
+		}
+		number, err := parseNumber(amount)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkFieldBounds(field, number, field.Min, field.Max); err != nil {
+			return nil, err
+		}
+		if code == "" {
+			code = field.Currency
+		}
+		encoded := map[string]interface{}{"number": number}
+		if code != "" {
+			encoded["currency"] = code
+		}
+		return encoded, nil
+
+	case "CHECKBOX":
+		switch strings.ToLower(raw) {
+		case "true", "yes", "y", "1", "on", "checked":
+			return map[string]interface{}{"checked": true}, nil
+		case "false", "no", "n", "0", "off", "unchecked", "":
+			return map[string]interface{}{"checked": false}, nil
+		}
+		return nil, fmt.Errorf("'%s' is a checkbox and expects true or false, got '%s'", field.Name, raw)
+
+	case "SELECT_SINGLE":
+		if raw == "" {
+			return map[string]interface{}{"customFieldOptionId": nil}, nil
+		}
+		option, err := findCustomFieldOption(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"customFieldOptionId": option.ID}, nil
+
+	case "SELECT_MULTI":
+		refs := splitCustomFieldList(values)
+		// An option title may itself contain a comma
+		if len(values) == 1 && len(refs) > 1 {
+			if option, err := findCustomFieldOption(field, raw); err == nil {
+				return map[string]interface{}{"customFieldOptionIds": []string{option.ID}}, nil
+			}
+		}
+		ids := []string{}
+		for _, ref := range refs {
+			option, err := findCustomFieldOption(field, ref)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, option.ID)
+		}
+		return map[string]interface{}{"customFieldOptionIds": ids}, nil
+
+	case "DATE":
+		if raw == "" {
+			return map[string]interface{}{"startDate": nil, "endDate": nil}, nil
+		}
+		startExpr, endExpr := raw, raw
+		if parts := strings.SplitN(raw, "..", 2); len(parts) == 2 {
+			startExpr, endExpr = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		}
+		start, err := common.ParseDate(startExpr, field.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' expects a date or a range such as 2024-03-01..2024-03-15: %v", field.Name, err)
+		}
+		end, err := common.ParseDate(endExpr, field.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' expects a date or a range such as 2024-03-01..2024-03-15: %v", field.Name, err)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("'%s': the range ends (%s) before it starts (%s)", field.Name, endExpr, startExpr)
+		}
+		if bound, err := time.Parse(time.RFC3339, field.StartDate); err == nil && start.Before(bound) {
+			return nil, fmt.Errorf("'%s' must not be before %s, got %s", field.Name, bound.Format("2006-01-02"), startExpr)
+		}
+		if bound, err := time.Parse(time.RFC3339, field.EndDate); err == nil && end.After(bound) {
+			return nil, fmt.Errorf("'%s' must not be after %s, got %s", field.Name, bound.Format("2006-01-02"), endExpr)
+		}
+		encoded := map[string]interface{}{
+			"startDate": common.FormatAPIDate(start),
+			"endDate":   common.FormatAPIDate(end),
+		}
+		if field.Timezone != "" {
+			encoded["timezone"] = field.Timezone
+		}
+		return encoded, nil
+
+	case "LOCATION":
+		if raw == "" {
+			return map[string]interface{}{"latitude": nil, "longitude": nil, "text": ""}, nil
+		}
+		parts := splitCustomFieldList(values)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("'%s' expects latitude,longitude such as 51.5072,-0.1276, got '%s'", field.Name, raw)
+		}
+		latitude, err := parseNumber(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		longitude, err := parseNumber(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+			return nil, fmt.Errorf("'%s': %g,%g is not a valid position (latitude -90..90, longitude -180..180)", field.Name, latitude, longitude)
+		}
+		return map[string]interface{}{"latitude": latitude, "longitude": longitude}, nil
+
+	case "COUNTRY":
+		codes := []string{}
+		for _, code := range splitCustomFieldList(values) {
+			if !countryPattern.MatchString(code) || !common.IsCountryCode(code) {
+				return nil, fmt.Errorf("'%s' expects two-letter ISO country codes such as GB or US, got '%s'", field.Name, code)
+			}
+			code = strings.ToUpper(code)
+			if len(field.CountryCodes) > 0 && !containsFold(field.CountryCodes, code) {
+				return nil, fmt.Errorf("'%s' only allows %s, got '%s'", field.Name, strings.Join(field.CountryCodes, ", "), code)
+			}
+			codes = append(codes, code)
+		}
+		return map[string]interface{}{"countryCodes": codes, "text": strings.Join(codes, ", ")}, nil
+
+	case "REFERENCE":
+		ids := []string{}
+		for _, id := range splitCustomFieldList(values) {
+			if strings.ContainsAny(id, " \t") {
+				return nil, fmt.Errorf("'%s' expects record IDs, got '%s'", field.Name, id)
+			}
+			ids = append(ids, id)
+		}
+		return map[string]interface{}{"customFieldReferenceTodoIds": ids}, nil
+
+	case "FILE":
+		return nil, fmt.Errorf("'%s' is a FILE field; attach files with attach-field-file instead", field.Name)
+
+	default:
+		return nil, fmt.Errorf("'%s' is a %s field, whose value is calculated and can't be set", field.Name, field.Type)
+	}
+}
+
+// validateCustomFieldValues resolves each value's field by ID or name and checks the
+// value against the field's type and constraints. Unlike encodeCustomFieldValues it
+// carries on after a problem, so a whole batch can be reported at once.
+func validateCustomFieldValues(fields []common.CustomField, values []common.CustomFieldValue) ([]common.CustomFieldValue, []string) {
+	var encoded []common.CustomFieldValue
+	var problems []string
+	for _, value := range values {
+		field := findCustomField(fields, value.CustomFieldID)
+		if field == nil {
+			problems = append(problems, fmt.Sprintf("custom field '%s' not found in the project", value.CustomFieldID))
+			continue
+		}
+		input, err := encodeCustomFieldValue(field, value.Value)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		encoded = append(encoded, common.CustomFieldValue{CustomFieldID: field.ID, Value: input})
+	}
+	return encoded, problems
+}
+
+// encodeCustomFieldValues validates and encodes values, failing on any problem
+func encodeCustomFieldValues(fields []common.CustomField, values []common.CustomFieldValue) ([]common.CustomFieldValue, error) {
+	encoded, problems := validateCustomFieldValues(fields, values)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return encoded, nil
+}
+
+// printValidationProblems prints the problems found in a batch and returns the matching error
+func printValidationProblems(problems []string, simple bool) error {
+	for _, problem := range problems {
+		if simple {
+			fmt.Println(problem)
+		} else {
+			fmt.Printf("❌ %s\n", problem)
+		}
+	}
+	return fmt.Errorf("%d invalid custom field value(s)", len(problems))
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
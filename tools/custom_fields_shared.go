@@ -2,8 +2,7 @@ package tools
 
 import (
 	"fmt"
-	"strings"
-	
+
 	"demo-builder/common"
 )
 
@@ -12,64 +11,37 @@ type SetCustomFieldResponse struct {
 	SetTodoCustomField bool `json:"setTodoCustomField"`
 }
 
-// buildCustomFieldMutation builds individual custom field mutations
-func buildCustomFieldMutation(todoID string, cfv common.CustomFieldValue) string {
-	var valueStr string
-
-	switch v := cfv.Value.(type) {
-	case string:
-		// Check if it looks like an array of option IDs or titles for multi-select
-		if strings.Contains(v, ",") {
-			// Split and treat as array for multi-select
-			items := strings.Split(v, ",")
-			var arrayItems []string
-			for _, item := range items {
-				arrayItems = append(arrayItems, fmt.Sprintf(`"%s"`, strings.TrimSpace(item)))
-			}
-			valueStr = fmt.Sprintf(`customFieldOptionIds: [%s]`, strings.Join(arrayItems, ", "))
-		} else {
-			// Single value - could be text, option ID, or option title
-			valueStr = fmt.Sprintf(`text: "%s"`, strings.ReplaceAll(v, `"`, `\"`))
-		}
-	case float64:
-		valueStr = fmt.Sprintf(`number: %g`, v)
-	case bool:
-		valueStr = fmt.Sprintf(`checked: %t`, v)
-	case []string:
-		var arrayItems []string
-		for _, item := range v {
-			arrayItems = append(arrayItems, fmt.Sprintf(`"%s"`, strings.ReplaceAll(item, `"`, `\"`)))
-		}
-		valueStr = fmt.Sprintf(`customFieldOptionIds: [%s]`, strings.Join(arrayItems, ", "))
-	default:
-		// Fallback to text
-		valueStr = fmt.Sprintf(`text: "%v"`, v)
-	}
-	
-	return fmt.Sprintf(`
-		mutation SetTodoCustomField {
-			setTodoCustomField(input: {
-				todoId: "%s"
-				customFieldId: "%s"
-				%s
-			})
-		}
-	`, todoID, cfv.CustomFieldID, valueStr)
-}
-
-// executeSetCustomFields sets custom field values on a record
+// executeSetCustomFields sets custom field values on a record. The values must
+// have been encoded for their field types with encodeCustomFieldValues.
 func executeSetCustomFields(client *common.Client, todoID string, customFields []common.CustomFieldValue) error {
 	if len(customFields) == 0 {
 		return nil
 	}
-	
+
+	mutation := `
+		mutation SetTodoCustomField($input: SetTodoCustomFieldInput!) {
+			setTodoCustomField(input: $input)
+		}
+	`
+
 	for _, cfv := range customFields {
-		mutation := buildCustomFieldMutation(todoID, cfv)
+		encoded, ok := cfv.Value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("value of custom field %s was not encoded for its type", cfv.CustomFieldID)
+		}
+		input := map[string]interface{}{
+			"todoId":        todoID,
+			"customFieldId": cfv.CustomFieldID,
+		}
+		for key, value := range encoded {
+			input[key] = value
+		}
+
 		var response SetCustomFieldResponse
-		if err := client.ExecuteQueryWithResult(mutation, nil, &response); err != nil {
+		if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
 			return fmt.Errorf("failed to set custom field %s: %v", cfv.CustomFieldID, err)
 		}
 	}
-	
+
 	return nil
-}
\ No newline at end of file
+}
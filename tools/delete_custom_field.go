@@ -12,6 +12,26 @@ type DeleteCustomFieldResponse struct {
 	DeleteCustomField bool `json:"deleteCustomField"`
 }
 
+// Execute GraphQL mutation to delete a custom field
+func executeDeleteCustomField(client *common.Client, customFieldID string) (bool, error) {
+	mutation := `
+		mutation DeleteCustomField($id: String!) {
+			deleteCustomField(id: $id)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": customFieldID,
+	}
+
+	var result DeleteCustomFieldResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &result); err != nil {
+		return false, err
+	}
+
+	return result.DeleteCustomField, nil
+}
+
 // RunDeleteCustomField executes the delete custom field command
 func RunDeleteCustomField(args []string) error {
 	flagSet := flag.NewFlagSet("delete-custom-field", flag.ExitOnError)
@@ -66,24 +86,13 @@ func RunDeleteCustomField(args []string) error {
 		fmt.Printf("\n🚨 This will permanently delete this custom field and remove it from all records!\n\n")
 	}
 
-	mutation := `
-		mutation DeleteCustomField($id: String!) {
-			deleteCustomField(id: $id)
-		}
-	`
-
-	variables := map[string]interface{}{
-		"id": *customFieldID,
-	}
-
-	var result DeleteCustomFieldResponse
-	err = client.ExecuteQueryWithResult(mutation, variables, &result)
+	deleted, err := executeDeleteCustomField(client, *customFieldID)
 	if err != nil {
 		return fmt.Errorf("failed to execute query: %v", err)
 	}
 
 	// Output results
-	if result.DeleteCustomField {
+	if deleted {
 		if *simple {
 			fmt.Printf("✅ Deleted custom field %s\n", *customFieldID)
 		} else {
@@ -119,6 +128,8 @@ func getCustomFieldDetails(client *common.Client, customFieldID string) (*common
 					id
 					title
 					color
+					currencyConversionFrom
+					currencyConversionTo
 				}
 			}
 		}
@@ -13,6 +13,30 @@ type DeleteCustomFieldOptionResponse struct {
 	DeleteCustomFieldOption bool `json:"deleteCustomFieldOption"`
 }
 
+// Execute GraphQL mutation to delete a single custom field option
+func executeDeleteCustomFieldOption(client *common.Client, customFieldID, optionID, todoID string) (bool, error) {
+	mutation := `
+		mutation DeleteCustomFieldOption($customFieldId: String!, $optionId: String!, $todoId: String) {
+			deleteCustomFieldOption(customFieldId: $customFieldId, optionId: $optionId, todoId: $todoId)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"customFieldId": customFieldID,
+		"optionId":      optionID,
+	}
+	if todoID != "" {
+		variables["todoId"] = todoID
+	}
+
+	var result DeleteCustomFieldOptionResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &result); err != nil {
+		return false, err
+	}
+
+	return result.DeleteCustomFieldOption, nil
+}
+
 // RunDeleteCustomFieldOptions executes the delete custom field options command
 func RunDeleteCustomFieldOptions(args []string) error {
 	flagSet := flag.NewFlagSet("delete-custom-field-options", flag.ExitOnError)
@@ -72,12 +96,6 @@ func RunDeleteCustomFieldOptions(args []string) error {
 		return fmt.Errorf("no valid options found to delete")
 	}
 
-	mutation := `
-		mutation DeleteCustomFieldOption($customFieldId: String!, $optionId: String!, $todoId: String) {
-			deleteCustomFieldOption(customFieldId: $customFieldId, optionId: $optionId, todoId: $todoId)
-		}
-	`
-
 	var deletedCount int
 	var errors []string
 
@@ -90,22 +108,13 @@ func RunDeleteCustomFieldOptions(args []string) error {
 			continue
 		}
 
-		variables := map[string]interface{}{
-			"customFieldId": *customFieldID,
-			"optionId":      optionID,
-		}
-		if *todoID != "" {
-			variables["todoId"] = *todoID
-		}
-
-		var result DeleteCustomFieldOptionResponse
-		err := client.ExecuteQueryWithResult(mutation, variables, &result)
+		deleted, err := executeDeleteCustomFieldOption(client, *customFieldID, optionID, *todoID)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("Failed to delete option %s: %v", optionID, err))
 			if !*simple {
 				fmt.Printf("❌ Failed to delete option %s: %v\n", optionID, err)
 			}
-		} else if result.DeleteCustomFieldOption {
+		} else if deleted {
 			deletedCount++
 			if !*simple {
 				fmt.Printf("✅ Deleted option %s\n", optionID)
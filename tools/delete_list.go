@@ -17,10 +17,59 @@ type DeleteTodoListResponse struct {
 	DeleteTodoList common.MutationResult `json:"deleteTodoList"`
 }
 
+// Execute GraphQL mutation to delete a list
+func executeDeleteTodoList(client *common.Client, input DeleteTodoListInput) (*common.MutationResult, error) {
+	mutation := `
+		mutation DeleteTodoList($input: DeleteTodoListInput!) {
+			deleteTodoList(input: $input) {
+				success
+				operationId
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response DeleteTodoListResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.DeleteTodoList, nil
+}
+
+// executeMoveListRecords moves every record of one list into another list
+func executeMoveListRecords(client *common.Client, fromListID, toListID string) error {
+	mutation := `
+		mutation MoveListRecords($input: UpdateTodosInput!) {
+			updateTodos(input: $input)
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"todoListId": toListID,
+			"filter": map[string]interface{}{
+				"todoListId": fromListID,
+			},
+		},
+	}
+
+	var response MoveRecordResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+	if !response.UpdateTodos {
+		return fmt.Errorf("updateTodos returned false")
+	}
+	return nil
+}
+
 func RunDeleteList(args []string) error {
 	fs := flag.NewFlagSet("delete-list", flag.ExitOnError)
 	projectID := fs.String("project", "", "Project ID (required)")
 	listID := fs.String("list", "", "List ID (required)")
+	moveTo := fs.String("move-to", "", "Move the list's records to this list (ID or title) before deleting")
 	confirm := fs.Bool("confirm", false, "Confirm deletion (required for safety)")
 	simple := fs.Bool("simple", false, "Simple output format")
 	fs.Parse(args)
@@ -41,7 +90,8 @@ func RunDeleteList(args []string) error {
 	if !*confirm {
 		fmt.Println("Error: -confirm flag is required for safety")
 		fmt.Println("This will permanently delete the todo list and may affect records in this list.")
-		fmt.Println("Usage: go run . delete-list -project PROJECT_ID -list LIST_ID -confirm")
+		fmt.Println("Use -move-to to keep the records by moving them to another list first.")
+		fmt.Println("Usage: go run . delete-list -project PROJECT_ID -list LIST_ID [-move-to LIST] -confirm")
 		return fmt.Errorf("confirmation required for deletion")
 	}
 
@@ -57,6 +107,33 @@ func RunDeleteList(args []string) error {
 	// Set project context
 	client.SetProjectID(*projectID)
 
+	// Move records out of the list first
+	if *moveTo != "" {
+		lists, err := fetchProjectLists(client, *projectID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch lists: %v", err)
+		}
+		dest := findListByIDOrTitle(lists, *moveTo)
+		if dest == nil {
+			return fmt.Errorf("list '%s' not found in project %s", *moveTo, *projectID)
+		}
+		if dest.ID == *listID {
+			return fmt.Errorf("-move-to must be a different list")
+		}
+		recordIDs, err := fetchListRecordIDs(client, *listID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch records: %v", err)
+		}
+		if len(recordIDs) > 0 {
+			if err := executeMoveListRecords(client, *listID, dest.ID); err != nil {
+				return fmt.Errorf("failed to move records to '%s' (list not deleted): %v", dest.Title, err)
+			}
+			if !*simple {
+				fmt.Printf("Moved %d record(s) to '%s'\n", len(recordIDs), dest.Title)
+			}
+		}
+	}
+
 	// Prepare input
 	input := DeleteTodoListInput{
 		ProjectID:  *projectID,
@@ -64,25 +141,12 @@ func RunDeleteList(args []string) error {
 	}
 
 	// Execute mutation
-	mutation := `
-		mutation DeleteTodoList($input: DeleteTodoListInput!) {
-			deleteTodoList(input: $input) {
-				success
-				operationId
-			}
-		}`
-
-	variables := map[string]interface{}{
-		"input": input,
-	}
-
-	var response DeleteTodoListResponse
-	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+	result, err := executeDeleteTodoList(client, input)
+	if err != nil {
 		return fmt.Errorf("failed to delete list: %v", err)
 	}
 
 	// Display results
-	result := response.DeleteTodoList
 	if result.Success {
 		if *simple {
 			fmt.Printf("List %s deleted successfully\n", *listID)
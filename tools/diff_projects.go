@@ -0,0 +1,327 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// ProjectDifference is one difference between two projects
+type ProjectDifference struct {
+	Section  string `json:"section"`
+	Name     string `json:"name"`
+	Change   string `json:"change"` // only-in-a, only-in-b or changed
+	Property string `json:"property,omitempty"`
+	A        string `json:"a,omitempty"`
+	B        string `json:"b,omitempty"`
+}
+
+// ProjectDiff is the result of comparing two projects
+type ProjectDiff struct {
+	ProjectA    string              `json:"projectA"`
+	ProjectB    string              `json:"projectB"`
+	Differences []ProjectDifference `json:"differences"`
+}
+
+// Sections compared by diff-projects, in report order
+var diffSections = []string{"features", "lists", "tags", "field groups", "custom fields", "automations"}
+
+// onlyInA records an item that exists only in the first project
+func (d *ProjectDiff) onlyInA(section, name string) {
+	d.Differences = append(d.Differences, ProjectDifference{Section: section, Name: name, Change: "only-in-a"})
+}
+
+// onlyInB records an item that exists only in the second project
+func (d *ProjectDiff) onlyInB(section, name string) {
+	d.Differences = append(d.Differences, ProjectDifference{Section: section, Name: name, Change: "only-in-b"})
+}
+
+// changed records a property that differs between the projects
+func (d *ProjectDiff) changed(section, name, property, a, b string) {
+	if a == b {
+		return
+	}
+	d.Differences = append(d.Differences, ProjectDifference{Section: section, Name: name, Change: "changed", Property: property, A: a, B: b})
+}
+
+// optionalFloat formats an optional number for the report
+func optionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("%g", *f)
+}
+
+// formulaText returns a canonical JSON form of a formula so it can be compared
+func formulaText(formula interface{}) string {
+	if formula == nil {
+		return ""
+	}
+	data, err := json.Marshal(formula)
+	if err != nil {
+		return fmt.Sprint(formula)
+	}
+	return string(data)
+}
+
+// diffProjectStates compares two projects by name
+func diffProjectStates(a, b *ProjectState) *ProjectDiff {
+	diff := &ProjectDiff{ProjectA: a.Project.Name, ProjectB: b.Project.Name}
+	specA, _ := projectStateToSpec(a)
+	specB, _ := projectStateToSpec(b)
+
+	for _, featureType := range featureTypes {
+		diff.changed("features", featureType, "enabled",
+			enabledLabel(specA.Features[featureType]), enabledLabel(specB.Features[featureType]))
+	}
+
+	// Lists
+	var commonA, commonB []string
+	for _, list := range specA.Lists {
+		if b.findList(list.Title) == nil {
+			diff.onlyInA("lists", list.Title)
+			continue
+		}
+		commonA = append(commonA, list.Title)
+	}
+	for _, list := range specB.Lists {
+		live := a.findList(list.Title)
+		if live == nil {
+			diff.onlyInB("lists", list.Title)
+			continue
+		}
+		commonB = append(commonB, list.Title)
+		diff.changed("lists", list.Title, "locked", fmt.Sprintf("%t", live.IsLocked), boolValue(list.Locked))
+	}
+	diff.changed("lists", "(all)", "order", strings.Join(commonA, ", "), strings.Join(commonB, ", "))
+
+	// Tags
+	for _, tag := range specA.Tags {
+		if b.findTag(tag.Title) == nil {
+			diff.onlyInA("tags", tag.Title)
+		}
+	}
+	for _, tag := range specB.Tags {
+		live := a.findTag(tag.Title)
+		if live == nil {
+			diff.onlyInB("tags", tag.Title)
+			continue
+		}
+		diff.changed("tags", tag.Title, "color", live.Color, tag.Color)
+	}
+
+	// Field groups
+	groupsA := make(map[string]FieldGroupSpec)
+	for _, group := range specA.FieldGroups {
+		groupsA[specKey(group.Name)] = group
+	}
+	groupsB := make(map[string]bool)
+	for _, group := range specB.FieldGroups {
+		groupsB[specKey(group.Name)] = true
+		live, ok := groupsA[specKey(group.Name)]
+		if !ok {
+			diff.onlyInB("field groups", group.Name)
+			continue
+		}
+		diff.changed("field groups", group.Name, "color", live.Color, group.Color)
+	}
+	for _, group := range specA.FieldGroups {
+		if !groupsB[specKey(group.Name)] {
+			diff.onlyInA("field groups", group.Name)
+		}
+	}
+
+	// Custom fields
+	fieldsA := make(map[string]CustomFieldSpec)
+	for _, field := range specA.CustomFields {
+		fieldsA[specKey(field.Name)] = field
+		if b.findCustomField(field.Name) == nil {
+			diff.onlyInA("custom fields", field.Name)
+		}
+	}
+	for _, field := range specB.CustomFields {
+		live, ok := fieldsA[specKey(field.Name)]
+		if !ok {
+			diff.onlyInB("custom fields", field.Name)
+			continue
+		}
+		diff.changed("custom fields", field.Name, "type", live.Type, field.Type)
+		diff.changed("custom fields", field.Name, "description", live.Description, field.Description)
+		diff.changed("custom fields", field.Name, "group", live.Group, field.Group)
+		diff.changed("custom fields", field.Name, "min", optionalFloat(live.Min), optionalFloat(field.Min))
+		diff.changed("custom fields", field.Name, "max", optionalFloat(live.Max), optionalFloat(field.Max))
+		diff.changed("custom fields", field.Name, "currency", live.Currency, field.Currency)
+		diff.changed("custom fields", field.Name, "prefix", live.Prefix, field.Prefix)
+		diff.changed("custom fields", field.Name, "formula",
+			formulaText(a.findCustomField(field.Name).Formula), formulaText(b.findCustomField(field.Name).Formula))
+
+		liveOptions := make(map[string]OptionSpec)
+		for _, option := range live.Options {
+			liveOptions[specKey(option.Title)] = option
+		}
+		seen := make(map[string]bool)
+		for _, option := range field.Options {
+			seen[specKey(option.Title)] = true
+			liveOption, ok := liveOptions[specKey(option.Title)]
+			if !ok {
+				diff.onlyInB("custom fields", fmt.Sprintf("%s / %s", field.Name, option.Title))
+				continue
+			}
+			diff.changed("custom fields", fmt.Sprintf("%s / %s", field.Name, option.Title), "color", liveOption.Color, option.Color)
+		}
+		for _, option := range live.Options {
+			if !seen[specKey(option.Title)] {
+				diff.onlyInA("custom fields", fmt.Sprintf("%s / %s", field.Name, option.Title))
+			}
+		}
+	}
+
+	// Automations have no name, so they are matched on what they do
+	automationsA := make(map[string]AutomationSpec)
+	for _, automation := range specA.Automations {
+		automationsA[automationSignature(automation.Trigger, automation.Actions)] = automation
+	}
+	automationsB := make(map[string]bool)
+	for _, automation := range specB.Automations {
+		signature := automationSignature(automation.Trigger, automation.Actions)
+		automationsB[signature] = true
+		live, ok := automationsA[signature]
+		if !ok {
+			diff.onlyInB("automations", describeAutomationSpec(automation))
+			continue
+		}
+		diff.changed("automations", describeAutomationSpec(automation), "active",
+			boolValue(live.Active), boolValue(automation.Active))
+	}
+	for _, automation := range specA.Automations {
+		if !automationsB[automationSignature(automation.Trigger, automation.Actions)] {
+			diff.onlyInA("automations", describeAutomationSpec(automation))
+		}
+	}
+
+	return diff
+}
+
+// printProjectDiff prints a diff grouped by section
+func printProjectDiff(diff *ProjectDiff, simple bool) {
+	if simple {
+		for _, d := range diff.Differences {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", d.Section, d.Name, d.Change, d.Property, d.A, d.B)
+		}
+		return
+	}
+
+	fmt.Printf("=== Diff: %s ↔ %s ===\n", diff.ProjectA, diff.ProjectB)
+	fmt.Printf("  - only in %s, + only in %s, ~ changed\n", diff.ProjectA, diff.ProjectB)
+
+	for _, section := range diffSections {
+		var lines []string
+		for _, d := range diff.Differences {
+			if d.Section != section {
+				continue
+			}
+			switch d.Change {
+			case "only-in-a":
+				lines = append(lines, fmt.Sprintf("  - %q", d.Name))
+			case "only-in-b":
+				lines = append(lines, fmt.Sprintf("  + %q", d.Name))
+			default:
+				lines = append(lines, fmt.Sprintf("  ~ %q %s: %s → %s", d.Name, d.Property, orNone(d.A), orNone(d.B)))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s%s:\n", strings.ToUpper(section[:1]), section[1:])
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Println()
+	if len(diff.Differences) == 0 {
+		fmt.Println("✅ No drift: the projects match")
+	} else {
+		fmt.Printf("⚠️  %d difference(s) found\n", len(diff.Differences))
+	}
+}
+
+// orNone shows empty values explicitly in the report
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// RunDiffProjects compares the configuration of two projects
+func RunDiffProjects(args []string) error {
+	fs := flag.NewFlagSet("diff-projects", flag.ExitOnError)
+	format := fs.String("format", "human", "Output format: human or json")
+	failOnDrift := fs.Bool("fail-on-drift", false, "Exit with an error status when the projects differ")
+	simple := fs.Bool("simple", false, "Simple output format (one tab-separated line per difference)")
+
+	// Allow flags before, between and after the two project arguments
+	var projects []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		projects = append(projects, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(projects) != 2 {
+		fmt.Println("Usage: go run . diff-projects <project-a> <project-b> [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . diff-projects golden-template acme-onboarding")
+		fmt.Println("  go run . diff-projects golden-template acme-onboarding -format json")
+		fmt.Println("  go run . diff-projects golden-template acme-onboarding -fail-on-drift -simple")
+		return fmt.Errorf("two projects are required")
+	}
+	if *format != "human" && *format != "json" {
+		return fmt.Errorf("invalid format '%s' (valid: human, json)", *format)
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+
+	var states []*ProjectState
+	for _, project := range projects {
+		client.SetProject(project)
+		state, err := fetchProjectState(client, project)
+		if err != nil {
+			return fmt.Errorf("failed to read project '%s': %v", project, err)
+		}
+		states = append(states, state)
+	}
+
+	diff := diffProjectStates(states[0], states[1])
+	if diff.Differences == nil {
+		diff.Differences = []ProjectDifference{}
+	}
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff: %v", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printProjectDiff(diff, *simple)
+	}
+
+	if *failOnDrift && len(diff.Differences) > 0 {
+		return fmt.Errorf("drift detected: %d difference(s) between '%s' and '%s'", len(diff.Differences), diff.ProjectA, diff.ProjectB)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"demo-builder/common"
+)
+
+// fieldFileEntries lays out the files of FILE fields as "Record [id]/Field/file" zip entries,
+// numbering files whose names clash within a folder
+func fieldFileEntries(records []FileFieldRecord, fieldID string) []zipEntry {
+	var entries []zipEntry
+	for _, record := range records {
+		title := record.Title
+		if strings.TrimSpace(title) == "" {
+			title = "Untitled"
+		}
+		recordDir := sanitizeFilename(fmt.Sprintf("%s [%s]", title, record.ID))
+		for _, field := range record.Fields {
+			if fieldID != "" && field.FieldID != fieldID {
+				continue
+			}
+			dir := path.Join(recordDir, sanitizeFilename(field.FieldName))
+			used := make(map[string]bool)
+			for _, file := range field.Files {
+				name := sanitizeFilename(fileNameWithExtension(file))
+				ext := path.Ext(name)
+				base := strings.TrimSuffix(name, ext)
+				for n := 2; used[strings.ToLower(name)]; n++ {
+					name = fmt.Sprintf("%s (%d)%s", base, n, ext)
+				}
+				used[strings.ToLower(name)] = true
+				entries = append(entries, zipEntry{Path: path.Join(dir, name), File: file})
+			}
+		}
+	}
+	return entries
+}
+
+// RunDownloadFieldFiles downloads the files in FILE custom fields into a zip archive organised by record
+func RunDownloadFieldFiles(args []string) error {
+	fs := flag.NewFlagSet("download-field-files", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID to download the field files of")
+	projectID := fs.String("project", "", "Project ID or slug to download the field files of every record")
+	fieldRef := fs.String("field", "", "Only download files of this FILE custom field (ID or name)")
+	outputPath := fs.String("output", "", "Output path for zip file (default: blue-field-files-TIMESTAMP.zip)")
+	parallel := fs.Int("parallel", 5, "Number of concurrent downloads")
+	fs.Parse(args)
+
+	if *recordID == "" && *projectID == "" {
+		fmt.Println("Usage: go run . download-field-files (-record RECORD_ID | -project PROJECT) [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Download everything attached to one record")
+		fmt.Println("  go run . download-field-files -record RECORD_ID")
+		fmt.Println("")
+		fmt.Println("  # Download the contracts of every record in a project")
+		fmt.Println("  go run . download-field-files -project PROJECT_ID -field \"Contract\" -output contracts.zip")
+		return fmt.Errorf("required flags missing")
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+
+	if *projectID == "" {
+		if *projectID, err = getProjectIDFromRecord(client, *recordID); err != nil {
+			return err
+		}
+	}
+	client.SetProject(*projectID)
+
+	// Resolve the field filter against the project's FILE fields
+	var fieldID string
+	if *fieldRef != "" {
+		fields, err := fetchProjectCustomFields(client, *projectID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch custom fields: %v", err)
+		}
+		field := findCustomField(fields, *fieldRef)
+		if field == nil {
+			return fmt.Errorf("custom field '%s' not found in project", *fieldRef)
+		}
+		if field.Type != "FILE" {
+			return fmt.Errorf("'%s' is a %s field, not a FILE field", field.Name, field.Type)
+		}
+		fieldID = field.ID
+	}
+
+	var records []FileFieldRecord
+	if *recordID != "" {
+		record, err := fetchFileFieldRecord(client, *recordID)
+		if err != nil {
+			return err
+		}
+		records = append(records, *record)
+		common.PrintInfo(fmt.Sprintf("Fetching field files of record: %s", record.Title))
+	} else {
+		common.PrintInfo(fmt.Sprintf("Fetching field files from project: %s", *projectID))
+		if records, err = fetchProjectFileFieldRecords(client, *projectID); err != nil {
+			return err
+		}
+	}
+
+	entries := fieldFileEntries(records, fieldID)
+	if len(entries) == 0 {
+		common.PrintInfo("No field files found")
+		return nil
+	}
+	common.PrintSuccess(fmt.Sprintf("Found %d file(s)", len(entries)))
+
+	zipPath := *outputPath
+	if zipPath == "" {
+		timestamp := time.Now().Format("20060102-150405")
+		zipPath = fmt.Sprintf("blue-field-files-%s.zip", timestamp)
+	}
+
+	if err := downloadAndZipEntries(client, entries, zipPath, *parallel); err != nil {
+		return fmt.Errorf("failed to download files: %w", err)
+	}
+
+	common.PrintSuccess(fmt.Sprintf("Files downloaded and zipped to: %s", zipPath))
+	return nil
+}
@@ -230,8 +230,32 @@ func fetchFiles(client *Client, companyID, projectID, folderID string) ([]File,
 	return response.Files.Items, nil
 }
 
+// zipEntry is a file to download and the path it gets inside the zip archive
+type zipEntry struct {
+	Path string
+	File File
+}
+
+// fileNameWithExtension returns the name of a file with its extension appended when missing
+func fileNameWithExtension(file File) string {
+	filename := file.Name
+	if file.Extension != "" && !strings.HasSuffix(strings.ToLower(filename), strings.ToLower(file.Extension)) {
+		filename = fmt.Sprintf("%s.%s", filename, file.Extension)
+	}
+	return filename
+}
+
 // downloadAndZipFiles downloads all files and creates a zip archive
 func downloadAndZipFiles(client *Client, files []File, zipPath string, parallel int) error {
+	entries := make([]zipEntry, len(files))
+	for i, file := range files {
+		entries[i] = zipEntry{Path: sanitizeFilename(fileNameWithExtension(file)), File: file}
+	}
+	return downloadAndZipEntries(client, entries, zipPath, parallel)
+}
+
+// downloadAndZipEntries downloads files concurrently and writes each one to its path in a zip archive
+func downloadAndZipEntries(client *Client, entries []zipEntry, zipPath string, parallel int) error {
 	// Validate parallel parameter
 	if parallel < 1 {
 		parallel = 1
@@ -250,7 +274,7 @@ func downloadAndZipFiles(client *Client, files []File, zipPath string, parallel
 	// Create channel for jobs and results
 	type downloadJob struct {
 		index int
-		file  File
+		entry zipEntry
 	}
 
 	type downloadResult struct {
@@ -260,8 +284,8 @@ func downloadAndZipFiles(client *Client, files []File, zipPath string, parallel
 		err      error
 	}
 
-	jobs := make(chan downloadJob, len(files))
-	results := make(chan downloadResult, len(files))
+	jobs := make(chan downloadJob, len(entries))
+	results := make(chan downloadResult, len(entries))
 
 	// Start worker goroutines
 	var wg sync.WaitGroup
@@ -270,21 +294,15 @@ func downloadAndZipFiles(client *Client, files []File, zipPath string, parallel
 		go func() {
 			defer wg.Done()
 			for job := range jobs {
-				PrintInfo(fmt.Sprintf("[%d/%d] Downloading: %s", job.index+1, len(files), job.file.Name))
+				PrintInfo(fmt.Sprintf("[%d/%d] Downloading: %s", job.index+1, len(entries), job.entry.File.Name))
 
 				// Download file
-				fileURL := fmt.Sprintf("https://api.blue.cc/uploads/%s", job.file.UID)
+				fileURL := fmt.Sprintf("https://api.blue.cc/uploads/%s", job.entry.File.UID)
 				data, err := client.DownloadFile(fileURL)
 
-				// Determine filename with extension
-				filename := job.file.Name
-				if job.file.Extension != "" && !strings.HasSuffix(strings.ToLower(filename), strings.ToLower(job.file.Extension)) {
-					filename = fmt.Sprintf("%s.%s", filename, job.file.Extension)
-				}
-
 				results <- downloadResult{
 					index:    job.index,
-					filename: filename,
+					filename: job.entry.Path,
 					data:     data,
 					err:      err,
 				}
@@ -293,8 +311,8 @@ func downloadAndZipFiles(client *Client, files []File, zipPath string, parallel
 	}
 
 	// Send jobs
-	for i, file := range files {
-		jobs <- downloadJob{index: i, file: file}
+	for i, entry := range entries {
+		jobs <- downloadJob{index: i, entry: entry}
 	}
 	close(jobs)
 
@@ -319,7 +337,7 @@ func downloadAndZipFiles(client *Client, files []File, zipPath string, parallel
 
 		// Thread-safe zip writing
 		zipMutex.Lock()
-		writer, err := zipWriter.Create(sanitizeFilename(result.filename))
+		writer, err := zipWriter.Create(result.filename)
 		if err != nil {
 			zipMutex.Unlock()
 			PrintError(fmt.Sprintf("Failed to add %s to zip: %v", result.filename, err))
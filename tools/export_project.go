@@ -0,0 +1,401 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"demo-builder/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportRecord is a record as returned by the export query
+type ExportRecord struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Text      string  `json:"text"`
+	StartedAt *string `json:"startedAt"`
+	DuedAt    *string `json:"duedAt"`
+	Color     *string `json:"color"`
+	Archived  bool    `json:"archived"`
+	Tags      []struct {
+		Title string `json:"title"`
+	} `json:"tags"`
+	CustomFields []struct {
+		ID             string   `json:"id"`
+		Type           string   `json:"type"`
+		Text           *string  `json:"text"`
+		Number         *float64 `json:"number"`
+		Checked        *bool    `json:"checked"`
+		SelectedOption *struct {
+			Title string `json:"title"`
+		} `json:"selectedOption"`
+		SelectedOptions []struct {
+			Title string `json:"title"`
+		} `json:"selectedOptions"`
+	} `json:"customFields"`
+	Checklists []struct {
+		Title          string `json:"title"`
+		ChecklistItems []struct {
+			Title string `json:"title"`
+		} `json:"checklistItems"`
+	} `json:"checklists"`
+	Comments []struct {
+		Text string `json:"text"`
+	} `json:"comments"`
+}
+
+// Custom field types whose values are computed or point at other objects,
+// so they cannot be carried over to another project
+var nonPortableFieldTypes = map[string]bool{
+	"FORMULA": true, "LOOKUP": true, "REFERENCE": true, "UNIQUE_ID": true,
+	"FILE": true, "BUTTON": true, "TIME_DURATION": true, "CURRENCY_CONVERSION": true,
+}
+
+// projectStateToSpec converts the live configuration of a project to a by-name
+// spec. The returned warnings describe anything that could not be exported.
+func projectStateToSpec(state *ProjectState) (*ProjectSpec, []string) {
+	var warnings []string
+	project := state.Project
+	spec := &ProjectSpec{
+		Project: ProjectSettingsSpec{
+			Name:                    project.Name,
+			Description:             project.Description,
+			Color:                   project.Color,
+			Icon:                    project.Icon,
+			Category:                project.Category,
+			TodoAlias:               project.TodoAlias,
+			HideRecordCount:         boolPtr(project.HideRecordCount),
+			ShowTimeSpentInTodoList: boolPtr(project.ShowTimeSpentInTodoList),
+			ShowTimeSpentInProject:  boolPtr(project.ShowTimeSpentInProject),
+		},
+		Features: make(map[string]bool),
+	}
+
+	// Features default to enabled when the project does not report them
+	for _, featureType := range featureTypes {
+		spec.Features[featureType] = true
+	}
+	for _, feature := range project.Features {
+		if featureType := normalizeFeatureType(feature.Type); featureType != "" {
+			spec.Features[featureType] = feature.Enabled
+		}
+	}
+
+	for _, list := range state.Lists {
+		spec.Lists = append(spec.Lists, ListSpec{Title: list.Title, Locked: boolPtr(list.IsLocked)})
+	}
+
+	for _, tag := range state.Tags {
+		spec.Tags = append(spec.Tags, TagSpec{Title: tag.Title, Color: tag.Color})
+	}
+
+	for _, group := range state.fieldGroups() {
+		if group.Name == nil {
+			continue
+		}
+		groupSpec := FieldGroupSpec{Name: *group.Name}
+		if group.Color != nil {
+			groupSpec.Color = *group.Color
+		}
+		spec.FieldGroups = append(spec.FieldGroups, groupSpec)
+	}
+
+	for _, field := range state.CustomFields {
+		fieldSpec := CustomFieldSpec{
+			Name:        field.Name,
+			Type:        field.Type,
+			Description: field.Description,
+			Group:       state.fieldGroupOf(field.ID),
+			Min:         field.Min,
+			Max:         field.Max,
+			Currency:    field.Currency,
+			Prefix:      field.Prefix,
+		}
+		for _, option := range field.Options {
+			fieldSpec.Options = append(fieldSpec.Options, OptionSpec{Title: option.Title, Color: option.Color})
+		}
+		if nonPortableFieldTypes[field.Type] {
+			warnings = append(warnings, fmt.Sprintf("custom field '%s' is %s; only its name and type are exported", field.Name, field.Type))
+		}
+		spec.CustomFields = append(spec.CustomFields, fieldSpec)
+	}
+
+	for _, role := range state.Roles {
+		spec.Roles = append(spec.Roles, RoleSpec{
+			Name:                      role.Name,
+			Description:               role.Description,
+			AllowInviteOthers:         boolPtr(role.AllowInviteOthers),
+			AllowMarkRecordsAsDone:    boolPtr(role.AllowMarkRecordsAsDone),
+			ShowOnlyAssignedTodos:     boolPtr(role.ShowOnlyAssignedTodos),
+			ShowOnlyMentionedComments: boolPtr(role.ShowOnlyMentionedComments),
+			CanDeleteRecords:          boolPtr(role.CanDeleteRecords),
+			IsActivityEnabled:         boolPtr(role.IsActivityEnabled),
+			IsChatEnabled:             boolPtr(role.IsChatEnabled),
+			IsDocsEnabled:             boolPtr(role.IsDocsEnabled),
+			IsFormsEnabled:            boolPtr(role.IsFormsEnabled),
+			IsWikiEnabled:             boolPtr(role.IsWikiEnabled),
+			IsFilesEnabled:            boolPtr(role.IsFilesEnabled),
+			IsRecordsEnabled:          boolPtr(role.IsRecordsEnabled),
+			IsPeopleEnabled:           boolPtr(role.IsPeopleEnabled),
+		})
+	}
+
+	for _, item := range state.Automations {
+		automation := automationToSpec(item)
+		// Assignees are user IDs, which mean nothing in another company
+		for i := range automation.Actions {
+			if len(automation.Actions[i].Assignees) > 0 {
+				warnings = append(warnings, fmt.Sprintf("automation '%s': assignees dropped (user IDs are not portable)", describeAutomationSpec(automation)))
+				automation.Actions[i].Assignees = nil
+			}
+		}
+		spec.Automations = append(spec.Automations, automation)
+	}
+
+	return spec, warnings
+}
+
+// fetchExportRecords loads the records of a list in spec form
+func fetchExportRecords(client *common.Client, state *ProjectState, list common.TodoList, withComments bool) ([]RecordSpec, error) {
+	commentsQuery := ""
+	if withComments {
+		commentsQuery = `
+		comments(orderBy: createdAt_ASC) {
+			text
+		}`
+	}
+
+	selection := `
+		id
+		title
+		text
+		startedAt
+		duedAt
+		color
+		archived
+		tags {
+			title
+		}
+		customFields {
+			id
+			type
+			text
+			number
+			checked
+			selectedOption {
+				title
+			}
+			selectedOptions {
+				title
+			}
+		}
+		checklists(orderBy: position_ASC) {
+			title
+			checklistItems(orderBy: position_ASC) {
+				title
+			}
+		}` + commentsQuery
+
+	todos, err := fetchListTodos[ExportRecord](client, list.ID, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldNames := make(map[string]string)
+	for _, field := range state.CustomFields {
+		fieldNames[field.ID] = field.Name
+	}
+
+	var records []RecordSpec
+	for _, todo := range todos {
+		if todo.Archived {
+			continue
+		}
+		record := RecordSpec{Title: todo.Title, List: list.Title, Description: todo.Text}
+		if todo.StartedAt != nil {
+			record.Start = *todo.StartedAt
+		}
+		if todo.DuedAt != nil {
+			record.Due = *todo.DuedAt
+		}
+		if todo.Color != nil {
+			record.Color = *todo.Color
+		}
+		for _, tag := range todo.Tags {
+			record.Tags = append(record.Tags, tag.Title)
+		}
+
+		for _, value := range todo.CustomFields {
+			name, ok := fieldNames[value.ID]
+			if !ok || nonPortableFieldTypes[value.Type] {
+				continue
+			}
+			var exported interface{}
+			switch value.Type {
+			case "SELECT_SINGLE":
+				if value.SelectedOption != nil {
+					exported = value.SelectedOption.Title
+				}
+			case "SELECT_MULTI":
+				if len(value.SelectedOptions) > 0 {
+					var titles []string
+					for _, option := range value.SelectedOptions {
+						titles = append(titles, option.Title)
+					}
+					exported = titles
+				}
+			case "CHECKBOX":
+				if value.Checked != nil {
+					exported = *value.Checked
+				}
+			case "NUMBER", "CURRENCY", "PERCENT", "RATING":
+				if value.Number != nil {
+					exported = *value.Number
+				}
+			default:
+				if value.Text != nil && *value.Text != "" {
+					exported = *value.Text
+				}
+			}
+			if exported == nil {
+				continue
+			}
+			if record.Fields == nil {
+				record.Fields = make(map[string]interface{})
+			}
+			record.Fields[name] = exported
+		}
+
+		for _, checklist := range todo.Checklists {
+			checklistSpec := RecordChecklistSpec{Title: checklist.Title}
+			for _, item := range checklist.ChecklistItems {
+				checklistSpec.Items = append(checklistSpec.Items, item.Title)
+			}
+			record.Checklists = append(record.Checklists, checklistSpec)
+		}
+		for _, comment := range todo.Comments {
+			record.Comments = append(record.Comments, comment.Text)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// marshalProjectSpec encodes a spec as YAML or JSON
+func marshalProjectSpec(spec *ProjectSpec, format string) ([]byte, error) {
+	if format == "json" {
+		data, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	return yaml.Marshal(spec)
+}
+
+// RunExportProject writes a project's configuration to a spec file that plan and apply can use
+func RunExportProject(args []string) error {
+	fs := flag.NewFlagSet("export-project", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug to export (required)")
+	output := fs.String("output", "", "File to write (default: print to stdout)")
+	format := fs.String("format", "", "Output format: yaml or json (default: from -output extension, else yaml)")
+	withRecords := fs.Bool("records", false, "Also export records with their checklists")
+	withComments := fs.Bool("comments", false, "Also export record comments (implies -records)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" {
+		fmt.Println("Usage: go run . export-project -project PROJECT [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . export-project -project my-project -output my-project.yaml")
+		fmt.Println("  go run . export-project -project my-project -records -comments -output seed.json")
+		fmt.Println("  go run . export-project -project my-project -format json > my-project.json")
+		return fmt.Errorf("required flags missing")
+	}
+
+	outputFormat := strings.ToLower(*format)
+	if outputFormat == "" {
+		outputFormat = "yaml"
+		if strings.EqualFold(filepath.Ext(*output), ".json") {
+			outputFormat = "json"
+		}
+	}
+	if outputFormat == "yml" {
+		outputFormat = "yaml"
+	}
+	if outputFormat != "yaml" && outputFormat != "json" {
+		return fmt.Errorf("invalid format '%s' (valid: yaml, json)", *format)
+	}
+	if *withComments {
+		*withRecords = true
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	state, err := fetchProjectState(client, *projectID)
+	if err != nil {
+		return err
+	}
+	client.SetProjectID(state.Project.ID)
+
+	spec, warnings := projectStateToSpec(state)
+
+	if *withRecords {
+		for _, list := range state.Lists {
+			records, err := fetchExportRecords(client, state, list, *withComments)
+			if err != nil {
+				return fmt.Errorf("failed to fetch records of list '%s': %v", list.Title, err)
+			}
+			spec.Records = append(spec.Records, records...)
+		}
+	}
+
+	data, err := marshalProjectSpec(spec, outputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to encode spec: %v", err)
+	}
+
+	// When printing the spec itself, keep stdout clean so it can be redirected
+	if *output == "" {
+		os.Stdout.Write(data)
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", *output, err)
+	}
+
+	if *simple {
+		fmt.Printf("%s\n", *output)
+	} else {
+		fmt.Printf("✅ Exported project '%s' to %s\n", state.Project.Name, *output)
+		fmt.Printf("   Lists: %d, Tags: %d, Custom fields: %d, Field groups: %d, Roles: %d, Automations: %d",
+			len(spec.Lists), len(spec.Tags), len(spec.CustomFields), len(spec.FieldGroups), len(spec.Roles), len(spec.Automations))
+		if *withRecords {
+			fmt.Printf(", Records: %d", len(spec.Records))
+		}
+		fmt.Println()
+	}
+	for _, warning := range warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// RecordFileField is a FILE custom field of a record with the files attached to it
+type RecordFileField struct {
+	FieldID   string
+	FieldName string
+	Files     []common.File
+}
+
+// FileFieldRecord is a record with the files in its FILE custom fields
+type FileFieldRecord struct {
+	ID     string
+	Title  string
+	Fields []RecordFileField
+}
+
+// fileFieldTodo is the shape of a record returned by fileFieldSelection
+type fileFieldTodo struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	CustomFields []struct {
+		ID    string        `json:"id"`
+		Name  string        `json:"name"`
+		Type  string        `json:"type"`
+		Files []common.File `json:"files"`
+	} `json:"customFields"`
+}
+
+const fileFieldSelection = `
+	id
+	title
+	customFields {
+		id
+		name
+		type
+		files {
+			id
+			uid
+			name
+			size
+			type
+			extension
+		}
+	}
+`
+
+// toFileFieldRecord keeps only the FILE fields of a record
+func (todo fileFieldTodo) toFileFieldRecord() FileFieldRecord {
+	record := FileFieldRecord{ID: todo.ID, Title: todo.Title}
+	for _, field := range todo.CustomFields {
+		if field.Type != "FILE" {
+			continue
+		}
+		record.Fields = append(record.Fields, RecordFileField{
+			FieldID:   field.ID,
+			FieldName: field.Name,
+			Files:     field.Files,
+		})
+	}
+	return record
+}
+
+// fetchFileFieldRecord returns the files in the FILE fields of a single record
+func fetchFileFieldRecord(client *common.Client, recordID string) (*FileFieldRecord, error) {
+	query := fmt.Sprintf(`
+		query RecordFieldFiles($id: String!) {
+			todo(id: $id) {
+				%s
+			}
+		}
+	`, fileFieldSelection)
+
+	var response struct {
+		Todo *fileFieldTodo `json:"todo"`
+	}
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"id": recordID}, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch record: %v", err)
+	}
+	if response.Todo == nil {
+		return nil, fmt.Errorf("record '%s' not found", recordID)
+	}
+	record := response.Todo.toFileFieldRecord()
+	return &record, nil
+}
+
+// fetchProjectFileFieldRecords returns the files in the FILE fields of every record of a project
+func fetchProjectFileFieldRecords(client *common.Client, projectID string) ([]FileFieldRecord, error) {
+	lists, err := fetchProjectLists(client, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lists: %v", err)
+	}
+
+	var records []FileFieldRecord
+	for _, list := range lists {
+		todos, err := fetchListTodos[fileFieldTodo](client, list.ID, fileFieldSelection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch records of '%s': %v", list.Title, err)
+		}
+		for _, todo := range todos {
+			records = append(records, todo.toFileFieldRecord())
+		}
+	}
+	return records, nil
+}
+
+// findRecordFileField returns the FILE field of a record by ID
+func (record *FileFieldRecord) findRecordFileField(fieldID string) *RecordFileField {
+	for i := range record.Fields {
+		if record.Fields[i].FieldID == fieldID {
+			return &record.Fields[i]
+		}
+	}
+	return nil
+}
+
+// findAttachedFile finds a file of a field by UID, ID or file name
+func findAttachedFile(files []common.File, ref string) *common.File {
+	ref = strings.TrimSpace(ref)
+	for i := range files {
+		if files[i].UID == ref || files[i].ID == ref {
+			return &files[i]
+		}
+	}
+	for i := range files {
+		if strings.EqualFold(files[i].Name, ref) || strings.EqualFold(fileNameWithExtension(files[i]), ref) {
+			return &files[i]
+		}
+	}
+	return nil
+}
+
+// uploadProjectFile uploads a local file to a project and returns the stored file
+func uploadProjectFile(client *common.Client, projectID, path string) (*common.File, error) {
+	mutation := `
+		mutation UploadFile($input: UploadFileInput!) {
+			uploadFile(input: $input) {
+				id
+				uid
+				name
+				size
+				type
+				extension
+			}
+		}
+	`
+
+	// The file itself is sent as a multipart part mapped to input.file
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"file":      nil,
+			"projectId": projectID,
+			"companyId": client.GetCompanyID(),
+		},
+	}
+	data, err := client.UploadFile(mutation, variables, "input.file", path)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data: %v", err)
+	}
+	var response struct {
+		UploadFile common.File `json:"uploadFile"`
+	}
+	if err := json.Unmarshal(jsonData, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling upload: %v", err)
+	}
+	if response.UploadFile.UID == "" {
+		return nil, fmt.Errorf("upload returned no file")
+	}
+	return &response.UploadFile, nil
+}
+
+// attachFieldFile attaches an uploaded file to a record's FILE field
+func attachFieldFile(client *common.Client, todoID, fieldID, fileUID string) error {
+	mutation := `
+		mutation CreateTodoCustomFieldFile($input: CreateTodoCustomFieldFileInput!) {
+			createTodoCustomFieldFile(input: $input)
+		}
+	`
+	return executeTodoCustomFieldFile(client, mutation, "createTodoCustomFieldFile", todoID, fieldID, fileUID)
+}
+
+// detachFieldFile removes a file from a record's FILE field
+func detachFieldFile(client *common.Client, todoID, fieldID, fileUID string) error {
+	mutation := `
+		mutation DeleteTodoCustomFieldFile($input: DeleteTodoCustomFieldFileInput!) {
+			deleteTodoCustomFieldFile(input: $input)
+		}
+	`
+	return executeTodoCustomFieldFile(client, mutation, "deleteTodoCustomFieldFile", todoID, fieldID, fileUID)
+}
+
+// executeTodoCustomFieldFile runs one of the record field file mutations
+func executeTodoCustomFieldFile(client *common.Client, mutation, name, todoID, fieldID, fileUID string) error {
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"todoId":        todoID,
+			"customFieldId": fieldID,
+			"fileUid":       fileUID,
+		},
+	}
+	// The mutations return a nullable Boolean; only an explicit false is a failure
+	var response map[string]*bool
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+	if ok := response[name]; ok != nil && !*ok {
+		return fmt.Errorf("%s returned false", name)
+	}
+	return nil
+}
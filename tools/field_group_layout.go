@@ -0,0 +1,479 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"demo-builder/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in record sections that can appear in todoFields next to custom fields
+var builtinTodoFieldTypes = []string{
+	"DUE_DATE", "ASSIGNEE", "TAG", "DEPENDENCY", "DESCRIPTION",
+	"CHECKLIST", "REFERENCED_BY", "TIME_TRACKING", "CREATED_DATE",
+}
+
+// FieldLayout is the complete desired order of a project's record fields and groups
+type FieldLayout struct {
+	Layout []FieldLayoutEntry `json:"layout" yaml:"layout"`
+}
+
+// FieldLayoutEntry is one top-level item of a layout: a custom field, a built-in
+// section or a group holding custom fields
+type FieldLayoutEntry struct {
+	Field   string   `json:"field,omitempty" yaml:"field,omitempty"`
+	Builtin string   `json:"builtin,omitempty" yaml:"builtin,omitempty"`
+	Group   string   `json:"group,omitempty" yaml:"group,omitempty"`
+	Color   string   `json:"color,omitempty" yaml:"color,omitempty"`
+	Fields  []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// loadFieldLayout reads a layout file; .json files are parsed as JSON, everything else as YAML
+func loadFieldLayout(path string) (*FieldLayout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var layout FieldLayout
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&layout); err != nil {
+			return nil, fmt.Errorf("invalid JSON layout: %v", err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&layout); err != nil {
+			return nil, fmt.Errorf("invalid YAML layout: %v", err)
+		}
+	}
+	if len(layout.Layout) == 0 {
+		return nil, fmt.Errorf("layout has no entries")
+	}
+	return &layout, nil
+}
+
+// customFieldNames maps custom field IDs to names
+func customFieldNames(fields []common.CustomField) map[string]string {
+	names := make(map[string]string)
+	for _, field := range fields {
+		names[field.ID] = field.Name
+	}
+	return names
+}
+
+// buildLayoutTodoFields turns a layout into todoFields, one entry per layout entry in order.
+// Existing groups are matched by name so they keep their IDs. Anything in the current
+// todoFields that the layout doesn't mention is kept at the end, unlisted groups intact,
+// and its names are returned.
+func buildLayoutTodoFields(current []common.TodoField, layout *FieldLayout, fields []common.CustomField) ([]common.TodoField, []string, error) {
+	placed := make(map[string]bool)
+	names := customFieldNames(fields)
+
+	customField := func(ref string) (common.TodoField, error) {
+		field := findCustomField(fields, strings.TrimSpace(ref))
+		if field == nil {
+			return common.TodoField{}, fmt.Errorf("custom field '%s' not found in project", ref)
+		}
+		if placed[field.ID] {
+			return common.TodoField{}, fmt.Errorf("custom field '%s' appears more than once in the layout", field.Name)
+		}
+		placed[field.ID] = true
+		return common.TodoField{Type: "CUSTOM_FIELD", CustomFieldID: strPtr(field.ID)}, nil
+	}
+
+	var result []common.TodoField
+	groups := make(map[string]bool)
+	for i, entry := range layout.Layout {
+		set := 0
+		for _, value := range []string{entry.Field, entry.Builtin, entry.Group} {
+			if strings.TrimSpace(value) != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return nil, nil, fmt.Errorf("layout entry %d must have exactly one of field, builtin or group", i+1)
+		}
+		if entry.Group == "" && (entry.Color != "" || len(entry.Fields) > 0) {
+			return nil, nil, fmt.Errorf("layout entry %d: color and fields only apply to groups", i+1)
+		}
+
+		switch {
+		case entry.Field != "":
+			todoField, err := customField(entry.Field)
+			if err != nil {
+				return nil, nil, err
+			}
+			result = append(result, todoField)
+
+		case entry.Builtin != "":
+			builtin := strings.ToUpper(strings.TrimSpace(entry.Builtin))
+			if !containsFold(builtinTodoFieldTypes, builtin) {
+				return nil, nil, fmt.Errorf("unknown built-in '%s' (valid: %s)", entry.Builtin, strings.Join(builtinTodoFieldTypes, ", "))
+			}
+			if placed[builtin] {
+				return nil, nil, fmt.Errorf("built-in '%s' appears more than once in the layout", builtin)
+			}
+			placed[builtin] = true
+			result = append(result, common.TodoField{Type: builtin})
+
+		default:
+			name := strings.TrimSpace(entry.Group)
+			if groups[specKey(name)] {
+				return nil, nil, fmt.Errorf("group '%s' appears more than once in the layout", name)
+			}
+			groups[specKey(name)] = true
+
+			group := common.TodoField{Type: "CUSTOM_FIELD_GROUP", Name: strPtr(name), TodoFields: []common.TodoField{}}
+			if idx := findGroupByName(current, name); idx != -1 {
+				group.CustomFieldID = current[idx].CustomFieldID
+				group.Color = current[idx].Color
+			} else {
+				group.CustomFieldID = strPtr(generateGroupID())
+				group.Color = strPtr("default")
+			}
+			if entry.Color != "" {
+				group.Color = strPtr(entry.Color)
+			}
+			for _, ref := range entry.Fields {
+				todoField, err := customField(ref)
+				if err != nil {
+					return nil, nil, err
+				}
+				group.TodoFields = append(group.TodoFields, todoField)
+			}
+			result = append(result, group)
+		}
+	}
+
+	// Keep what the layout leaves out rather than dropping it from the record view
+	var unlisted []string
+	keep := func(field common.TodoField) bool {
+		key := field.Type
+		label := field.Type
+		if field.Type == "CUSTOM_FIELD" {
+			if field.CustomFieldID == nil {
+				return false
+			}
+			key = *field.CustomFieldID
+			label = orNone(names[key])
+		}
+		if placed[key] {
+			return false
+		}
+		placed[key] = true
+		unlisted = append(unlisted, label)
+		return true
+	}
+	for _, field := range current {
+		if field.Type != "CUSTOM_FIELD_GROUP" {
+			if keep(field) {
+				result = append(result, field)
+			}
+			continue
+		}
+		if field.Name != nil && groups[specKey(*field.Name)] {
+			// The fields of a listed group that the layout leaves out move to the end
+			for _, nested := range field.TodoFields {
+				if keep(nested) {
+					result = append(result, nested)
+				}
+			}
+			continue
+		}
+
+		// A group the layout doesn't list stays intact with the fields not placed elsewhere
+		group := field
+		group.TodoFields = []common.TodoField{}
+		for _, nested := range field.TodoFields {
+			if nested.CustomFieldID != nil && !placed[*nested.CustomFieldID] {
+				placed[*nested.CustomFieldID] = true
+				group.TodoFields = append(group.TodoFields, nested)
+			}
+		}
+		name := "Unnamed Group"
+		if field.Name != nil {
+			name = *field.Name
+		}
+		unlisted = append(unlisted, fmt.Sprintf("group '%s'", name))
+		result = append(result, group)
+	}
+	return result, unlisted, nil
+}
+
+// layoutSignature is a comparable form of todoFields covering what a layout controls
+func layoutSignature(fields []common.TodoField) string {
+	var parts []string
+	for _, field := range fields {
+		part := field.Type
+		if field.CustomFieldID != nil {
+			part += ":" + *field.CustomFieldID
+		}
+		if field.Type == "CUSTOM_FIELD_GROUP" {
+			name, color := "", ""
+			if field.Name != nil {
+				name = *field.Name
+			}
+			if field.Color != nil {
+				color = *field.Color
+			}
+			part += fmt.Sprintf("(%s|%s)[%s]", name, color, layoutSignature(field.TodoFields))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// layoutKey identifies a todoFields entry: custom fields and groups by ID, built-ins by type
+func layoutKey(field common.TodoField) string {
+	if field.CustomFieldID != nil {
+		return *field.CustomFieldID
+	}
+	return field.Type
+}
+
+// layoutKeys returns the keys of the entries and nested fields in todoFields
+func layoutKeys(fields []common.TodoField) map[string]bool {
+	keys := make(map[string]bool)
+	for _, field := range fields {
+		keys[layoutKey(field)] = true
+		for _, nested := range field.TodoFields {
+			keys[layoutKey(nested)] = true
+		}
+	}
+	return keys
+}
+
+// controlledSignature is layoutSignature limited to the entries in keys, so entries
+// a layout doesn't mention don't count as differences. A missing group color counts
+// as "default", as the API may return either.
+func controlledSignature(fields []common.TodoField, keys map[string]bool) string {
+	var parts []string
+	for _, field := range fields {
+		if !keys[layoutKey(field)] {
+			continue
+		}
+		part := field.Type + ":" + layoutKey(field)
+		if field.Type == "CUSTOM_FIELD_GROUP" {
+			name, color := "", "default"
+			if field.Name != nil {
+				name = *field.Name
+			}
+			if field.Color != nil && *field.Color != "" {
+				color = *field.Color
+			}
+			part += fmt.Sprintf("(%s|%s)[%s]", name, color, controlledSignature(field.TodoFields, keys))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// layoutFromTodoFields converts todoFields back to a layout by name
+func layoutFromTodoFields(fields []common.TodoField, names map[string]string) *FieldLayout {
+	fieldName := func(field common.TodoField) string {
+		if field.CustomFieldID == nil {
+			return ""
+		}
+		if name, ok := names[*field.CustomFieldID]; ok {
+			return name
+		}
+		return *field.CustomFieldID
+	}
+
+	layout := &FieldLayout{}
+	for _, field := range fields {
+		switch field.Type {
+		case "CUSTOM_FIELD":
+			layout.Layout = append(layout.Layout, FieldLayoutEntry{Field: fieldName(field)})
+		case "CUSTOM_FIELD_GROUP":
+			entry := FieldLayoutEntry{}
+			if field.Name != nil {
+				entry.Group = *field.Name
+			}
+			if field.Color != nil && *field.Color != "default" {
+				entry.Color = *field.Color
+			}
+			for _, nested := range field.TodoFields {
+				if nested.Type == "CUSTOM_FIELD" {
+					entry.Fields = append(entry.Fields, fieldName(nested))
+				}
+			}
+			layout.Layout = append(layout.Layout, entry)
+		default:
+			layout.Layout = append(layout.Layout, FieldLayoutEntry{Builtin: field.Type})
+		}
+	}
+	return layout
+}
+
+// printFieldLayoutTree renders todoFields as an ASCII tree
+func printFieldLayoutTree(title string, fields []common.TodoField, names map[string]string) {
+	label := func(field common.TodoField) string {
+		switch field.Type {
+		case "CUSTOM_FIELD":
+			if field.CustomFieldID == nil {
+				return "(field without ID)"
+			}
+			if name, ok := names[*field.CustomFieldID]; ok {
+				return name
+			}
+			return fmt.Sprintf("(unknown field) [%s]", *field.CustomFieldID)
+		case "CUSTOM_FIELD_GROUP":
+			name, color := "Unnamed Group", "default"
+			if field.Name != nil {
+				name = *field.Name
+			}
+			if field.Color != nil {
+				color = *field.Color
+			}
+			return fmt.Sprintf("📁 %s (%s)", name, color)
+		default:
+			return fmt.Sprintf("%s (built-in)", field.Type)
+		}
+	}
+
+	fmt.Println(title)
+	if len(fields) == 0 {
+		fmt.Println("└── (no fields)")
+		return
+	}
+	for i, field := range fields {
+		branch, indent := "├── ", "│   "
+		if i == len(fields)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Println(branch + label(field))
+		if field.Type != "CUSTOM_FIELD_GROUP" {
+			continue
+		}
+		if len(field.TodoFields) == 0 {
+			fmt.Println(indent + "└── (empty)")
+		}
+		for j, nested := range field.TodoFields {
+			nestedBranch := "├── "
+			if j == len(field.TodoFields)-1 {
+				nestedBranch = "└── "
+			}
+			fmt.Println(indent + nestedBranch + label(nested))
+		}
+	}
+}
+
+// actionTree shows the current layout as a tree, or as a layout file to edit and apply
+func actionTree(client *common.Client, projectID, format string) error {
+	fields, err := fetchProjectCustomFields(client, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	todoFields, err := fetchProjectTodoFields(client, projectID)
+	if err != nil {
+		return err
+	}
+	names := customFieldNames(fields)
+
+	switch format {
+	case "", "tree":
+		printFieldLayoutTree("Field layout", todoFields, names)
+	case "yaml":
+		data, err := yaml.Marshal(layoutFromTodoFields(todoFields, names))
+		if err != nil {
+			return fmt.Errorf("failed to encode layout: %v", err)
+		}
+		fmt.Print(string(data))
+	case "json":
+		data, err := json.MarshalIndent(layoutFromTodoFields(todoFields, names), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode layout: %v", err)
+		}
+		fmt.Println(string(data))
+	default:
+		return fmt.Errorf("invalid format '%s' (valid: tree, yaml, json)", format)
+	}
+	return nil
+}
+
+// actionLayout replaces the whole field layout in a single write. The todoFields are
+// read again just before writing and after it; if someone else changed them in
+// between, the layout is rebuilt from their version and written again.
+func actionLayout(client *common.Client, projectID, file string, dryRun bool, retries int) error {
+	layout, err := loadFieldLayout(file)
+	if err != nil {
+		return fmt.Errorf("failed to load layout: %v", err)
+	}
+	fields, err := fetchProjectCustomFields(client, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	names := customFieldNames(fields)
+
+	current, err := fetchProjectTodoFields(client, projectID)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		desired, unlisted, err := buildLayoutTodoFields(current, layout, fields)
+		if err != nil {
+			return err
+		}
+		if layoutSignature(desired) == layoutSignature(current) {
+			fmt.Println("✅ Field layout already matches")
+			return nil
+		}
+		if len(unlisted) > 0 && attempt == 1 {
+			fmt.Printf("⚠️  Not in the layout, kept at the end: %s\n", strings.Join(unlisted, ", "))
+		}
+		if dryRun {
+			printFieldLayoutTree("Current layout", current, names)
+			fmt.Println()
+			printFieldLayoutTree("New layout", desired, names)
+			fmt.Println("\nDry run: no changes made")
+			return nil
+		}
+
+		// Don't overwrite a layout that changed since it was read
+		latest, err := fetchProjectTodoFields(client, projectID)
+		if err != nil {
+			return err
+		}
+		if layoutSignature(latest) != layoutSignature(current) {
+			if attempt > retries {
+				return fmt.Errorf("field layout kept changing while applying; gave up after %d attempt(s)", attempt)
+			}
+			fmt.Printf("⚠️  Field layout changed since it was read, retrying (%d/%d)\n", attempt, retries)
+			current = latest
+			continue
+		}
+
+		if err := updateProjectTodoFields(client, projectID, convertToInput(desired)); err != nil {
+			return fmt.Errorf("failed to update field layout: %v", err)
+		}
+
+		// A concurrent write can still land between the check and ours. Only the entries
+		// the layout lists are compared, so server-side normalisation doesn't count.
+		written, err := fetchProjectTodoFields(client, projectID)
+		if err != nil {
+			return err
+		}
+		listed := desired[:len(layout.Layout)]
+		keys := layoutKeys(listed)
+		if controlledSignature(written, keys) == controlledSignature(listed, keys) {
+			fmt.Println("✅ Field layout applied")
+			printFieldLayoutTree("Field layout", written, names)
+			return nil
+		}
+		if attempt > retries {
+			return fmt.Errorf("field layout was changed concurrently; gave up after %d attempt(s)", attempt)
+		}
+		fmt.Printf("⚠️  Field layout was changed during the update, retrying (%d/%d)\n", attempt, retries)
+		current = written
+	}
+}
@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"demo-builder/common"
+)
+
+func layoutTestFields() []common.CustomField {
+	return []common.CustomField{
+		{ID: "f1", Name: "Budget"},
+		{ID: "f2", Name: "Stage"},
+		{ID: "f3", Name: "Owner"},
+		{ID: "f4", Name: "Notes"},
+		{ID: "f5", Name: "Legacy"},
+	}
+}
+
+func layoutTestTodoFields() []common.TodoField {
+	field := func(id string) common.TodoField {
+		return common.TodoField{Type: "CUSTOM_FIELD", CustomFieldID: strPtr(id)}
+	}
+	return []common.TodoField{
+		{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g1"), Name: strPtr("Sales"), Color: strPtr("blue"),
+			TodoFields: []common.TodoField{field("f1"), field("f2")}},
+		{Type: "DUE_DATE"},
+		{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g2"), Name: strPtr("Archive"),
+			TodoFields: []common.TodoField{field("f3"), field("f4")}},
+		field("f5"),
+	}
+}
+
+func TestBuildLayoutTodoFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		layout   []FieldLayoutEntry
+		want     string
+		unlisted []string
+	}{
+		{
+			name: "unlisted groups stay intact without the fields placed elsewhere",
+			layout: []FieldLayoutEntry{
+				{Group: "sales", Fields: []string{"Stage"}},
+				{Field: "Owner"},
+				{Builtin: "due_date"},
+				{Group: "New", Color: "red", Fields: []string{"Budget"}},
+			},
+			want: "CUSTOM_FIELD_GROUP:g1(sales|blue)[CUSTOM_FIELD:f2],CUSTOM_FIELD:f3,DUE_DATE," +
+				"CUSTOM_FIELD_GROUP:new(New|red)[CUSTOM_FIELD:f1]," +
+				"CUSTOM_FIELD_GROUP:g2(Archive|)[CUSTOM_FIELD:f4],CUSTOM_FIELD:f5",
+			unlisted: []string{"group 'Archive'", "Legacy"},
+		},
+		{
+			name: "fields a listed group leaves out move to the end",
+			layout: []FieldLayoutEntry{
+				{Field: "Legacy"},
+				{Group: "Sales", Color: "green", Fields: []string{"Stage"}},
+				{Group: "Archive", Fields: []string{"Notes", "Owner"}},
+			},
+			want: "CUSTOM_FIELD:f5,CUSTOM_FIELD_GROUP:g1(Sales|green)[CUSTOM_FIELD:f2]," +
+				"CUSTOM_FIELD_GROUP:g2(Archive|)[CUSTOM_FIELD:f4,CUSTOM_FIELD:f3],CUSTOM_FIELD:f1,DUE_DATE",
+			unlisted: []string{"Budget", "DUE_DATE"},
+		},
+	}
+	for _, tt := range tests {
+		result, unlisted, err := buildLayoutTodoFields(layoutTestTodoFields(), &FieldLayout{Layout: tt.layout}, layoutTestFields())
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		// New groups get a generated ID
+		for i := range result {
+			if id := result[i].CustomFieldID; id != nil && strings.HasPrefix(*id, "grp_") {
+				result[i].CustomFieldID = strPtr("new")
+			}
+		}
+		if got := layoutSignature(result); got != tt.want {
+			t.Errorf("%s:\ngot  %s\nwant %s", tt.name, got, tt.want)
+		}
+		if !reflect.DeepEqual(unlisted, tt.unlisted) {
+			t.Errorf("%s: unlisted = %q; want %q", tt.name, unlisted, tt.unlisted)
+		}
+	}
+}
+
+func TestBuildLayoutTodoFieldsErrors(t *testing.T) {
+	tests := []struct {
+		layout []FieldLayoutEntry
+		want   string
+	}{
+		{[]FieldLayoutEntry{{Field: "Budget"}, {Group: "Sales", Fields: []string{"budget"}}}, "custom field 'Budget' appears more than once in the layout"},
+		{[]FieldLayoutEntry{{Group: "Sales"}, {Group: " sales "}}, "group 'sales' appears more than once in the layout"},
+		{[]FieldLayoutEntry{{Builtin: "TAG"}, {Builtin: "tag"}}, "built-in 'TAG' appears more than once in the layout"},
+		{[]FieldLayoutEntry{{Field: "Budget", Builtin: "TAG"}}, "layout entry 1 must have exactly one of field, builtin or group"},
+		{[]FieldLayoutEntry{{Field: "Budget"}, {Color: "red"}}, "layout entry 2 must have exactly one of field, builtin or group"},
+		{[]FieldLayoutEntry{{Field: "Budget", Color: "red"}}, "layout entry 1: color and fields only apply to groups"},
+		{[]FieldLayoutEntry{{Builtin: "COMMENTS"}}, "unknown built-in 'COMMENTS'"},
+		{[]FieldLayoutEntry{{Group: "Sales", Fields: []string{"Revenue"}}}, "custom field 'Revenue' not found in project"},
+	}
+	for _, tt := range tests {
+		_, _, err := buildLayoutTodoFields(layoutTestTodoFields(), &FieldLayout{Layout: tt.layout}, layoutTestFields())
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("layout %+v: error = %v; want %q", tt.layout, err, tt.want)
+		}
+	}
+}
+
+func TestControlledSignature(t *testing.T) {
+	group := func(color *string, fields ...string) []common.TodoField {
+		var nested []common.TodoField
+		for _, id := range fields {
+			nested = append(nested, common.TodoField{Type: "CUSTOM_FIELD", CustomFieldID: strPtr(id)})
+		}
+		return []common.TodoField{
+			{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g1"), Name: strPtr("Sales"), Color: color, TodoFields: nested},
+			{Type: "CUSTOM_FIELD", CustomFieldID: strPtr("f9")},
+		}
+	}
+	keys := map[string]bool{"g1": true, "f1": true}
+
+	base := controlledSignature(group(nil, "f1"), keys)
+	for _, tt := range []struct {
+		name   string
+		fields []common.TodoField
+		same   bool
+	}{
+		{"default color", group(strPtr("default"), "f1"), true},
+		{"empty color", group(strPtr(""), "f1"), true},
+		{"fields outside the keys", group(nil, "f1", "f2"), true},
+		{"another color", group(strPtr("red"), "f1"), false},
+		{"a missing controlled field", group(nil), false},
+	} {
+		if got := controlledSignature(tt.fields, keys); (got == base) != tt.same {
+			t.Errorf("%s: signature %q vs %q; want same=%t", tt.name, got, base, tt.same)
+		}
+	}
+}
+
+func TestLayoutFromTodoFields(t *testing.T) {
+	fields := layoutTestTodoFields()
+	fields[0].Color = strPtr("default")
+	fields = append(fields, common.TodoField{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g3"), Name: strPtr("Ops"), Color: strPtr("red"),
+		TodoFields: []common.TodoField{{Type: "CUSTOM_FIELD", CustomFieldID: strPtr("gone")}}})
+
+	want := []FieldLayoutEntry{
+		{Group: "Sales", Fields: []string{"Budget", "Stage"}},
+		{Builtin: "DUE_DATE"},
+		{Group: "Archive", Fields: []string{"Owner", "Notes"}},
+		{Field: "Legacy"},
+		{Group: "Ops", Color: "red", Fields: []string{"gone"}},
+	}
+	if got := layoutFromTodoFields(fields, customFieldNames(layoutTestFields())); !reflect.DeepEqual(got.Layout, want) {
+		t.Errorf("layoutFromTodoFields = %+v\nwant %+v", got.Layout, want)
+	}
+}
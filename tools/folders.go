@@ -0,0 +1,579 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// ProjectFolder is a folder that groups projects
+type ProjectFolder struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Color    *string `json:"color"`
+	Position float64 `json:"position"`
+	Parent   *struct {
+		ID string `json:"id"`
+	} `json:"parent"`
+}
+
+// parentID returns the ID of the folder's parent, or "" for a top-level folder
+func (f ProjectFolder) parentID() string {
+	if f.Parent == nil {
+		return ""
+	}
+	return f.Parent.ID
+}
+
+// fetchFolderPage loads the project folders directly below a parent ("" for the top level)
+func fetchFolderPage(client *common.Client, companyID, parentID string) ([]ProjectFolder, error) {
+	query := `
+		query ProjectFolders($filter: FolderFilterInput!, $skip: Int, $take: Int) {
+			folders(filter: $filter, sort: [position_ASC], skip: $skip, take: $take) {
+				items {
+					id
+					title
+					color
+					position
+					parent {
+						id
+					}
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}
+	`
+
+	var folders []ProjectFolder
+	for skip := 0; ; skip += 100 {
+		filter := map[string]interface{}{
+			"companyId": companyID,
+			"type":      "PROJECT",
+		}
+		if parentID != "" {
+			filter["parentId"] = parentID
+		}
+
+		var response struct {
+			Folders struct {
+				Items    []ProjectFolder `json:"items"`
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"folders"`
+		}
+		variables := map[string]interface{}{"filter": filter, "skip": skip, "take": 100}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		folders = append(folders, response.Folders.Items...)
+		if !response.Folders.PageInfo.HasNextPage {
+			break
+		}
+	}
+	return folders, nil
+}
+
+// fetchProjectFolders loads every project folder of a company, walking down from the top level
+func fetchProjectFolders(client *common.Client, companyID string) ([]ProjectFolder, error) {
+	var folders []ProjectFolder
+	seen := make(map[string]bool)
+	queue := []string{""}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+
+		page, err := fetchFolderPage(client, companyID, parentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, folder := range page {
+			if seen[folder.ID] {
+				continue
+			}
+			seen[folder.ID] = true
+			folders = append(folders, folder)
+			queue = append(queue, folder.ID)
+		}
+	}
+	return folders, nil
+}
+
+// resolveFolder finds a folder by ID or case-insensitive title
+func resolveFolder(folders []ProjectFolder, value string) (*ProjectFolder, error) {
+	for i := range folders {
+		if folders[i].ID == value {
+			return &folders[i], nil
+		}
+	}
+	var matches []*ProjectFolder
+	for i := range folders {
+		if strings.EqualFold(folders[i].Title, value) {
+			matches = append(matches, &folders[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("folder '%s' not found", value)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d folders are called '%s'; use the folder ID instead", len(matches), value)
+	}
+}
+
+// childFolders returns the folders below a parent ("" for the top level) sorted by position
+func childFolders(folders []ProjectFolder, parentID string) []ProjectFolder {
+	var children []ProjectFolder
+	for _, folder := range folders {
+		if folder.parentID() == parentID {
+			children = append(children, folder)
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].Position < children[j].Position
+	})
+	return children
+}
+
+// isFolderInside reports whether folder lies inside (or is) ancestor
+func isFolderInside(folders []ProjectFolder, folderID, ancestorID string) bool {
+	byID := make(map[string]ProjectFolder)
+	for _, folder := range folders {
+		byID[folder.ID] = folder
+	}
+	for id := folderID; id != ""; id = byID[id].parentID() {
+		if id == ancestorID {
+			return true
+		}
+		if _, ok := byID[id]; !ok {
+			return false
+		}
+	}
+	return false
+}
+
+// printFolderTree prints folders and, optionally, the projects inside them
+func printFolderTree(folders []ProjectFolder, projects []PositionedProject, parentID, indent string) {
+	for _, folder := range childFolders(folders, parentID) {
+		fmt.Printf("%s📁 %s  (%s)\n", indent, folder.Title, folder.ID)
+		printFolderTree(folders, projects, folder.ID, indent+"   ")
+	}
+	for _, project := range projects {
+		folderID := ""
+		if project.Folder != nil {
+			folderID = project.Folder.ID
+		}
+		if folderID == parentID {
+			fmt.Printf("%s• %s  (%s)\n", indent, project.Name, project.Slug)
+		}
+	}
+}
+
+// executeCreateFolder creates a project folder
+func executeCreateFolder(client *common.Client, title, parentID string) (*ProjectFolder, error) {
+	mutation := `
+		mutation CreateFolder($input: CreateFolderInput!) {
+			createFolder(input: $input) {
+				id
+				title
+				position
+			}
+		}
+	`
+	input := map[string]interface{}{
+		"type":      "PROJECT",
+		"title":     title,
+		"companyId": client.GetCompanyID(),
+	}
+	if parentID != "" {
+		input["parentId"] = parentID
+	}
+
+	var response struct {
+		CreateFolder ProjectFolder `json:"createFolder"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return nil, err
+	}
+	return &response.CreateFolder, nil
+}
+
+// executeEditFolder changes a folder's title, color or position; empty values are left alone
+func executeEditFolder(client *common.Client, folderID, title, color string, position *float64) error {
+	mutation := `
+		mutation EditFolder($input: EditFolderInput!) {
+			editFolder(input: $input) {
+				id
+			}
+		}
+	`
+	input := map[string]interface{}{"id": folderID}
+	if title != "" {
+		input["title"] = title
+	}
+	if color != "" {
+		input["color"] = color
+	}
+	if position != nil {
+		input["position"] = *position
+	}
+
+	var response struct {
+		EditFolder struct {
+			ID string `json:"id"`
+		} `json:"editFolder"`
+	}
+	return client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response)
+}
+
+// executeSetParentFolder moves folders below a parent, or to the top level when parentID is ""
+func executeSetParentFolder(client *common.Client, folderIDs []string, parentID string) error {
+	mutation := `
+		mutation SetParentFolder($input: SetParentFolderInput!) {
+			setParentFolder(input: $input)
+		}
+	`
+	input := map[string]interface{}{"folderIds": folderIDs, "parentFolderId": nil}
+	if parentID != "" {
+		input["parentFolderId"] = parentID
+	}
+
+	var response struct {
+		SetParentFolder bool `json:"setParentFolder"`
+	}
+	return client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response)
+}
+
+// executeUpdateFolderPosition sets a folder's position among its siblings
+func executeUpdateFolderPosition(client *common.Client, folderID string, position float64) error {
+	mutation := `
+		mutation UpdateFolderPosition($input: UpdateFolderPositionInput!) {
+			updateFolderPosition(input: $input) {
+				id
+			}
+		}
+	`
+	input := map[string]interface{}{"folderId": folderID, "position": position}
+
+	var response struct {
+		UpdateFolderPosition struct {
+			ID string `json:"id"`
+		} `json:"updateFolderPosition"`
+	}
+	return client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response)
+}
+
+// executeDeleteFolder deletes a folder
+func executeDeleteFolder(client *common.Client, folderID string) error {
+	mutation := `
+		mutation DeleteFolder($input: DeleteFolderInput!) {
+			deleteFolder(input: $input)
+		}
+	`
+
+	var response struct {
+		DeleteFolder bool `json:"deleteFolder"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": map[string]interface{}{"id": folderID}}, &response); err != nil {
+		return err
+	}
+	if !response.DeleteFolder {
+		return fmt.Errorf("the API reported that the folder was not deleted")
+	}
+	return nil
+}
+
+// newFoldersClient loads the configuration and all folders for a folders subcommand
+func newFoldersClient() (*common.Client, []ProjectFolder, error) {
+	config, err := common.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+
+	folders, err := fetchProjectFolders(client, client.GetCompanyID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch folders: %v", err)
+	}
+	return client, folders, nil
+}
+
+// printFoldersUsage prints the usage of the folders command group
+func printFoldersUsage() {
+	fmt.Println("Usage: go run . folders <subcommand> [flags]")
+	fmt.Println("\nSubcommands:")
+	fmt.Println("  tree      Show folders (and their projects) as a tree")
+	fmt.Println("  create    Create a folder")
+	fmt.Println("  rename    Rename or recolor a folder")
+	fmt.Println("  move      Move a folder into another folder or reposition it")
+	fmt.Println("  delete    Delete a folder")
+	fmt.Println("\nExamples:")
+	fmt.Println("  go run . folders tree -projects")
+	fmt.Println("  go run . folders create -title \"Clients\"")
+	fmt.Println("  go run . folders create -title \"Acme\" -parent \"Clients\"")
+	fmt.Println("  go run . folders rename -folder \"Acme\" -title \"Acme Corp\"")
+	fmt.Println("  go run . folders move -folder \"Acme Corp\" -root")
+	fmt.Println("  go run . folders delete -folder \"Old\" -confirm")
+}
+
+// RunFolders manages project folders
+func RunFolders(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		printFoldersUsage()
+		return fmt.Errorf("subcommand required")
+	}
+
+	switch args[0] {
+	case "tree":
+		return runFoldersTree(args[1:])
+	case "create":
+		return runFoldersCreate(args[1:])
+	case "rename":
+		return runFoldersRename(args[1:])
+	case "move":
+		return runFoldersMove(args[1:])
+	case "delete":
+		return runFoldersDelete(args[1:])
+	default:
+		printFoldersUsage()
+		return fmt.Errorf("unknown subcommand '%s'", args[0])
+	}
+}
+
+// runFoldersTree prints the folder tree
+func runFoldersTree(args []string) error {
+	fs := flag.NewFlagSet("folders tree", flag.ExitOnError)
+	withProjects := fs.Bool("projects", false, "Show the projects inside each folder")
+	root := fs.String("folder", "", "Only show this folder (ID or title)")
+	fs.Parse(args)
+
+	client, folders, err := newFoldersClient()
+	if err != nil {
+		return err
+	}
+
+	var projects []PositionedProject
+	if *withProjects {
+		if projects, err = fetchPositionedProjects(client, client.GetCompanyID()); err != nil {
+			return fmt.Errorf("failed to fetch projects: %v", err)
+		}
+	}
+
+	parentID := ""
+	if *root != "" {
+		folder, err := resolveFolder(folders, *root)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📁 %s  (%s)\n", folder.Title, folder.ID)
+		printFolderTree(folders, projects, folder.ID, "   ")
+		return nil
+	}
+
+	fmt.Printf("\n=== Folders in %s ===\n", client.GetCompanyID())
+	if len(folders) == 0 {
+		fmt.Println("No folders found.")
+	}
+	printFolderTree(folders, projects, parentID, "")
+	return nil
+}
+
+// runFoldersCreate creates a folder
+func runFoldersCreate(args []string) error {
+	fs := flag.NewFlagSet("folders create", flag.ExitOnError)
+	title := fs.String("title", "", "Folder title (required)")
+	parent := fs.String("parent", "", "Parent folder (ID or title)")
+	color := fs.String("color", "", "Folder color")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *title == "" {
+		fmt.Println("Usage: go run . folders create -title TITLE [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, folders, err := newFoldersClient()
+	if err != nil {
+		return err
+	}
+
+	parentID := ""
+	if *parent != "" {
+		folder, err := resolveFolder(folders, *parent)
+		if err != nil {
+			return err
+		}
+		parentID = folder.ID
+	}
+
+	folder, err := executeCreateFolder(client, *title, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to create folder: %v", err)
+	}
+	if *color != "" {
+		if err := executeEditFolder(client, folder.ID, "", *color, nil); err != nil {
+			return fmt.Errorf("folder created (%s) but setting its color failed: %v", folder.ID, err)
+		}
+	}
+
+	if *simple {
+		fmt.Println(folder.ID)
+	} else {
+		fmt.Printf("✅ Folder '%s' created\n", folder.Title)
+		fmt.Printf("   ID: %s\n", folder.ID)
+	}
+	return nil
+}
+
+// runFoldersRename renames or recolors a folder
+func runFoldersRename(args []string) error {
+	fs := flag.NewFlagSet("folders rename", flag.ExitOnError)
+	folderRef := fs.String("folder", "", "Folder ID or title (required)")
+	title := fs.String("title", "", "New title")
+	color := fs.String("color", "", "New color")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *folderRef == "" || (*title == "" && *color == "") {
+		fmt.Println("Usage: go run . folders rename -folder FOLDER (-title TITLE | -color COLOR) [-simple]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, folders, err := newFoldersClient()
+	if err != nil {
+		return err
+	}
+	folder, err := resolveFolder(folders, *folderRef)
+	if err != nil {
+		return err
+	}
+
+	if err := executeEditFolder(client, folder.ID, *title, *color, nil); err != nil {
+		return fmt.Errorf("failed to update folder: %v", err)
+	}
+
+	switch {
+	case *simple:
+		fmt.Println(folder.ID)
+	case *title != "":
+		fmt.Printf("✅ Folder '%s' renamed to '%s'\n", folder.Title, *title)
+	default:
+		fmt.Printf("✅ Folder '%s' updated\n", folder.Title)
+	}
+	return nil
+}
+
+// runFoldersMove moves a folder to another parent and/or position
+func runFoldersMove(args []string) error {
+	fs := flag.NewFlagSet("folders move", flag.ExitOnError)
+	folderRef := fs.String("folder", "", "Folder ID or title (required)")
+	parent := fs.String("parent", "", "New parent folder (ID or title)")
+	root := fs.Bool("root", false, "Move the folder to the top level")
+	position := fs.Float64("position", -1, "New position among its siblings")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *folderRef == "" || (*parent == "" && !*root && *position < 0) {
+		fmt.Println("Usage: go run . folders move -folder FOLDER (-parent FOLDER | -root) [-position N] [-simple]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+	if *parent != "" && *root {
+		return fmt.Errorf("use either -parent or -root, not both")
+	}
+
+	client, folders, err := newFoldersClient()
+	if err != nil {
+		return err
+	}
+	folder, err := resolveFolder(folders, *folderRef)
+	if err != nil {
+		return err
+	}
+
+	if *parent != "" || *root {
+		parentID := ""
+		destination := "the top level"
+		if *parent != "" {
+			target, err := resolveFolder(folders, *parent)
+			if err != nil {
+				return err
+			}
+			if isFolderInside(folders, target.ID, folder.ID) {
+				return fmt.Errorf("cannot move '%s' into itself or one of its subfolders", folder.Title)
+			}
+			parentID = target.ID
+			destination = "'" + target.Title + "'"
+		}
+		if err := executeSetParentFolder(client, []string{folder.ID}, parentID); err != nil {
+			return fmt.Errorf("failed to move folder: %v", err)
+		}
+		if !*simple {
+			fmt.Printf("✅ Folder '%s' moved to %s\n", folder.Title, destination)
+		}
+	}
+
+	if *position >= 0 {
+		if err := executeUpdateFolderPosition(client, folder.ID, *position); err != nil {
+			return fmt.Errorf("failed to reposition folder: %v", err)
+		}
+		if !*simple {
+			fmt.Printf("✅ Folder '%s' moved to position %g\n", folder.Title, *position)
+		}
+	}
+
+	if *simple {
+		fmt.Println(folder.ID)
+	}
+	return nil
+}
+
+// runFoldersDelete deletes a folder
+func runFoldersDelete(args []string) error {
+	fs := flag.NewFlagSet("folders delete", flag.ExitOnError)
+	folderRef := fs.String("folder", "", "Folder ID or title (required)")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (required for safety)")
+	fs.Parse(args)
+
+	if *folderRef == "" {
+		fmt.Println("Usage: go run . folders delete -folder FOLDER -confirm")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, folders, err := newFoldersClient()
+	if err != nil {
+		return err
+	}
+	folder, err := resolveFolder(folders, *folderRef)
+	if err != nil {
+		return err
+	}
+
+	if !*confirm {
+		fmt.Printf("⚠️  This will delete folder '%s'", folder.Title)
+		if subfolders := childFolders(folders, folder.ID); len(subfolders) > 0 {
+			fmt.Printf(" and its %d subfolder(s)", len(subfolders))
+		}
+		fmt.Println()
+		return fmt.Errorf("deletion confirmation is required. Use -confirm flag to confirm deletion")
+	}
+
+	if err := executeDeleteFolder(client, folder.ID); err != nil {
+		return fmt.Errorf("failed to delete folder: %v", err)
+	}
+	fmt.Printf("✅ Folder '%s' deleted\n", folder.Title)
+	return nil
+}
@@ -0,0 +1,653 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"demo-builder/common"
+)
+
+// FormulaInput is the formula of a FORMULA custom field as the API stores it.
+// Field references are kept in logic.text as {{customFieldId}}; logic.html holds
+// the same expression with the references rendered for the web app.
+type FormulaInput struct {
+	Logic   FormulaLogicInput   `json:"logic"`
+	Display FormulaDisplayInput `json:"display"`
+}
+
+// FormulaLogicInput is the expression of a formula
+type FormulaLogicInput struct {
+	Text string `json:"text"`
+	HTML string `json:"html"`
+}
+
+// FormulaDisplayInput controls how a formula result is shown
+type FormulaDisplayInput struct {
+	Type      string                       `json:"type"`
+	Currency  *FormulaDisplayCurrencyInput `json:"currency,omitempty"`
+	Precision *float64                     `json:"precision,omitempty"`
+}
+
+// FormulaDisplayCurrencyInput is the currency of a CURRENCY formula display
+type FormulaDisplayCurrencyInput struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// formulaDisplayTypes are the valid values of FormulaDisplayType
+var formulaDisplayTypes = []string{"NUMBER", "CURRENCY", "PERCENTAGE"}
+
+// formulaFieldTypes are the custom field types a formula can reference
+var formulaFieldTypes = []string{"NUMBER", "CURRENCY", "PERCENT", "RATING"}
+
+// formulaFunctions are the functions a formula can call, with their argument counts
+var formulaFunctions = map[string][2]int{
+	"SUM":     {1, -1},
+	"AVERAGE": {1, -1},
+	"MIN":     {1, -1},
+	"MAX":     {1, -1},
+	"ABS":     {1, 1},
+	"ROUND":   {1, 2},
+}
+
+// currencyNames names the common currencies for CURRENCY formula displays
+var currencyNames = map[string]string{
+	"USD": "US Dollar", "EUR": "Euro", "GBP": "British Pound", "JPY": "Japanese Yen",
+	"CAD": "Canadian Dollar", "AUD": "Australian Dollar", "CHF": "Swiss Franc",
+	"CNY": "Chinese Yuan", "INR": "Indian Rupee", "BRL": "Brazilian Real",
+}
+
+var formulaReferencePattern = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+// formulaNode is a node of a parsed formula expression
+type formulaNode interface {
+	// eval computes the node from numeric field values keyed by field ID
+	eval(values map[string]float64) (float64, error)
+	// render writes the node back as an expression, formatting field references with ref
+	render(ref func(field *common.CustomField) string) string
+}
+
+type formulaNumber struct{ value float64 }
+
+type formulaField struct {
+	name  string
+	field *common.CustomField
+}
+
+type formulaUnary struct{ operand formulaNode }
+
+type formulaBinary struct {
+	op          byte
+	left, right formulaNode
+}
+
+type formulaCall struct {
+	name string
+	args []formulaNode
+}
+
+func (n formulaNumber) eval(map[string]float64) (float64, error) { return n.value, nil }
+
+func (n formulaNumber) render(func(*common.CustomField) string) string {
+	return strconv.FormatFloat(n.value, 'f', -1, 64)
+}
+
+// Empty field values count as zero, as they do in the web app
+func (n formulaField) eval(values map[string]float64) (float64, error) {
+	return values[n.field.ID], nil
+}
+
+func (n formulaField) render(ref func(*common.CustomField) string) string { return ref(n.field) }
+
+func (n formulaUnary) eval(values map[string]float64) (float64, error) {
+	value, err := n.operand.eval(values)
+	return -value, err
+}
+
+func (n formulaUnary) render(ref func(*common.CustomField) string) string {
+	if _, ok := n.operand.(formulaBinary); ok {
+		return "-(" + n.operand.render(ref) + ")"
+	}
+	return "-" + n.operand.render(ref)
+}
+
+func (n formulaBinary) eval(values map[string]float64) (float64, error) {
+	left, err := n.left.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	}
+}
+
+// precedence ranks operators: * and / bind tighter than + and -
+func (n formulaBinary) precedence() int {
+	if n.op == '*' || n.op == '/' {
+		return 2
+	}
+	return 1
+}
+
+// render only adds the parentheses the operator precedence needs
+func (n formulaBinary) render(ref func(*common.CustomField) string) string {
+	left, right := n.left.render(ref), n.right.render(ref)
+	if child, ok := n.left.(formulaBinary); ok && child.precedence() < n.precedence() {
+		left = "(" + left + ")"
+	}
+	if child, ok := n.right.(formulaBinary); ok && (child.precedence() < n.precedence() || (child.precedence() == n.precedence() && (n.op == '-' || n.op == '/'))) {
+		right = "(" + right + ")"
+	}
+	return fmt.Sprintf("%s %c %s", left, n.op, right)
+}
+
+func (n formulaCall) eval(values map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(values)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+
+	switch n.name {
+	case "ABS":
+		return math.Abs(args[0]), nil
+	case "ROUND":
+		scale := 1.0
+		if len(args) == 2 {
+			scale = math.Pow(10, math.Trunc(args[1]))
+		}
+		return math.Round(args[0]*scale) / scale, nil
+	case "MIN", "MAX":
+		result := args[0]
+		for _, value := range args[1:] {
+			if (n.name == "MIN") == (value < result) {
+				result = value
+			}
+		}
+		return result, nil
+	default:
+		sum := 0.0
+		for _, value := range args {
+			sum += value
+		}
+		if n.name == "AVERAGE" {
+			return sum / float64(len(args)), nil
+		}
+		return sum, nil
+	}
+}
+
+func (n formulaCall) render(ref func(*common.CustomField) string) string {
+	args := make([]string, len(n.args))
+	for i, arg := range n.args {
+		args[i] = arg.render(ref)
+	}
+	return n.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// formulaParser is a recursive descent parser for formula expressions:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | "{" field name "}" | FUNCTION "(" expr { "," expr } ")" | "(" expr ")"
+type formulaParser struct {
+	input  string
+	pos    int
+	fields []common.CustomField
+}
+
+// parseFormula parses an expression such as "{Deal Size} * {Probability} / 100",
+// resolving field names against the project's custom fields
+func parseFormula(expression string, fields []common.CustomField) (formulaNode, error) {
+	p := &formulaParser{input: expression, fields: fields}
+	node, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected '%c'", p.input[p.pos])
+	}
+	return node, nil
+}
+
+func (p *formulaParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("formula error at position %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *formulaParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end
+func (p *formulaParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *formulaParser) expr() (formulaNode, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *formulaParser) term() (formulaNode, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *formulaParser) unary() (formulaNode, error) {
+	if p.peek() == '-' {
+		p.pos++
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return formulaUnary{operand: operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *formulaParser) primary() (formulaNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, p.errorf("unexpected end of formula")
+
+	case c == '(':
+		p.pos++
+		node, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("missing ')'")
+		}
+		p.pos++
+		return node, nil
+
+	case c == '{':
+		end := strings.IndexByte(p.input[p.pos:], '}')
+		if end < 0 {
+			return nil, p.errorf("missing '}' after field name")
+		}
+		name := strings.TrimSpace(p.input[p.pos+1 : p.pos+end])
+		field := findCustomField(p.fields, name)
+		if field == nil {
+			return nil, p.errorf("unknown field {%s}", name)
+		}
+		if !containsFold(formulaFieldTypes, field.Type) {
+			return nil, p.errorf("{%s} is a %s field; formulas can use %s fields", name, field.Type, strings.Join(formulaFieldTypes, ", "))
+		}
+		p.pos += end + 1
+		return formulaField{name: name, field: field}, nil
+
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '.' || (p.input[p.pos] >= '0' && p.input[p.pos] <= '9')) {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			number := p.input[start:p.pos]
+			p.pos = start
+			return nil, p.errorf("invalid number '%s'", number)
+		}
+		return formulaNumber{value: value}, nil
+
+	case unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && unicode.IsLetter(rune(p.input[p.pos])) {
+			p.pos++
+		}
+		name := strings.ToUpper(p.input[start:p.pos])
+		arity, ok := formulaFunctions[name]
+		if !ok {
+			p.pos = start
+			return nil, p.errorf("unknown function %s (field names go in braces, e.g. {%s})", name, p.input[start:start+len(name)])
+		}
+		if p.peek() != '(' {
+			return nil, p.errorf("expected '(' after %s", name)
+		}
+		p.pos++
+		var args []formulaNode
+		for {
+			arg, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("missing ')' after the arguments of %s", name)
+		}
+		p.pos++
+		if len(args) < arity[0] || (arity[1] >= 0 && len(args) > arity[1]) {
+			return nil, p.errorf("%s takes %s argument(s), got %d", name, describeArity(arity), len(args))
+		}
+		return formulaCall{name: name, args: args}, nil
+
+	default:
+		return nil, p.errorf("unexpected '%c'", c)
+	}
+}
+
+// describeArity formats a function's argument count for error messages
+func describeArity(arity [2]int) string {
+	switch {
+	case arity[1] < 0:
+		return fmt.Sprintf("at least %d", arity[0])
+	case arity[0] == arity[1]:
+		return strconv.Itoa(arity[0])
+	default:
+		return fmt.Sprintf("%d or %d", arity[0], arity[1])
+	}
+}
+
+// compileFormula turns an expression into the API's formula structure
+func compileFormula(expression string, fields []common.CustomField, display FormulaDisplayInput) (*FormulaInput, formulaNode, error) {
+	node, err := parseFormula(expression, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text := node.render(func(field *common.CustomField) string {
+		return "{{" + field.ID + "}}"
+	})
+	markup := node.render(func(field *common.CustomField) string {
+		return fmt.Sprintf(`<span class="formula-field" data-id="%s">%s</span>`, field.ID, html.EscapeString(field.Name))
+	})
+
+	return &FormulaInput{
+		Logic:   FormulaLogicInput{Text: text, HTML: "<p>" + markup + "</p>"},
+		Display: display,
+	}, node, nil
+}
+
+// newFormulaDisplay builds the display settings of a formula field
+func newFormulaDisplay(displayType, currency string, precision int) (FormulaDisplayInput, error) {
+	displayType = strings.ToUpper(strings.TrimSpace(displayType))
+	if !containsFold(formulaDisplayTypes, displayType) {
+		return FormulaDisplayInput{}, fmt.Errorf("invalid formula display '%s' (valid: %s)", displayType, strings.Join(formulaDisplayTypes, ", "))
+	}
+	display := FormulaDisplayInput{Type: displayType}
+	if precision >= 0 {
+		value := float64(precision)
+		display.Precision = &value
+	}
+	if displayType == "CURRENCY" {
+		code := strings.ToUpper(currency)
+		if !common.IsCurrencyCode(code) {
+			return FormulaDisplayInput{}, fmt.Errorf("'%s' is not an ISO 4217 currency code", currency)
+		}
+		name := currencyNames[code]
+		if name == "" {
+			name = code
+		}
+		display.Currency = &FormulaDisplayCurrencyInput{Code: code, Name: name}
+	}
+	return display, nil
+}
+
+// formatFormulaResult formats a result the way the display settings show it
+func formatFormulaResult(value float64, display FormulaDisplayInput) string {
+	precision := -1
+	if display.Precision != nil {
+		precision = int(*display.Precision)
+	}
+	number := strconv.FormatFloat(value, 'f', precision, 64)
+	switch display.Type {
+	case "PERCENTAGE":
+		return number + "%"
+	case "CURRENCY":
+		if display.Currency != nil {
+			return number + " " + display.Currency.Code
+		}
+	}
+	return number
+}
+
+// formulaGraphQL renders a formula as a GraphQL input object literal
+func formulaGraphQL(formula *FormulaInput) string {
+	quote := func(s string) string {
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+
+	display := []string{"type: " + formula.Display.Type}
+	if formula.Display.Precision != nil {
+		display = append(display, fmt.Sprintf("precision: %g", *formula.Display.Precision))
+	}
+	if currency := formula.Display.Currency; currency != nil {
+		display = append(display, fmt.Sprintf("currency: { code: %s, name: %s }", quote(currency.Code), quote(currency.Name)))
+	}
+
+	return fmt.Sprintf("{ logic: { text: %s, html: %s }, display: { %s } }",
+		quote(formula.Logic.Text), quote(formula.Logic.HTML), strings.Join(display, ", "))
+}
+
+// explainFormula renders a stored formula as an expression with field names,
+// together with its display settings
+func explainFormula(formula interface{}, fields []common.CustomField) (string, string) {
+	data, err := json.Marshal(formula)
+	if err != nil || formula == nil {
+		return "", ""
+	}
+	var stored struct {
+		Logic *struct {
+			Text string `json:"text"`
+		} `json:"logic"`
+		Display *struct {
+			Type      string   `json:"type"`
+			Precision *float64 `json:"precision"`
+			Currency  *struct {
+				Code string `json:"code"`
+			} `json:"currency"`
+		} `json:"display"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Logic == nil {
+		return string(data), ""
+	}
+
+	expression := formulaReferencePattern.ReplaceAllStringFunc(stored.Logic.Text, func(match string) string {
+		id := strings.TrimSpace(match[2 : len(match)-2])
+		for _, field := range fields {
+			if field.ID == id {
+				return "{" + field.Name + "}"
+			}
+		}
+		return "{? " + id + "}"
+	})
+
+	display := ""
+	if stored.Display != nil {
+		display = stored.Display.Type
+		if stored.Display.Currency != nil && stored.Display.Currency.Code != "" {
+			display += " " + stored.Display.Currency.Code
+		}
+		if stored.Display.Precision != nil {
+			display += fmt.Sprintf(", %g decimal(s)", *stored.Display.Precision)
+		}
+	}
+	return expression, display
+}
+
+// printFormulaExplanations prints the formula of every FORMULA field
+func printFormulaExplanations(fields []common.CustomField) {
+	count := 0
+	for _, field := range fields {
+		if field.Type != "FORMULA" {
+			continue
+		}
+		count++
+		expression, display := explainFormula(field.Formula, fields)
+		fmt.Printf("\n%s\n", field.Name)
+		fmt.Printf("   🔑 Field ID: %s\n", field.ID)
+		if expression == "" {
+			fmt.Println("   🧮 Formula:  (not set)")
+			continue
+		}
+		fmt.Printf("   🧮 Formula:  %s\n", expression)
+		if display != "" {
+			fmt.Printf("   📐 Display:  %s\n", display)
+		}
+	}
+	if count == 0 {
+		fmt.Println("No FORMULA fields found")
+	}
+}
+
+// FormulaSample is a record with the numeric custom field values a formula can use
+type FormulaSample struct {
+	Title  string
+	Values map[string]float64
+}
+
+// fetchFormulaSamples returns up to limit records of a project with their numeric field values
+func fetchFormulaSamples(client *common.Client, projectID string, limit int) ([]FormulaSample, error) {
+	lists, err := fetchProjectLists(client, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		query FormulaSamples($todoListId: String!, $first: Int) {
+			todoList(id: $todoListId) {
+				todos(first: $first, orderBy: position_ASC) {
+					title
+					customFields {
+						id
+						number
+					}
+				}
+			}
+		}
+	`
+
+	var samples []FormulaSample
+	for _, list := range lists {
+		if len(samples) >= limit {
+			break
+		}
+		var response struct {
+			TodoList struct {
+				Todos []struct {
+					Title        string `json:"title"`
+					CustomFields []struct {
+						ID     string   `json:"id"`
+						Number *float64 `json:"number"`
+					} `json:"customFields"`
+				} `json:"todos"`
+			} `json:"todoList"`
+		}
+		variables := map[string]interface{}{"todoListId": list.ID, "first": limit - len(samples)}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		for _, todo := range response.TodoList.Todos {
+			sample := FormulaSample{Title: todo.Title, Values: make(map[string]float64)}
+			for _, value := range todo.CustomFields {
+				if value.Number != nil {
+					sample.Values[value.ID] = *value.Number
+				}
+			}
+			samples = append(samples, sample)
+		}
+	}
+	return samples, nil
+}
+
+// parseFormulaSample parses sample values such as "Deal Size=1000;Probability=40"
+func parseFormulaSample(sample string, fields []common.CustomField) (map[string]float64, error) {
+	values := make(map[string]float64)
+	for _, pair := range strings.Split(sample, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid sample value '%s' (expected Field=number)", strings.TrimSpace(pair))
+		}
+		name := strings.Trim(strings.TrimSpace(parts[0]), "{}")
+		field := findCustomField(fields, name)
+		if field == nil {
+			return nil, fmt.Errorf("unknown field '%s' in sample", name)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("sample value of '%s' must be a number, got '%s'", name, strings.TrimSpace(parts[1]))
+		}
+		values[field.ID] = value
+	}
+	return values, nil
+}
+
+// previewFormula evaluates a formula for sample records and prints the results
+func previewFormula(node formulaNode, display FormulaDisplayInput, samples []FormulaSample) {
+	if len(samples) == 0 {
+		fmt.Println("   (no records to preview)")
+		return
+	}
+	for _, sample := range samples {
+		result, err := node.eval(sample.Values)
+		if err != nil {
+			fmt.Printf("   %-40s ⚠️  %v\n", truncateString(sample.Title, 40), err)
+			continue
+		}
+		fmt.Printf("   %-40s %s\n", truncateString(sample.Title, 40), formatFormulaResult(result, display))
+	}
+}
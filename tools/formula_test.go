@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"demo-builder/common"
+)
+
+func TestParseFormula(t *testing.T) {
+	fields := []common.CustomField{
+		{ID: "f-a", Name: "A", Type: "NUMBER"},
+		{ID: "f-b", Name: "B", Type: "CURRENCY"},
+		{ID: "f-c", Name: "C", Type: "PERCENT"},
+		{ID: "f-note", Name: "Note", Type: "TEXT_SINGLE"},
+	}
+	values := map[string]float64{"f-a": 10, "f-b": 4, "f-c": 2}
+	byName := func(field *common.CustomField) string { return "{" + field.Name + "}" }
+
+	tests := []struct {
+		expr   string
+		want   float64
+		render string
+	}{
+		{"1 + 2 * 3", 7, "1 + 2 * 3"},
+		{"(1 + 2) * 3", 9, "(1 + 2) * 3"},
+		{"{A} - {B} - {C}", 4, "{A} - {B} - {C}"},
+		{"{A} - ({B} - {C})", 8, "{A} - ({B} - {C})"},
+		{"{A} / ({B} * {C})", 1.25, "{A} / ({B} * {C})"},
+		{"{A} / {B} * {C}", 5, "{A} / {B} * {C}"},
+		{"-{A} + {B}", -6, "-{A} + {B}"},
+		{"-({A} + {B})", -14, "-({A} + {B})"},
+		{"{A} * -{B}", -40, "{A} * -{B}"},
+		{"--{A}", 10, "--{A}"},
+		{"SUM({A}, {B}, {C})", 16, "SUM({A}, {B}, {C})"},
+		{"average({A}, {B})", 7, "AVERAGE({A}, {B})"},
+		{"MAX({A}, {B}) - MIN({B}, {C})", 8, "MAX({A}, {B}) - MIN({B}, {C})"},
+		{"ROUND({A} / 3, 2)", 3.33, "ROUND({A} / 3, 2)"},
+		{"ABS({C} - {A})", 8, "ABS({C} - {A})"},
+		{"{ f-a } * .5", 5, "{A} * 0.5"},
+	}
+	for _, tt := range tests {
+		node, err := parseFormula(tt.expr, fields)
+		if err != nil {
+			t.Errorf("parseFormula(%q): unexpected error: %v", tt.expr, err)
+			continue
+		}
+		got, err := node.eval(values)
+		if err != nil || got != tt.want {
+			t.Errorf("parseFormula(%q).eval = %v, %v; want %v", tt.expr, got, err, tt.want)
+		}
+		rendered := node.render(byName)
+		if rendered != tt.render {
+			t.Errorf("parseFormula(%q).render = %q; want %q", tt.expr, rendered, tt.render)
+		}
+
+		// The rendered expression must parse back to the same value
+		reparsed, err := parseFormula(rendered, fields)
+		if err != nil {
+			t.Errorf("reparsing %q: %v", rendered, err)
+			continue
+		}
+		if again, _ := reparsed.eval(values); again != got {
+			t.Errorf("reparsing %q = %v; want %v", rendered, again, got)
+		}
+	}
+}
+
+func TestParseFormulaErrors(t *testing.T) {
+	fields := []common.CustomField{
+		{ID: "f-a", Name: "A", Type: "NUMBER"},
+		{ID: "f-note", Name: "Note", Type: "TEXT_SINGLE"},
+	}
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "unexpected end of formula"},
+		{"{A} +", "unexpected end of formula"},
+		{"({A} + 1", "missing ')'"},
+		{"{A} 1", "unexpected '1'"},
+		{"{Missing} * 2", "unknown field {Missing}"},
+		{"{A", "missing '}'"},
+		{"{Note} * 2", "is a TEXT_SINGLE field"},
+		{"Deal * 2", "unknown function DEAL"},
+		{"ABS({A}, 1)", "ABS takes 1 argument(s), got 2"},
+		{"ROUND({A}, 1, 2)", "ROUND takes 1 or 2 argument(s), got 3"},
+		{"SUM()", "unexpected ')'"},
+		{"ABS {A}", "expected '(' after ABS"},
+		{"1.2.3", "invalid number '1.2.3'"},
+	}
+	for _, tt := range tests {
+		_, err := parseFormula(tt.expr, fields)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseFormula(%q) error = %v; want it to mention %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestFormulaDivisionByZero(t *testing.T) {
+	fields := []common.CustomField{{ID: "f-a", Name: "A", Type: "NUMBER"}}
+	node, err := parseFormula("1 / {A}", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := node.eval(map[string]float64{}); err == nil {
+		t.Errorf("eval of 1 / {A} with an empty {A}: want a division by zero error")
+	}
+}
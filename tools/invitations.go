@@ -0,0 +1,507 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"demo-builder/common"
+)
+
+// Invitation is a pending invitation to a company or project
+type Invitation struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	AccessLevel string `json:"accessLevel"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+	ExpiredAt   string `json:"expiredAt"`
+	InvitedBy   struct {
+		ID       string `json:"id"`
+		FullName string `json:"fullName"`
+		Email    string `json:"email"`
+	} `json:"invitedBy"`
+	Project *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// age returns how long ago the invitation was last sent
+func (i *Invitation) age() time.Duration {
+	sent := i.UpdatedAt
+	if sent == "" {
+		sent = i.CreatedAt
+	}
+	t, err := time.Parse(time.RFC3339, sent)
+	if err != nil {
+		return 0
+	}
+	return time.Since(t)
+}
+
+// projectName returns the name of the invitation's project, or "-"
+func (i *Invitation) projectName() string {
+	if i.Project == nil || i.Project.Name == "" {
+		return "-"
+	}
+	return i.Project.Name
+}
+
+// invitationFields is the selection used by every invitation query
+const invitationFields = `
+	id
+	email
+	accessLevel
+	createdAt
+	updatedAt
+	expiredAt
+	invitedBy {
+		id
+		fullName
+		email
+	}
+	project {
+		id
+		name
+	}
+`
+
+// parseAge parses an age such as "7d", "2w" or "1mo" with the shared day parser
+func parseAge(value string) (time.Duration, error) {
+	days, err := common.ParseDays(value)
+	if err != nil {
+		return 0, err
+	}
+	if days < 0 {
+		return 0, fmt.Errorf("invalid age '%s': must not be negative", value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// formatAge formats a duration as whole days, or hours under a day
+func formatAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// fetchInvitations returns the invitations of the company in the client context,
+// optionally only those of one project
+func fetchInvitations(client *common.Client, projectID, search string, pending bool) ([]Invitation, error) {
+	query := `
+		query Invitations($projectId: String, $search: String, $pending: Boolean, $first: Int, $skip: Int) {
+			invitations(projectId: $projectId, search: $search, pending: $pending, first: $first, skip: $skip) {` + invitationFields + `}
+		}
+	`
+
+	var invitations []Invitation
+	for skip := 0; ; skip += 100 {
+		variables := map[string]interface{}{
+			"pending": pending,
+			"first":   100,
+			"skip":    skip,
+		}
+		if projectID != "" {
+			variables["projectId"] = projectID
+		}
+		if search != "" {
+			variables["search"] = search
+		}
+
+		var response struct {
+			Invitations []Invitation `json:"invitations"`
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, response.Invitations...)
+		if len(response.Invitations) < 100 {
+			break
+		}
+	}
+	return invitations, nil
+}
+
+// fetchMyInvitations returns the invitations sent to the authenticated user
+func fetchMyInvitations(client *common.Client) ([]Invitation, error) {
+	query := `
+		query MyInvitations {
+			myInvitations {` + invitationFields + `}
+		}
+	`
+
+	var response struct {
+		MyInvitations []Invitation `json:"myInvitations"`
+	}
+	if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.MyInvitations, nil
+}
+
+// executeResendInvitation sends an invitation email again
+func executeResendInvitation(client *common.Client, id string) error {
+	mutation := `
+		mutation ResendInvitation($id: String!) {
+			resendInvitation(id: $id) {
+				id
+			}
+		}
+	`
+
+	var response struct {
+		ResendInvitation struct {
+			ID string `json:"id"`
+		} `json:"resendInvitation"`
+	}
+	return client.ExecuteQueryWithResult(mutation, map[string]interface{}{"id": id}, &response)
+}
+
+// executeCancelInvitation withdraws a pending invitation
+func executeCancelInvitation(client *common.Client, id string) error {
+	mutation := `
+		mutation CancelInvitation($id: String!) {
+			cancelInvitation(id: $id)
+		}
+	`
+
+	var response struct {
+		CancelInvitation bool `json:"cancelInvitation"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"id": id}, &response); err != nil {
+		return err
+	}
+	if !response.CancelInvitation {
+		return fmt.Errorf("the API reported that the invitation was not cancelled")
+	}
+	return nil
+}
+
+// executeAnswerInvitation accepts or rejects an invitation sent to the authenticated user
+func executeAnswerInvitation(client *common.Client, id string, accept bool) error {
+	if accept {
+		mutation := `
+			mutation AcceptInvitation($id: String!) {
+				acceptInvitation(id: $id) {
+					success
+				}
+			}
+		`
+		var response struct {
+			AcceptInvitation common.MutationResult `json:"acceptInvitation"`
+		}
+		if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"id": id}, &response); err != nil {
+			return err
+		}
+		if !response.AcceptInvitation.Success {
+			return fmt.Errorf("the API reported that the invitation was not accepted")
+		}
+		return nil
+	}
+
+	mutation := `
+		mutation RejectInvitation($id: String!) {
+			rejectInvitation(id: $id)
+		}
+	`
+	var response struct {
+		RejectInvitation *bool `json:"rejectInvitation"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"id": id}, &response); err != nil {
+		return err
+	}
+	if response.RejectInvitation != nil && !*response.RejectInvitation {
+		return fmt.Errorf("the API reported that the invitation was not rejected")
+	}
+	return nil
+}
+
+// invitationFilter selects invitations by ID, email and age
+type invitationFilter struct {
+	ids       map[string]bool
+	emails    map[string]bool
+	olderThan time.Duration
+}
+
+// newInvitationFilter builds a filter from comma-separated IDs and emails and an age
+func newInvitationFilter(ids, emails, olderThan string) (*invitationFilter, error) {
+	filter := &invitationFilter{ids: make(map[string]bool), emails: make(map[string]bool)}
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			filter.ids[id] = true
+		}
+	}
+	for _, email := range strings.Split(emails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			filter.emails[strings.ToLower(email)] = true
+		}
+	}
+	if olderThan != "" {
+		age, err := parseAge(olderThan)
+		if err != nil {
+			return nil, err
+		}
+		filter.olderThan = age
+	}
+	return filter, nil
+}
+
+// empty reports whether the filter selects nothing in particular
+func (f *invitationFilter) empty() bool {
+	return len(f.ids) == 0 && len(f.emails) == 0 && f.olderThan == 0
+}
+
+// apply returns the invitations that match every criterion of the filter
+func (f *invitationFilter) apply(invitations []Invitation) []Invitation {
+	var matched []Invitation
+	for _, invitation := range invitations {
+		if len(f.ids) > 0 && !f.ids[invitation.ID] {
+			continue
+		}
+		if len(f.emails) > 0 && !f.emails[strings.ToLower(invitation.Email)] {
+			continue
+		}
+		if f.olderThan > 0 && invitation.age() < f.olderThan {
+			continue
+		}
+		matched = append(matched, invitation)
+	}
+	return matched
+}
+
+// newInvitationsClient loads the configuration and resolves an optional project to its ID
+func newInvitationsClient(projectRef string) (*common.Client, string, error) {
+	config, err := common.LoadConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	if projectRef == "" {
+		return client, "", nil
+	}
+
+	client.SetProject(projectRef)
+	project, err := getCurrentProject(client, projectRef)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch project: %v", err)
+	}
+	return client, project.ID, nil
+}
+
+// printInvitations prints invitations as a table, or tab-separated with simple
+func printInvitations(invitations []Invitation, simple bool) {
+	if simple {
+		for _, invitation := range invitations {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", invitation.ID, invitation.Email, invitation.AccessLevel, invitation.projectName(), formatAge(invitation.age()))
+		}
+		return
+	}
+	for _, invitation := range invitations {
+		fmt.Printf("  %-26s %-35s %-12s %-25s %5s  by %s\n",
+			invitation.ID, invitation.Email, invitation.AccessLevel,
+			truncateString(invitation.projectName(), 25), formatAge(invitation.age()), invitation.InvitedBy.FullName)
+	}
+}
+
+// printInvitationsUsage prints the usage of the invitations command group
+func printInvitationsUsage() {
+	fmt.Println("Usage: go run . invitations <subcommand> [flags]")
+	fmt.Println("\nSubcommands:")
+	fmt.Println("  list      List pending invitations (or your own with -mine)")
+	fmt.Println("  resend    Send invitation emails again")
+	fmt.Println("  cancel    Cancel pending invitations")
+	fmt.Println("  accept    Accept an invitation sent to you")
+	fmt.Println("  reject    Reject an invitation sent to you")
+	fmt.Println("\nExamples:")
+	fmt.Println("  go run . invitations list")
+	fmt.Println("  go run . invitations list -project acme -older-than 7d")
+	fmt.Println("  go run . invitations resend -email ann@acme.com")
+	fmt.Println("  go run . invitations resend -older-than 7d -dry-run")
+	fmt.Println("  go run . invitations cancel -older-than 30d -project acme -confirm")
+	fmt.Println("  go run . invitations accept -id INVITATION_ID")
+}
+
+// RunInvitations manages pending invitations
+func RunInvitations(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		printInvitationsUsage()
+		return fmt.Errorf("subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		return runInvitationsList(args[1:])
+	case "resend":
+		return runInvitationsChange("resend", args[1:])
+	case "cancel":
+		return runInvitationsChange("cancel", args[1:])
+	case "accept":
+		return runInvitationsAnswer("accept", args[1:])
+	case "reject":
+		return runInvitationsAnswer("reject", args[1:])
+	default:
+		printInvitationsUsage()
+		return fmt.Errorf("unknown subcommand '%s'", args[0])
+	}
+}
+
+// runInvitationsList prints pending invitations
+func runInvitationsList(args []string) error {
+	fs := flag.NewFlagSet("invitations list", flag.ExitOnError)
+	projectRef := fs.String("project", "", "Only invitations to this project (ID or slug)")
+	olderThan := fs.String("older-than", "", "Only invitations sent longer ago than this (e.g. 7d, 2w, 1mo)")
+	search := fs.String("search", "", "Search by email")
+	all := fs.Bool("all", false, "Include invitations that are no longer pending")
+	mine := fs.Bool("mine", false, "List the invitations sent to you instead")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	filter, err := newInvitationFilter("", "", *olderThan)
+	if err != nil {
+		return err
+	}
+	client, projectID, err := newInvitationsClient(*projectRef)
+	if err != nil {
+		return err
+	}
+
+	var invitations []Invitation
+	if *mine {
+		invitations, err = fetchMyInvitations(client)
+	} else {
+		invitations, err = fetchInvitations(client, projectID, *search, !*all)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch invitations: %v", err)
+	}
+	invitations = filter.apply(invitations)
+
+	if !*simple {
+		fmt.Printf("\n=== Invitations (%d) ===\n", len(invitations))
+		if len(invitations) == 0 {
+			fmt.Println("  No invitations found")
+			return nil
+		}
+	}
+	printInvitations(invitations, *simple)
+	return nil
+}
+
+// runInvitationsChange implements resend and cancel for one or many invitations
+func runInvitationsChange(command string, args []string) error {
+	fs := flag.NewFlagSet("invitations "+command, flag.ExitOnError)
+	ids := fs.String("id", "", "Comma-separated invitation IDs")
+	emails := fs.String("email", "", "Comma-separated invited emails")
+	olderThan := fs.String("older-than", "", "All pending invitations sent longer ago than this (e.g. 7d, 2w, 1mo)")
+	projectRef := fs.String("project", "", "Only invitations to this project (ID or slug)")
+	dryRun := fs.Bool("dry-run", false, "Show the matching invitations without changing anything")
+	confirm := fs.Bool("confirm", false, "Confirm cancellation (required for cancel)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	filter, err := newInvitationFilter(*ids, *emails, *olderThan)
+	if err != nil {
+		return err
+	}
+	if filter.empty() {
+		fmt.Printf("Usage: go run . invitations %s (-id IDS | -email EMAILS | -older-than AGE) [flags]\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Printf("  go run . invitations %s -email ann@acme.com\n", command)
+		fmt.Printf("  go run . invitations %s -older-than 7d -project acme -dry-run\n", command)
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, projectID, err := newInvitationsClient(*projectRef)
+	if err != nil {
+		return err
+	}
+	invitations, err := fetchInvitations(client, projectID, "", true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch invitations: %v", err)
+	}
+	matched := filter.apply(invitations)
+	if len(matched) == 0 {
+		if !*simple {
+			fmt.Println("No matching pending invitations")
+		}
+		return nil
+	}
+
+	if *dryRun {
+		if !*simple {
+			fmt.Printf("Dry run: %d invitation(s) would be %s\n\n", len(matched), map[string]string{"resend": "resent", "cancel": "cancelled"}[command])
+		}
+		printInvitations(matched, *simple)
+		return nil
+	}
+	if command == "cancel" && !*confirm {
+		fmt.Printf("⚠️  This will cancel %d invitation(s):\n", len(matched))
+		printInvitations(matched, false)
+		return fmt.Errorf("cancellation confirmation is required. Use -confirm flag to confirm")
+	}
+
+	done, failed := 0, 0
+	for _, invitation := range matched {
+		if command == "resend" {
+			err = executeResendInvitation(client, invitation.ID)
+		} else {
+			err = executeCancelInvitation(client, invitation.ID)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s (%s): %v\n", invitation.Email, invitation.projectName(), err)
+			continue
+		}
+		done++
+		if *simple {
+			fmt.Println(invitation.ID)
+		} else {
+			fmt.Printf("✅ %s (%s)\n", invitation.Email, invitation.projectName())
+		}
+	}
+
+	if !*simple {
+		if command == "resend" {
+			fmt.Printf("\n✅ Resent %d of %d invitation(s)\n", done, len(matched))
+		} else {
+			fmt.Printf("\n✅ Cancelled %d of %d invitation(s)\n", done, len(matched))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d invitation(s) failed", failed)
+	}
+	return nil
+}
+
+// runInvitationsAnswer implements accept and reject for invitations sent to the authenticated user
+func runInvitationsAnswer(command string, args []string) error {
+	fs := flag.NewFlagSet("invitations "+command, flag.ExitOnError)
+	id := fs.String("id", "", "Invitation ID (see: invitations list -mine) (required)")
+	fs.Parse(args)
+
+	if *id == "" {
+		fmt.Printf("Usage: go run . invitations %s -id INVITATION_ID\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, _, err := newInvitationsClient("")
+	if err != nil {
+		return err
+	}
+	if err := executeAnswerInvitation(client, *id, command == "accept"); err != nil {
+		return fmt.Errorf("failed to %s invitation: %v", command, err)
+	}
+	if command == "accept" {
+		fmt.Printf("✅ Invitation %s accepted\n", *id)
+	} else {
+		fmt.Printf("✅ Invitation %s rejected\n", *id)
+	}
+	return nil
+}
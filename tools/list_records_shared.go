@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"fmt"
+
+	"demo-builder/common"
+)
+
+// listTodosPageSize is how many records are requested per page of a list
+const listTodosPageSize = 500
+
+// fetchListTodos returns every record of a list, paging until a short page comes back.
+// selection is the GraphQL selection for each record and T the type it decodes into.
+func fetchListTodos[T any](client *common.Client, listID, selection string) ([]T, error) {
+	query := fmt.Sprintf(`
+		query ListTodos($todoListId: String!, $first: Int, $skip: Int) {
+			todoList(id: $todoListId) {
+				todos(first: $first, skip: $skip, orderBy: position_ASC) {
+					%s
+				}
+			}
+		}
+	`, selection)
+
+	var todos []T
+	for skip := 0; ; skip += listTodosPageSize {
+		var response struct {
+			TodoList struct {
+				Todos []T `json:"todos"`
+			} `json:"todoList"`
+		}
+		variables := map[string]interface{}{
+			"todoListId": listID,
+			"first":      listTodosPageSize,
+			"skip":       skip,
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		todos = append(todos, response.TodoList.Todos...)
+		if len(response.TodoList.Todos) < listTodosPageSize {
+			return todos, nil
+		}
+	}
+}
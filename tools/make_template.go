@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+
+	"demo-builder/common"
+)
+
+// executeConvertProjectToTemplate turns a project into a template
+func executeConvertProjectToTemplate(client *common.Client, projectID string, official bool) error {
+	mutation := `
+		mutation ConvertProjectToTemplate($input: ConvertProjectToTemplateInput!) {
+			convertProjectToTemplate(input: $input) {
+				id
+				isTemplate
+			}
+		}
+	`
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"projectId":          projectID,
+			"isOfficialTemplate": official,
+		},
+	}
+
+	var response struct {
+		ConvertProjectToTemplate struct {
+			ID         string `json:"id"`
+			IsTemplate bool   `json:"isTemplate"`
+		} `json:"convertProjectToTemplate"`
+	}
+	return client.ExecuteQueryWithResult(mutation, variables, &response)
+}
+
+// executeRemoveProjectFromTemplates turns a template back into a regular project
+func executeRemoveProjectFromTemplates(client *common.Client, projectID string) error {
+	mutation := `
+		mutation RemoveProjectFromTemplates($input: RemoveProjectFromTemplatesInput!) {
+			removeProjectFromTemplates(input: $input) {
+				id
+				isTemplate
+			}
+		}
+	`
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"projectId": projectID,
+		},
+	}
+
+	var response struct {
+		RemoveProjectFromTemplates struct {
+			ID         string `json:"id"`
+			IsTemplate bool   `json:"isTemplate"`
+		} `json:"removeProjectFromTemplates"`
+	}
+	return client.ExecuteQueryWithResult(mutation, variables, &response)
+}
+
+// runSetProjectTemplate implements make-template and unmake-template
+func runSetProjectTemplate(command string, template bool, args []string) error {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	official := false
+	if template {
+		fs.BoolVar(&official, "official", false, "Mark as an official template (Blue staff only)")
+	}
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" {
+		fmt.Printf("Usage: go run . %s -project PROJECT [flags]\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Printf("  go run . %s -project my-project\n", command)
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	project, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+
+	if template {
+		err = executeConvertProjectToTemplate(client, project.ID, official)
+	} else {
+		err = executeRemoveProjectFromTemplates(client, project.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update project: %v", err)
+	}
+
+	switch {
+	case *simple:
+		fmt.Println(project.ID)
+	case template:
+		fmt.Printf("✅ Project '%s' is now a template\n", project.Name)
+		fmt.Println("   Create projects from it with: go run . create-project -template " + project.ID)
+	default:
+		fmt.Printf("✅ Project '%s' is no longer a template\n", project.Name)
+	}
+	return nil
+}
+
+// RunMakeTemplate converts a project into a template
+func RunMakeTemplate(args []string) error {
+	return runSetProjectTemplate("make-template", true, args)
+}
+
+// RunUnmakeTemplate turns a template back into a regular project
+func RunUnmakeTemplate(args []string) error {
+	return runSetProjectTemplate("unmake-template", false, args)
+}
@@ -399,11 +399,15 @@ func ManageCustomFieldGroups(args []string) error {
 	fs := flag.NewFlagSet("manage-field-groups", flag.ExitOnError)
 
 	projectID := fs.String("project", "", "Project ID or slug (required)")
-	action := fs.String("action", "", "Action to perform: create, add-field, delete, rename, recolor, move-in, move-out (required)")
+	action := fs.String("action", "", "Action to perform: create, add-field, delete, rename, recolor, move-in, move-out, layout, tree (required)")
 	name := fs.String("name", "", "Group name (for create, rename)")
 	color := fs.String("color", "", "Group color (for create, recolor)")
 	groupID := fs.String("group", "", "Group ID (for delete, rename, recolor, move-in)")
 	fieldID := fs.String("field", "", "Field ID (for add-field, move-in, move-out)")
+	layoutFile := fs.String("file", "", "YAML or JSON file with the complete field layout (for layout)")
+	dryRun := fs.Bool("dry-run", false, "Show the current and new layout without changing anything (for layout)")
+	retries := fs.Int("retries", 3, "Times to retry when the layout changes concurrently (for layout)")
+	format := fs.String("format", "tree", "Output format: tree, yaml or json (for tree)")
 
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
@@ -414,7 +418,7 @@ func ManageCustomFieldGroups(args []string) error {
 	}
 
 	if *action == "" {
-		return fmt.Errorf("action is required. Use -action flag (create, add-field, delete, rename, recolor, move-in, move-out)")
+		return fmt.Errorf("action is required. Use -action flag (create, add-field, delete, rename, recolor, move-in, move-out, layout, tree)")
 	}
 
 	// Load configuration
@@ -476,7 +480,16 @@ func ManageCustomFieldGroups(args []string) error {
 		}
 		return actionMoveOut(client, *projectID, *fieldID)
 
+	case "layout":
+		if *layoutFile == "" {
+			return fmt.Errorf("layout file is required for layout action. Use -file flag (see -action tree -format yaml for the current one)")
+		}
+		return actionLayout(client, *projectID, *layoutFile, *dryRun, *retries)
+
+	case "tree":
+		return actionTree(client, *projectID, strings.ToLower(*format))
+
 	default:
-		return fmt.Errorf("invalid action '%s'. Valid actions: create, add-field, delete, rename, recolor, move-in, move-out", *action)
+		return fmt.Errorf("invalid action '%s'. Valid actions: create, add-field, delete, rename, recolor, move-in, move-out, layout, tree", *action)
 	}
 }
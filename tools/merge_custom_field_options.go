@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// optionRecord is a record with the options it has selected in a field
+type optionRecord struct {
+	ID        string
+	Title     string
+	OptionIDs []string
+}
+
+// optionTodo is a record as fetched with optionTodoSelection
+type optionTodo struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	CustomFields []struct {
+		ID             string `json:"id"`
+		SelectedOption *struct {
+			ID string `json:"id"`
+		} `json:"selectedOption"`
+		SelectedOptions []struct {
+			ID string `json:"id"`
+		} `json:"selectedOptions"`
+	} `json:"customFields"`
+}
+
+// optionTodoSelection selects a record with the options chosen in each of its fields
+const optionTodoSelection = `
+	id
+	title
+	customFields {
+		id
+		selectedOption {
+			id
+		}
+		selectedOptions {
+			id
+		}
+	}`
+
+// fetchOptionRecords returns the records of a project that have any option of the field selected
+func fetchOptionRecords(client *common.Client, projectID, fieldID string) ([]optionRecord, error) {
+	lists, err := fetchProjectLists(client, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lists: %v", err)
+	}
+
+	var records []optionRecord
+	for _, list := range lists {
+		todos, err := fetchListTodos[optionTodo](client, list.ID, optionTodoSelection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch records of '%s': %v", list.Title, err)
+		}
+		for _, todo := range todos {
+			for _, value := range todo.CustomFields {
+				if value.ID != fieldID {
+					continue
+				}
+				record := optionRecord{ID: todo.ID, Title: todo.Title}
+				if value.SelectedOption != nil {
+					record.OptionIDs = append(record.OptionIDs, value.SelectedOption.ID)
+				}
+				for _, option := range value.SelectedOptions {
+					if !containsFold(record.OptionIDs, option.ID) {
+						record.OptionIDs = append(record.OptionIDs, option.ID)
+					}
+				}
+				if len(record.OptionIDs) > 0 {
+					records = append(records, record)
+				}
+			}
+		}
+	}
+	return records, nil
+}
+
+// mergedOptionIDs replaces the source options of a selection with the target option.
+// It reports false when the selection doesn't use any source option.
+func mergedOptionIDs(selected []string, sources map[string]bool, targetID string) ([]string, bool) {
+	var merged []string
+	changed := false
+	for _, id := range selected {
+		if sources[id] {
+			changed = true
+			continue
+		}
+		merged = append(merged, id)
+	}
+	if !changed {
+		return nil, false
+	}
+	if !containsFold(merged, targetID) {
+		merged = append(merged, targetID)
+	}
+	return merged, true
+}
+
+// RunMergeCustomFieldOptions moves every record from some options to another one and deletes the source options
+func RunMergeCustomFieldOptions(args []string) error {
+	fs := flag.NewFlagSet("merge-custom-field-options", flag.ExitOnError)
+	fieldRef := fs.String("field", "", "Custom field ID or name (required)")
+	projectID := fs.String("project", "", "Project ID or slug whose records are re-pointed (required)")
+	from := fs.String("from", "", "Comma-separated titles or IDs of the options to merge away (required)")
+	into := fs.String("into", "", "Title or ID of the option to merge into (required)")
+	keep := fs.Bool("keep", false, "Keep the source options after moving their records")
+	dryRun := fs.Bool("dry-run", false, "Show which records would change without changing anything")
+	confirm := fs.Bool("confirm", false, "Confirm the merge (required unless -dry-run)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *fieldRef == "" || *projectID == "" || *from == "" || *into == "" {
+		fmt.Println("Usage: go run . merge-custom-field-options -project PROJECT -field FIELD -from \"A,B\" -into \"C\" [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Preview merging two lead stages")
+		fmt.Println("  go run . merge-custom-field-options -project PROJECT_ID -field \"Stage\" -from \"Hot,Warm\" -into \"Qualified\" -dry-run")
+		fmt.Println("")
+		fmt.Println("  # Merge them and delete the old options")
+		fmt.Println("  go run . merge-custom-field-options -project PROJECT_ID -field \"Stage\" -from \"Hot,Warm\" -into \"Qualified\" -confirm")
+		return fmt.Errorf("required flags missing")
+	}
+	if !*dryRun && !*confirm {
+		return fmt.Errorf("-confirm flag is required for safety (or use -dry-run to preview)")
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	field, err := fetchOptionField(client, *fieldRef, *projectID)
+	if err != nil {
+		return err
+	}
+	target, err := findFieldOption(field, *into)
+	if err != nil {
+		return err
+	}
+	sources := make(map[string]bool)
+	var sourceOptions []PositionedFieldOption
+	for _, ref := range strings.Split(*from, ",") {
+		if strings.TrimSpace(ref) == "" {
+			continue
+		}
+		option, err := findFieldOption(field, ref)
+		if err != nil {
+			return err
+		}
+		if option.ID == target.ID {
+			return fmt.Errorf("'%s' can't be merged into itself", option.Title)
+		}
+		if !sources[option.ID] {
+			sources[option.ID] = true
+			sourceOptions = append(sourceOptions, *option)
+		}
+	}
+	if len(sourceOptions) == 0 {
+		return fmt.Errorf("no options to merge")
+	}
+
+	records, err := fetchOptionRecords(client, *projectID, field.ID)
+	if err != nil {
+		return err
+	}
+
+	var sourceTitles []string
+	for _, option := range sourceOptions {
+		sourceTitles = append(sourceTitles, option.Title)
+	}
+	if !*simple {
+		fmt.Printf("=== Merging %s into '%s' (%s) ===\n", strings.Join(sourceTitles, ", "), target.Title, field.Name)
+	}
+
+	var changed, failed int
+	for _, record := range records {
+		optionIDs, ok := mergedOptionIDs(record.OptionIDs, sources, target.ID)
+		if !ok {
+			continue
+		}
+		if *dryRun {
+			changed++
+			if !*simple {
+				fmt.Printf("  ~ %s [%s]\n", record.Title, record.ID)
+			}
+			continue
+		}
+		encoded := map[string]interface{}{"customFieldOptionIds": optionIDs}
+		if field.Type == "SELECT_SINGLE" {
+			encoded = map[string]interface{}{"customFieldOptionId": target.ID}
+		}
+		value := common.CustomFieldValue{CustomFieldID: field.ID, Value: encoded}
+		if err := executeSetCustomFields(client, record.ID, []common.CustomFieldValue{value}); err != nil {
+			failed++
+			fmt.Printf("❌ %s [%s]: %v\n", record.Title, record.ID, err)
+			continue
+		}
+		changed++
+		if !*simple {
+			fmt.Printf("✅ %s [%s]\n", record.Title, record.ID)
+		}
+	}
+
+	if *dryRun {
+		fmt.Printf("\nDry run: %d record(s) would move to '%s'", changed, target.Title)
+		if !*keep {
+			fmt.Printf(" and %d option(s) would be deleted", len(sourceOptions))
+		}
+		fmt.Println()
+		return nil
+	}
+	if failed > 0 {
+		// Deleting the options now would strand the records that failed to move
+		fmt.Printf("\n⚠️  Moved %d record(s); %d failed, so the source options were kept\n", changed, failed)
+		return fmt.Errorf("some records could not be moved")
+	}
+
+	var deleted int
+	if !*keep {
+		// Records created or changed during the merge would lose their value with the option
+		records, err := fetchOptionRecords(client, *projectID, field.ID)
+		if err != nil {
+			return fmt.Errorf("failed to re-check records before deleting options: %v", err)
+		}
+		var remaining int
+		for _, record := range records {
+			if _, ok := mergedOptionIDs(record.OptionIDs, sources, target.ID); ok {
+				remaining++
+				fmt.Printf("⚠️  %s [%s] still uses a source option\n", record.Title, record.ID)
+			}
+		}
+		if remaining > 0 {
+			fmt.Printf("\n⚠️  Moved %d record(s), but %d still use a source option, so the source options were kept\n", changed, remaining)
+			return fmt.Errorf("source options are still in use; run the merge again")
+		}
+
+		for _, option := range sourceOptions {
+			ok, err := executeDeleteCustomFieldOption(client, field.ID, option.ID, "")
+			if err != nil {
+				fmt.Printf("⚠️  Could not delete option '%s': %v\n", option.Title, err)
+				continue
+			}
+			if !ok {
+				fmt.Printf("⚠️  Option '%s' was not deleted\n", option.Title)
+				continue
+			}
+			deleted++
+		}
+	}
+
+	if *simple {
+		fmt.Printf("✅ Merged %d options into %s: %d records changed, %d options deleted\n", len(sourceOptions), target.ID, changed, deleted)
+		return nil
+	}
+	fmt.Printf("\n=== Summary ===\n")
+	fmt.Printf("Records changed: %d\n", changed)
+	fmt.Printf("Options deleted: %d\n", deleted)
+	return nil
+}
@@ -0,0 +1,512 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// migratableFieldTypes are the field types values can be migrated from and to
+var migratableFieldTypes = []string{
+	"TEXT_SINGLE", "TEXT_MULTI", "NUMBER", "CURRENCY", "PERCENT", "RATING",
+	"CHECKBOX", "SELECT_SINGLE", "SELECT_MULTI", "EMAIL", "PHONE", "URL",
+}
+
+// migrationRecord holds a record's value in the old field and whether the new field is set
+type migrationRecord struct {
+	ID       string
+	Title    string
+	Values   []string
+	Migrated bool
+}
+
+// migrationProblem is a value that could not be converted to the new field type
+type migrationProblem struct {
+	Record migrationRecord
+	Reason string
+}
+
+// recordFieldValue is the typed value of a custom field on a record
+type recordFieldValue struct {
+	ID             string   `json:"id"`
+	Text           *string  `json:"text"`
+	Number         *float64 `json:"number"`
+	Checked        *bool    `json:"checked"`
+	SelectedOption *struct {
+		Title string `json:"title"`
+	} `json:"selectedOption"`
+	SelectedOptions []struct {
+		Title string `json:"title"`
+	} `json:"selectedOptions"`
+}
+
+// strings returns the value as strings, or nil when it is empty
+func (v recordFieldValue) strings() []string {
+	switch {
+	case v.SelectedOption != nil:
+		return []string{v.SelectedOption.Title}
+	case len(v.SelectedOptions) > 0:
+		var titles []string
+		for _, option := range v.SelectedOptions {
+			titles = append(titles, option.Title)
+		}
+		return titles
+	case v.Number != nil:
+		return []string{strconv.FormatFloat(*v.Number, 'f', -1, 64)}
+	case v.Checked != nil && *v.Checked:
+		return []string{"true"}
+	case v.Text != nil && strings.TrimSpace(*v.Text) != "":
+		return []string{strings.TrimSpace(*v.Text)}
+	}
+	return nil
+}
+
+// fetchMigrationRecords returns every record of the project with a value in the old field
+func fetchMigrationRecords(client *common.Client, projectID, oldFieldID, newFieldID string) ([]migrationRecord, error) {
+	lists, err := fetchProjectLists(client, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lists: %v", err)
+	}
+
+	selection := `
+		id
+		title
+		customFields {
+			id
+			text
+			number
+			checked
+			selectedOption {
+				title
+			}
+			selectedOptions {
+				title
+			}
+		}`
+
+	var records []migrationRecord
+	for _, list := range lists {
+		todos, err := fetchListTodos[struct {
+			ID           string             `json:"id"`
+			Title        string             `json:"title"`
+			CustomFields []recordFieldValue `json:"customFields"`
+		}](client, list.ID, selection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch records of '%s': %v", list.Title, err)
+		}
+		for _, todo := range todos {
+			record := migrationRecord{ID: todo.ID, Title: todo.Title}
+			for _, value := range todo.CustomFields {
+				switch value.ID {
+				case oldFieldID:
+					record.Values = value.strings()
+				case newFieldID:
+					record.Migrated = newFieldID != "" && value.strings() != nil
+				}
+			}
+			if len(record.Values) > 0 {
+				records = append(records, record)
+			}
+		}
+	}
+	return records, nil
+}
+
+// normalizeMigratedValues cleans up values for the new field type, such as
+// dropping currency symbols and thousands separators before numbers are parsed
+func normalizeMigratedValues(values []string, toType string) []string {
+	switch toType {
+	case "NUMBER", "PERCENT", "RATING", "CURRENCY":
+		var normalized []string
+		for _, value := range values {
+			value = strings.NewReplacer("$", "", "€", "", "£", "", "¥", "", ",", "", "_", "").Replace(value)
+			if toType != "CURRENCY" {
+				value = strings.ReplaceAll(value, " ", "")
+			}
+			normalized = append(normalized, strings.TrimSpace(value))
+		}
+		return normalized
+	case "SELECT_MULTI":
+		return splitCustomFieldList(values)
+	}
+	return values
+}
+
+// missingMigrationOptions returns the option titles the new select field needs, in first-seen order
+func missingMigrationOptions(field *common.CustomField, records []migrationRecord) []string {
+	var missing []string
+	for _, record := range records {
+		values := normalizeMigratedValues(record.Values, field.Type)
+		if field.Type == "SELECT_SINGLE" && len(values) > 1 {
+			continue
+		}
+		for _, title := range values {
+			if findOption(field, title) == nil && !containsFold(missing, title) {
+				missing = append(missing, title)
+			}
+		}
+	}
+	return missing
+}
+
+// finishInterruptedSwap completes a migration that stopped after the old field was renamed
+// or deleted but before the new field '<name> (<TYPE>)' took over the name
+func finishInterruptedSwap(client *common.Client, fields []common.CustomField, name string, newField *common.CustomField, dryRun, simple bool) error {
+	oldField := findCustomField(fields, name+" (old)")
+	if !simple {
+		fmt.Printf("=== Finishing the migration of '%s' ===\n", name)
+		if oldField != nil {
+			fmt.Printf("The old field was already renamed to '%s' [%s]\n", oldField.Name, oldField.ID)
+		} else {
+			fmt.Println("The old field was already deleted")
+		}
+	}
+	if dryRun {
+		fmt.Printf("\nDry run: '%s' [%s] would be renamed to '%s'\n", newField.Name, newField.ID, name)
+		return nil
+	}
+	if _, err := executeUpdateCustomField(client, UpdateCustomFieldInput{CustomFieldID: newField.ID, Name: name}); err != nil {
+		return fmt.Errorf("failed to rename the new field: %v", err)
+	}
+	if simple {
+		fmt.Printf("✅ Renamed %s to %s\n", newField.ID, name)
+		return nil
+	}
+	fmt.Printf("✅ '%s' is now a %s field [%s]\n", name, newField.Type, newField.ID)
+	return nil
+}
+
+// placeFieldAfter moves newID right after oldID in the record layout, inside the same
+// group when oldID is grouped. It reports false when oldID is not in the layout or
+// newID already follows it.
+func placeFieldAfter(fields []common.TodoField, oldID, newID string) ([]common.TodoField, bool) {
+	groupIdx, idx := findFieldIndex(fields, oldID)
+	if groupIdx == -1 {
+		return fields, false
+	}
+	siblings := fields
+	if idx == -1 {
+		idx = groupIdx
+	} else {
+		siblings = fields[groupIdx].TodoFields
+	}
+	if next := idx + 1; next < len(siblings) && siblings[next].CustomFieldID != nil && *siblings[next].CustomFieldID == newID {
+		return fields, false
+	}
+
+	// Take the new field out of wherever the project put it first
+	var result []common.TodoField
+	for _, field := range fields {
+		if field.CustomFieldID != nil && *field.CustomFieldID == newID {
+			continue
+		}
+		if field.Type == "CUSTOM_FIELD_GROUP" {
+			var nested []common.TodoField
+			for _, child := range field.TodoFields {
+				if child.CustomFieldID == nil || *child.CustomFieldID != newID {
+					nested = append(nested, child)
+				}
+			}
+			field.TodoFields = nested
+		}
+		result = append(result, field)
+	}
+
+	entry := common.TodoField{Type: "CUSTOM_FIELD", CustomFieldID: strPtr(newID)}
+	groupIdx, idx = findFieldIndex(result, oldID)
+	if idx == -1 {
+		result = append(result[:groupIdx+1], append([]common.TodoField{entry}, result[groupIdx+1:]...)...)
+		return result, true
+	}
+	nested := result[groupIdx].TodoFields
+	result[groupIdx].TodoFields = append(nested[:idx+1], append([]common.TodoField{entry}, nested[idx+1:]...)...)
+	return result, true
+}
+
+// placeMigratedField puts the new field next to the old one in the project's record layout
+func placeMigratedField(client *common.Client, projectID, oldID, newID string) error {
+	fields, err := fetchProjectTodoFields(client, projectID)
+	if err != nil {
+		return err
+	}
+	fields, changed := placeFieldAfter(fields, oldID, newID)
+	if !changed {
+		return nil
+	}
+	return updateProjectTodoFields(client, projectID, convertToInput(fields))
+}
+
+// RunMigrateCustomField converts a custom field to another type, carrying every record's value over
+func RunMigrateCustomField(args []string) error {
+	fs := flag.NewFlagSet("migrate-custom-field", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	fieldRef := fs.String("field", "", "Custom field ID or name to migrate (required)")
+	toType := fs.String("to-type", "", "New field type (required): "+strings.Join(migratableFieldTypes, ", "))
+	finish := fs.String("finish", "swap", "What to do with the old field: swap (rename it to '<name> (old)'), delete or keep")
+	noCreateOptions := fs.Bool("no-create-options", false, "Report values without a matching option instead of creating options")
+	dryRun := fs.Bool("dry-run", false, "Show how values would convert without changing anything")
+	confirm := fs.Bool("confirm", false, "Confirm deleting the old field (required with -finish delete)")
+	force := fs.Bool("force", false, "Swap or delete the old field even if some values could not be converted")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" || *fieldRef == "" || *toType == "" {
+		fmt.Println("Usage: go run . migrate-custom-field -project PROJECT -field FIELD -to-type TYPE [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nThe new field is created next to the old one as '<name> (<TYPE>)'. If the migration")
+		fmt.Println("is interrupted, run the same command again: it reuses that field and skips records")
+		fmt.Println("that already have a value, or finishes the renames if the old field was already swapped.")
+		fmt.Println("The old field is only swapped or deleted once every value converted, unless -force is given.")
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Preview turning a text field into a number field")
+		fmt.Println("  go run . migrate-custom-field -project PROJECT_ID -field \"Budget\" -to-type NUMBER -dry-run")
+		fmt.Println("")
+		fmt.Println("  # Turn free text into a dropdown, creating an option per distinct value")
+		fmt.Println("  go run . migrate-custom-field -project PROJECT_ID -field \"Industry\" -to-type SELECT_SINGLE")
+		fmt.Println("")
+		fmt.Println("  # Migrate and delete the old field")
+		fmt.Println("  go run . migrate-custom-field -project PROJECT_ID -field \"Budget\" -to-type CURRENCY -finish delete -confirm")
+		return fmt.Errorf("required flags missing")
+	}
+	*toType = strings.ToUpper(*toType)
+	if !containsFold(migratableFieldTypes, *toType) {
+		return fmt.Errorf("can't migrate to '%s' (valid: %s)", *toType, strings.Join(migratableFieldTypes, ", "))
+	}
+	if *finish != "swap" && *finish != "delete" && *finish != "keep" {
+		return fmt.Errorf("invalid -finish '%s' (valid: swap, delete, keep)", *finish)
+	}
+	if *finish == "delete" && !*confirm && !*dryRun {
+		return fmt.Errorf("-confirm flag is required to delete the old field")
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	fields, err := fetchProjectCustomFields(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	oldField := findCustomField(fields, *fieldRef)
+	if oldField == nil {
+		// An interrupted swap leaves the old field renamed and the new one still named '<name> (<TYPE>)'
+		newField := findCustomField(fields, fmt.Sprintf("%s (%s)", *fieldRef, *toType))
+		if newField != nil && newField.Type == *toType {
+			return finishInterruptedSwap(client, fields, *fieldRef, newField, *dryRun, *simple)
+		}
+		return fmt.Errorf("custom field '%s' not found in the project", *fieldRef)
+	}
+	if !containsFold(migratableFieldTypes, oldField.Type) {
+		return fmt.Errorf("can't migrate values of %s fields (valid: %s)", oldField.Type, strings.Join(migratableFieldTypes, ", "))
+	}
+	if oldField.Type == *toType {
+		return fmt.Errorf("'%s' is already a %s field", oldField.Name, *toType)
+	}
+
+	// A field left behind by an interrupted run is picked up again
+	tempName := fmt.Sprintf("%s (%s)", oldField.Name, *toType)
+	newField := findCustomField(fields, tempName)
+	if newField != nil && newField.Type != *toType {
+		return fmt.Errorf("a %s field named '%s' already exists; rename it first", newField.Type, tempName)
+	}
+	resuming := newField != nil
+
+	newFieldID := ""
+	if resuming {
+		newFieldID = newField.ID
+	}
+	records, err := fetchMigrationRecords(client, *projectID, oldField.ID, newFieldID)
+	if err != nil {
+		return err
+	}
+
+	if !*simple {
+		fmt.Printf("=== Migrating '%s' from %s to %s ===\n", oldField.Name, oldField.Type, *toType)
+		fmt.Printf("Records with a value: %d\n", len(records))
+		if resuming {
+			fmt.Printf("Resuming into existing field '%s' [%s]\n", newField.Name, newField.ID)
+		}
+	}
+
+	// Create the new field right after the old one, or finish placing the one left behind
+	if resuming && !*dryRun {
+		if err := placeMigratedField(client, *projectID, oldField.ID, newField.ID); err != nil {
+			fmt.Printf("⚠️  Could not place the new field next to '%s' in the record layout: %v\n", oldField.Name, err)
+		}
+	}
+	if newField == nil {
+		if *dryRun {
+			newField = &common.CustomField{Name: tempName, Type: *toType, Currency: oldField.Currency}
+		} else {
+			input := LocalCreateCustomFieldInput{Name: tempName, Type: *toType, Description: oldField.Description}
+			if *toType == "CURRENCY" && oldField.Currency != "" {
+				input.Currency = oldField.Currency
+			}
+			created, err := executeCreateCustomField(client, input)
+			if err != nil {
+				return fmt.Errorf("failed to create the new field: %v", err)
+			}
+			position := oldField.Position + 1
+			for i := range fields {
+				if fields[i].ID == oldField.ID && i+1 < len(fields) {
+					position = (oldField.Position + fields[i+1].Position) / 2
+				}
+			}
+			if _, err := executeUpdateCustomField(client, UpdateCustomFieldInput{CustomFieldID: created.ID, Position: &position}); err != nil {
+				fmt.Printf("⚠️  Could not move the new field next to '%s': %v\n", oldField.Name, err)
+			}
+			newField = &common.CustomField{ID: created.ID, Name: created.Name, Type: created.Type, Currency: input.Currency}
+			if err := placeMigratedField(client, *projectID, oldField.ID, newField.ID); err != nil {
+				fmt.Printf("⚠️  Could not place the new field next to '%s' in the record layout: %v\n", oldField.Name, err)
+			}
+			if !*simple {
+				fmt.Printf("✅ Created field '%s' [%s]\n", newField.Name, newField.ID)
+			}
+		}
+	}
+
+	// Select fields get an option for every distinct value
+	if *toType == "SELECT_SINGLE" || *toType == "SELECT_MULTI" {
+		if newField.ID != "" {
+			if details, err := getCustomFieldDetails(client, newField.ID); err == nil {
+				newField.Options = details.Options
+			}
+		}
+		missing := missingMigrationOptions(newField, records)
+		if len(missing) > 0 && !*noCreateOptions {
+			if !*simple {
+				fmt.Printf("Options to create: %s\n", strings.Join(missing, ", "))
+			}
+			if *dryRun {
+				for _, title := range missing {
+					newField.Options = append(newField.Options, common.CustomFieldOption{ID: title, Title: title})
+				}
+			} else {
+				var inputs []common.CustomFieldOptionInput
+				for _, title := range missing {
+					inputs = append(inputs, common.CustomFieldOptionInput{Title: title})
+				}
+				if err := createCustomFieldOptions(client, newField.ID, inputs); err != nil {
+					return fmt.Errorf("failed to create options: %v", err)
+				}
+				details, err := getCustomFieldDetails(client, newField.ID)
+				if err != nil {
+					return fmt.Errorf("failed to fetch the new options: %v", err)
+				}
+				newField.Options = details.Options
+			}
+		}
+	}
+
+	// Convert and set every value
+	var converted, skipped, failed int
+	var problems []migrationProblem
+	for _, record := range records {
+		if record.Migrated {
+			skipped++
+			continue
+		}
+		encoded, err := encodeCustomFieldValue(newField, normalizeMigratedValues(record.Values, *toType))
+		if err != nil {
+			problems = append(problems, migrationProblem{Record: record, Reason: err.Error()})
+			continue
+		}
+		if *dryRun {
+			converted++
+			continue
+		}
+		value := common.CustomFieldValue{CustomFieldID: newField.ID, Value: encoded}
+		if err := executeSetCustomFields(client, record.ID, []common.CustomFieldValue{value}); err != nil {
+			failed++
+			fmt.Printf("❌ %s [%s]: %v\n", record.Title, record.ID, err)
+			continue
+		}
+		converted++
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("\n⚠️  %d value(s) could not be converted and were left empty:\n", len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  - %s [%s] '%s': %s\n", problem.Record.Title, problem.Record.ID, strings.Join(problem.Record.Values, ", "), problem.Reason)
+		}
+	}
+
+	if *dryRun {
+		fmt.Printf("\nDry run: %d value(s) would convert, %d would not", converted, len(problems))
+		if skipped > 0 {
+			fmt.Printf(", %d already migrated", skipped)
+		}
+		switch {
+		case *finish != "keep" && len(problems) > 0 && !*force:
+			fmt.Println("; the old field would be kept because of the unconvertible values (use -force to finish anyway)")
+		case *finish == "swap":
+			fmt.Printf("; the old field would be renamed to '%s (old)'\n", oldField.Name)
+		case *finish == "delete":
+			fmt.Println("; the old field would be deleted")
+		default:
+			fmt.Println("; the old field would be kept")
+		}
+		return nil
+	}
+	if failed > 0 {
+		// Keep both fields so the command can be run again to finish the migration
+		fmt.Printf("\n⚠️  %d record(s) failed; run the same command again to resume\n", failed)
+		return fmt.Errorf("some records could not be migrated")
+	}
+	if *finish != "keep" && len(problems) > 0 && !*force {
+		// Swapping or deleting now would hide or lose the values that did not convert
+		fmt.Printf("\n⚠️  Both fields were kept; fix the values above in '%s' and run the same command again, or add -force\n", oldField.Name)
+		return fmt.Errorf("%d value(s) could not be converted", len(problems))
+	}
+
+	// Hand the old field's name over to the new field
+	switch *finish {
+	case "swap":
+		if _, err := executeUpdateCustomField(client, UpdateCustomFieldInput{CustomFieldID: oldField.ID, Name: oldField.Name + " (old)"}); err != nil {
+			return fmt.Errorf("failed to rename the old field: %v", err)
+		}
+	case "delete":
+		deleted, err := executeDeleteCustomField(client, oldField.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete the old field: %v", err)
+		}
+		if !deleted {
+			return fmt.Errorf("the old field was not deleted")
+		}
+	}
+	if *finish != "keep" {
+		if _, err := executeUpdateCustomField(client, UpdateCustomFieldInput{CustomFieldID: newField.ID, Name: oldField.Name}); err != nil {
+			return fmt.Errorf("failed to rename the new field: %v", err)
+		}
+	}
+
+	if *simple {
+		fmt.Printf("✅ Migrated %s to %s: %d converted, %d skipped, %d unconvertible\n", oldField.ID, newField.ID, converted, skipped, len(problems))
+		return nil
+	}
+	fmt.Printf("\n=== Summary ===\n")
+	fmt.Printf("Converted: %d\n", converted)
+	if skipped > 0 {
+		fmt.Printf("Already migrated: %d\n", skipped)
+	}
+	fmt.Printf("Unconvertible: %d\n", len(problems))
+	switch *finish {
+	case "swap":
+		fmt.Printf("✅ '%s' is now a %s field [%s]; the old field was renamed to '%s (old)'\n", oldField.Name, *toType, newField.ID, oldField.Name)
+	case "delete":
+		fmt.Printf("✅ '%s' is now a %s field [%s]; the old field was deleted\n", oldField.Name, *toType, newField.ID)
+	default:
+		fmt.Printf("✅ Values copied to '%s' [%s]; the old field was kept\n", newField.Name, newField.ID)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"demo-builder/common"
+)
+
+func TestPlaceFieldAfter(t *testing.T) {
+	field := func(id string) common.TodoField {
+		return common.TodoField{Type: "CUSTOM_FIELD", CustomFieldID: strPtr(id)}
+	}
+	group := func(id string, children ...common.TodoField) common.TodoField {
+		return common.TodoField{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr(id), Name: strPtr(id), TodoFields: children}
+	}
+	// ids lists the layout as "id" or "group[child child]"
+	ids := func(fields []common.TodoField) []string {
+		var out []string
+		for _, f := range fields {
+			id := *f.CustomFieldID
+			if f.Type == "CUSTOM_FIELD_GROUP" {
+				id += "["
+				for i, child := range f.TodoFields {
+					if i > 0 {
+						id += " "
+					}
+					id += *child.CustomFieldID
+				}
+				id += "]"
+			}
+			out = append(out, id)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		fields  []common.TodoField
+		want    []string
+		changed bool
+	}{
+		{
+			name:    "top level",
+			fields:  []common.TodoField{field("a"), field("old"), field("b"), field("new")},
+			want:    []string{"a", "old", "new", "b"},
+			changed: true,
+		},
+		{
+			name:    "inside the old field's group",
+			fields:  []common.TodoField{group("g", field("old"), field("b")), field("new")},
+			want:    []string{"g[old new b]"},
+			changed: true,
+		},
+		{
+			name:    "out of another group",
+			fields:  []common.TodoField{group("g1", field("new")), group("g2", field("a"), field("old"))},
+			want:    []string{"g1[]", "g2[a old new]"},
+			changed: true,
+		},
+		{
+			name:    "new field not in the layout yet",
+			fields:  []common.TodoField{field("old")},
+			want:    []string{"old", "new"},
+			changed: true,
+		},
+		{
+			name:   "already placed",
+			fields: []common.TodoField{group("g", field("old"), field("new"))},
+			want:   []string{"g[old new]"},
+		},
+		{
+			name:   "old field not in the layout",
+			fields: []common.TodoField{field("a"), field("new")},
+			want:   []string{"a", "new"},
+		},
+	}
+	for _, tt := range tests {
+		got, changed := placeFieldAfter(tt.fields, "old", "new")
+		if changed != tt.changed || !reflect.DeepEqual(ids(got), tt.want) {
+			t.Errorf("%s: got %q, %t; want %q, %t", tt.name, ids(got), changed, tt.want, tt.changed)
+		}
+	}
+}
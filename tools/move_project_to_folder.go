@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+
+	"demo-builder/common"
+)
+
+// SetProjectFolderInput is the input for the setProjectFolder mutation
+type SetProjectFolderInput struct {
+	ProjectID string   `json:"projectId"`
+	FolderID  *string  `json:"folderId"`
+	Position  *float64 `json:"position,omitempty"`
+}
+
+// executeSetProjectFolder moves a project into a folder, or out of all folders when FolderID is nil
+func executeSetProjectFolder(client *common.Client, input SetProjectFolderInput) error {
+	mutation := `
+		mutation SetProjectFolder($input: SetProjectFolderInput!) {
+			setProjectFolder(input: $input)
+		}
+	`
+
+	var response struct {
+		SetProjectFolder bool `json:"setProjectFolder"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return err
+	}
+	if !response.SetProjectFolder {
+		return fmt.Errorf("the API reported that the project was not moved")
+	}
+	return nil
+}
+
+// RunMoveProjectToFolder moves a project into a folder or back to the top level
+func RunMoveProjectToFolder(args []string) error {
+	fs := flag.NewFlagSet("move-project-to-folder", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	folderRef := fs.String("folder", "", "Target folder (ID or title)")
+	root := fs.Bool("root", false, "Move the project out of its folder to the top level")
+	position := fs.Float64("position", -1, "Position inside the folder (default: last)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" || (*folderRef == "" && !*root) {
+		fmt.Println("Usage: go run . move-project-to-folder -project PROJECT (-folder FOLDER | -root) [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . move-project-to-folder -project my-project -folder \"Clients\"")
+		fmt.Println("  go run . move-project-to-folder -project my-project -root")
+		return fmt.Errorf("required flags missing")
+	}
+	if *folderRef != "" && *root {
+		return fmt.Errorf("use either -folder or -root, not both")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	project, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+
+	input := SetProjectFolderInput{ProjectID: project.ID}
+	destination := ""
+	if *folderRef != "" {
+		folders, err := fetchProjectFolders(client, client.GetCompanyID())
+		if err != nil {
+			return fmt.Errorf("failed to fetch folders: %v", err)
+		}
+		folder, err := resolveFolder(folders, *folderRef)
+		if err != nil {
+			return err
+		}
+		input.FolderID = &folder.ID
+		destination = folder.Title
+	}
+	if *position >= 0 {
+		input.Position = position
+	}
+
+	if err := executeSetProjectFolder(client, input); err != nil {
+		return fmt.Errorf("failed to move project: %v", err)
+	}
+
+	switch {
+	case *simple:
+		fmt.Println(project.ID)
+	case *root:
+		fmt.Printf("✅ Project '%s' moved to the top level\n", project.Name)
+	default:
+		fmt.Printf("✅ Project '%s' moved to folder '%s'\n", project.Name, destination)
+	}
+	return nil
+}
@@ -0,0 +1,1365 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// specChange is a single step of a plan. op is "+" (create), "~" (update),
+// "-" (delete), "!" (conflict that needs manual attention) or "#" (ignored).
+type specChange struct {
+	op     string
+	kind   string
+	name   string
+	detail string
+	apply  func(ctx *applyContext) error
+}
+
+// applyContext tracks IDs by name while a plan is applied, so objects created
+// in earlier steps can be referenced by later ones
+type applyContext struct {
+	client       *common.Client
+	projectID    string
+	lists        map[string]string
+	tags         map[string]string
+	fields       map[string]*common.CustomField
+	fieldsStale  bool
+	listPosition float64
+}
+
+// newApplyContext seeds the name lookups from the live project state
+func newApplyContext(client *common.Client, state *ProjectState) *applyContext {
+	ctx := &applyContext{
+		client: client,
+		lists:  make(map[string]string),
+		tags:   make(map[string]string),
+		fields: make(map[string]*common.CustomField),
+	}
+	if state.Project != nil {
+		ctx.projectID = state.Project.ID
+	}
+	for _, list := range state.Lists {
+		ctx.lists[specKey(list.Title)] = list.ID
+		if list.Position > ctx.listPosition {
+			ctx.listPosition = list.Position
+		}
+	}
+	for _, tag := range state.Tags {
+		ctx.tags[specKey(tag.Title)] = tag.ID
+	}
+	for i := range state.CustomFields {
+		ctx.fields[specKey(state.CustomFields[i].Name)] = &state.CustomFields[i]
+	}
+	return ctx
+}
+
+// listID resolves a list title to its ID
+func (c *applyContext) listID(title string) (string, error) {
+	if id, ok := c.lists[specKey(title)]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("list '%s' not found", title)
+}
+
+// tagIDs resolves tag titles to IDs
+func (c *applyContext) tagIDs(titles []string) ([]string, error) {
+	var ids []string
+	for _, title := range titles {
+		id, ok := c.tags[specKey(title)]
+		if !ok {
+			return nil, fmt.Errorf("tag '%s' not found", title)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// field resolves a custom field by name, refreshing options created during this apply
+func (c *applyContext) field(name string) (*common.CustomField, error) {
+	if c.fieldsStale {
+		fields, err := fetchProjectCustomFields(c.client, c.projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refresh custom fields: %v", err)
+		}
+		for i := range fields {
+			c.fields[specKey(fields[i].Name)] = &fields[i]
+		}
+		c.fieldsStale = false
+	}
+	if field, ok := c.fields[specKey(name)]; ok {
+		return field, nil
+	}
+	return nil, fmt.Errorf("custom field '%s' not found", name)
+}
+
+// optionIDs resolves option titles of a custom field to IDs
+func (c *applyContext) optionIDs(fieldName string, titles []string) ([]string, error) {
+	if len(titles) == 0 {
+		return nil, nil
+	}
+	field, err := c.field(fieldName)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, title := range titles {
+		option := findOption(field, title)
+		if option == nil {
+			return nil, fmt.Errorf("option '%s' of '%s' not found", title, fieldName)
+		}
+		ids = append(ids, option.ID)
+	}
+	return ids, nil
+}
+
+// boolValue formats an optional bool for plan output
+func boolValue(value *bool) string {
+	if value == nil {
+		return "unset"
+	}
+	return fmt.Sprintf("%t", *value)
+}
+
+// floatValue formats an optional number for plan output
+func floatValue(value *float64) string {
+	if value == nil {
+		return "unset"
+	}
+	return fmt.Sprintf("%g", *value)
+}
+
+// sameFloat compares two optional numbers
+func sameFloat(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// planProjectSpec works out the changes needed to make the live project match the spec.
+// Deletions are only planned with prune, and only for sections present in the spec.
+func planProjectSpec(client *common.Client, spec *ProjectSpec, state *ProjectState, prune bool) ([]specChange, error) {
+	var changes []specChange
+	changes = append(changes, planProjectSettings(spec, state)...)
+	changes = append(changes, planLists(spec, state, prune)...)
+	changes = append(changes, planTags(spec, state, prune)...)
+	changes = append(changes, planCustomFields(spec, state, prune)...)
+	changes = append(changes, planFieldGroups(spec, state, prune)...)
+	changes = append(changes, planRoles(spec, state, prune)...)
+	changes = append(changes, planAutomations(spec, state, prune)...)
+
+	records, err := planRecords(client, spec, state)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, records...)
+	changes = append(changes, checkSpecReferences(spec, state)...)
+	changes = append(changes, checkSpecRecordValues(spec, state)...)
+
+	return changes, nil
+}
+
+// planProjectSettings plans project creation, setting changes and feature toggles
+func planProjectSettings(spec *ProjectSpec, state *ProjectState) []specChange {
+	var changes []specChange
+	settings := spec.Project
+
+	current := state.Project
+	if current == nil {
+		changes = append(changes, specChange{
+			op:     "+",
+			kind:   "project",
+			name:   settings.Name,
+			detail: settings.Category,
+			apply: func(ctx *applyContext) error {
+				created, err := executeCreateProject(ctx.client, common.CreateProjectInput{
+					Name:        settings.Name,
+					CompanyID:   ctx.client.GetCompanyID(),
+					Description: settings.Description,
+					Color:       settings.Color,
+					Icon:        settings.Icon,
+					Category:    strings.ToUpper(settings.Category),
+				})
+				if err != nil {
+					return err
+				}
+				ctx.projectID = created.ID
+				ctx.client.SetProjectID(created.ID)
+				return nil
+			},
+		})
+		current = &EditedProject{
+			Name:        settings.Name,
+			Description: settings.Description,
+			Color:       settings.Color,
+			Icon:        settings.Icon,
+			Category:    settings.Category,
+		}
+	}
+
+	editSetting := func(name, from, to string, input EditProjectInput) {
+		changes = append(changes, specChange{
+			op:     "~",
+			kind:   "project",
+			name:   name,
+			detail: fmt.Sprintf("%q → %q", from, to),
+			apply: func(ctx *applyContext) error {
+				input.ProjectID = ctx.projectID
+				_, err := executeEditProject(ctx.client, input)
+				return err
+			},
+		})
+	}
+
+	if settings.Name != "" && settings.Name != current.Name {
+		editSetting("name", current.Name, settings.Name, EditProjectInput{Name: settings.Name})
+	}
+	if settings.Description != "" && settings.Description != current.Description {
+		editSetting("description", current.Description, settings.Description, EditProjectInput{Description: settings.Description})
+	}
+	if settings.Color != "" && settings.Color != current.Color {
+		editSetting("color", current.Color, settings.Color, EditProjectInput{Color: settings.Color})
+	}
+	if settings.Icon != "" && settings.Icon != current.Icon {
+		editSetting("icon", current.Icon, settings.Icon, EditProjectInput{Icon: settings.Icon})
+	}
+	if settings.Category != "" && !strings.EqualFold(settings.Category, current.Category) {
+		editSetting("category", current.Category, settings.Category, EditProjectInput{Category: strings.ToUpper(settings.Category)})
+	}
+	if settings.TodoAlias != "" && settings.TodoAlias != current.TodoAlias {
+		editSetting("todoAlias", current.TodoAlias, settings.TodoAlias, EditProjectInput{TodoAlias: settings.TodoAlias})
+	}
+	if settings.HideRecordCount != nil && *settings.HideRecordCount != current.HideRecordCount {
+		editSetting("hideRecordCount", fmt.Sprint(current.HideRecordCount), boolValue(settings.HideRecordCount),
+			EditProjectInput{HideRecordCount: settings.HideRecordCount})
+	}
+	if settings.ShowTimeSpentInTodoList != nil && *settings.ShowTimeSpentInTodoList != current.ShowTimeSpentInTodoList {
+		editSetting("showTimeSpentInTodoList", fmt.Sprint(current.ShowTimeSpentInTodoList), boolValue(settings.ShowTimeSpentInTodoList),
+			EditProjectInput{ShowTimeSpentInTodoList: settings.ShowTimeSpentInTodoList})
+	}
+	if settings.ShowTimeSpentInProject != nil && *settings.ShowTimeSpentInProject != current.ShowTimeSpentInProject {
+		editSetting("showTimeSpentInProject", fmt.Sprint(current.ShowTimeSpentInProject), boolValue(settings.ShowTimeSpentInProject),
+			EditProjectInput{ShowTimeSpentInProject: settings.ShowTimeSpentInProject})
+	}
+
+	// Features default to enabled when the project does not report them
+	enabled := make(map[string]bool)
+	for _, featureType := range featureTypes {
+		enabled[featureType] = true
+	}
+	for _, feature := range current.Features {
+		enabled[feature.Type] = feature.Enabled
+	}
+	for _, featureType := range featureTypes {
+		for name, want := range spec.Features {
+			if normalizeFeatureType(name) != featureType || enabled[featureType] == want {
+				continue
+			}
+			feature := common.ProjectFeatureInput{Type: featureType, Enabled: want}
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "feature",
+				name:   featureType,
+				detail: fmt.Sprintf("%s → %s", enabledLabel(enabled[featureType]), enabledLabel(want)),
+				apply: func(ctx *applyContext) error {
+					_, err := executeEditProject(ctx.client, EditProjectInput{
+						ProjectID: ctx.projectID,
+						Features:  []common.ProjectFeatureInput{feature},
+					})
+					return err
+				},
+			})
+		}
+	}
+
+	return changes
+}
+
+// enabledLabel formats a feature state
+func enabledLabel(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// planLists plans list creation, lock changes and pruning
+func planLists(spec *ProjectSpec, state *ProjectState, prune bool) []specChange {
+	var changes []specChange
+	if len(spec.Lists) == 0 {
+		return changes
+	}
+
+	wanted := make(map[string]bool)
+	for _, list := range spec.Lists {
+		list := list
+		wanted[specKey(list.Title)] = true
+
+		live := state.findList(list.Title)
+		if live == nil {
+			changes = append(changes, specChange{
+				op:   "+",
+				kind: "list",
+				name: list.Title,
+				apply: func(ctx *applyContext) error {
+					ctx.listPosition += 65535.0
+					created, err := createTodoList(ctx.client, CreateTodoListInput{
+						ProjectID: ctx.projectID,
+						Title:     list.Title,
+						Position:  ctx.listPosition,
+					})
+					if err != nil {
+						return err
+					}
+					ctx.lists[specKey(list.Title)] = created.ID
+					if list.Locked != nil && *list.Locked {
+						_, err = executeUpdateTodoList(ctx.client, UpdateTodoListInput{TodoListID: created.ID, IsLocked: list.Locked})
+					}
+					return err
+				},
+			})
+			continue
+		}
+
+		if list.Locked != nil && *list.Locked != live.IsLocked {
+			listID := live.ID
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "list",
+				name:   list.Title,
+				detail: fmt.Sprintf("locked: %t → %t", live.IsLocked, *list.Locked),
+				apply: func(ctx *applyContext) error {
+					_, err := executeUpdateTodoList(ctx.client, UpdateTodoListInput{TodoListID: listID, IsLocked: list.Locked})
+					return err
+				},
+			})
+		}
+	}
+
+	for _, live := range state.Lists {
+		if wanted[specKey(live.Title)] {
+			continue
+		}
+		listID := live.ID
+		detail := fmt.Sprintf("%d record(s)", live.TodosCount)
+		if !prune {
+			changes = append(changes, specChange{op: "#", kind: "list", name: live.Title, detail: detail + ", not in spec"})
+			continue
+		}
+		changes = append(changes, specChange{
+			op:     "-",
+			kind:   "list",
+			name:   live.Title,
+			detail: detail,
+			apply: func(ctx *applyContext) error {
+				_, err := executeDeleteTodoList(ctx.client, DeleteTodoListInput{ProjectID: ctx.projectID, TodoListID: listID})
+				return err
+			},
+		})
+	}
+
+	return changes
+}
+
+// planTags plans tag creation, recolouring and pruning
+func planTags(spec *ProjectSpec, state *ProjectState, prune bool) []specChange {
+	var changes []specChange
+	if len(spec.Tags) == 0 {
+		return changes
+	}
+
+	wanted := make(map[string]bool)
+	for _, tag := range spec.Tags {
+		tag := tag
+		wanted[specKey(tag.Title)] = true
+
+		color := tag.Color
+		if color == "" {
+			color = defaultTagColor
+		}
+
+		live := state.findTag(tag.Title)
+		if live == nil {
+			changes = append(changes, specChange{
+				op:     "+",
+				kind:   "tag",
+				name:   tag.Title,
+				detail: color,
+				apply: func(ctx *applyContext) error {
+					created, err := executeCreateTag(ctx.client, tag.Title, color)
+					if err != nil {
+						return err
+					}
+					ctx.tags[specKey(tag.Title)] = created.ID
+					return nil
+				},
+			})
+			continue
+		}
+
+		if tag.Color != "" && !strings.EqualFold(tag.Color, live.Color) {
+			tagID := live.ID
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "tag",
+				name:   tag.Title,
+				detail: fmt.Sprintf("color: %s → %s", live.Color, tag.Color),
+				apply: func(ctx *applyContext) error {
+					return executeEditTag(ctx.client, tagID, "", tag.Color)
+				},
+			})
+		}
+	}
+
+	for _, live := range state.Tags {
+		if wanted[specKey(live.Title)] {
+			continue
+		}
+		if !prune {
+			changes = append(changes, specChange{op: "#", kind: "tag", name: live.Title, detail: "not in spec"})
+			continue
+		}
+		tagID := live.ID
+		changes = append(changes, specChange{
+			op:   "-",
+			kind: "tag",
+			name: live.Title,
+			apply: func(ctx *applyContext) error {
+				return executeDeleteTag(ctx.client, tagID)
+			},
+		})
+	}
+
+	return changes
+}
+
+// planCustomFields plans custom field and option changes
+func planCustomFields(spec *ProjectSpec, state *ProjectState, prune bool) []specChange {
+	var changes []specChange
+	if len(spec.CustomFields) == 0 {
+		return changes
+	}
+
+	wanted := make(map[string]bool)
+	for _, field := range spec.CustomFields {
+		field := field
+		wanted[specKey(field.Name)] = true
+
+		var options []common.CustomFieldOptionInput
+		for _, option := range field.Options {
+			options = append(options, common.CustomFieldOptionInput{Title: option.Title, Color: option.Color})
+		}
+
+		live := state.findCustomField(field.Name)
+		if live == nil {
+			detail := field.Type
+			if len(options) > 0 {
+				detail += fmt.Sprintf(", %d option(s)", len(options))
+			}
+			changes = append(changes, specChange{
+				op:     "+",
+				kind:   "custom field",
+				name:   field.Name,
+				detail: detail,
+				apply: func(ctx *applyContext) error {
+					created, err := executeCreateCustomField(ctx.client, LocalCreateCustomFieldInput{
+						Name:        field.Name,
+						Type:        field.Type,
+						Description: field.Description,
+						Min:         field.Min,
+						Max:         field.Max,
+						Currency:    field.Currency,
+						Prefix:      field.Prefix,
+					})
+					if err != nil {
+						return err
+					}
+					ctx.fields[specKey(field.Name)] = &common.CustomField{ID: created.ID, Name: created.Name, Type: created.Type}
+					if err := createCustomFieldOptions(ctx.client, created.ID, options); err != nil {
+						return fmt.Errorf("field created but options failed: %v", err)
+					}
+					ctx.fieldsStale = len(options) > 0
+					return nil
+				},
+			})
+			continue
+		}
+
+		if live.Type != field.Type {
+			changes = append(changes, specChange{
+				op:     "!",
+				kind:   "custom field",
+				name:   field.Name,
+				detail: fmt.Sprintf("type is %s in the project but %s in the spec; delete and recreate it manually", live.Type, field.Type),
+			})
+			continue
+		}
+
+		// Only properties set in the spec are managed
+		input := UpdateCustomFieldInput{CustomFieldID: live.ID}
+		var diffs []string
+		if field.Description != "" && field.Description != live.Description {
+			input.Description = field.Description
+			diffs = append(diffs, fmt.Sprintf("description: %q → %q", live.Description, field.Description))
+		}
+		if field.Min != nil && !sameFloat(field.Min, live.Min) {
+			input.Min = field.Min
+			diffs = append(diffs, fmt.Sprintf("min: %s → %s", floatValue(live.Min), floatValue(field.Min)))
+		}
+		if field.Max != nil && !sameFloat(field.Max, live.Max) {
+			input.Max = field.Max
+			diffs = append(diffs, fmt.Sprintf("max: %s → %s", floatValue(live.Max), floatValue(field.Max)))
+		}
+		if field.Currency != "" && !strings.EqualFold(field.Currency, live.Currency) {
+			input.Currency = field.Currency
+			diffs = append(diffs, fmt.Sprintf("currency: %s → %s", live.Currency, field.Currency))
+		}
+		if field.Prefix != "" && field.Prefix != live.Prefix {
+			input.Prefix = field.Prefix
+			diffs = append(diffs, fmt.Sprintf("prefix: %q → %q", live.Prefix, field.Prefix))
+		}
+		if len(diffs) > 0 {
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "custom field",
+				name:   field.Name,
+				detail: strings.Join(diffs, ", "),
+				apply: func(ctx *applyContext) error {
+					_, err := executeUpdateCustomField(ctx.client, input)
+					return err
+				},
+			})
+		}
+
+		changes = append(changes, planOptions(field, live, prune)...)
+	}
+
+	for _, live := range state.CustomFields {
+		if wanted[specKey(live.Name)] {
+			continue
+		}
+		if !prune {
+			changes = append(changes, specChange{op: "#", kind: "custom field", name: live.Name, detail: live.Type + ", not in spec"})
+			continue
+		}
+		fieldID := live.ID
+		changes = append(changes, specChange{
+			op:     "-",
+			kind:   "custom field",
+			name:   live.Name,
+			detail: live.Type,
+			apply: func(ctx *applyContext) error {
+				_, err := executeDeleteCustomField(ctx.client, fieldID)
+				return err
+			},
+		})
+	}
+
+	return changes
+}
+
+// planOptions plans option changes for an existing select field
+func planOptions(field CustomFieldSpec, live *common.CustomField, prune bool) []specChange {
+	var changes []specChange
+	if len(field.Options) == 0 {
+		return changes
+	}
+
+	fieldID := live.ID
+	wanted := make(map[string]bool)
+	var missing []common.CustomFieldOptionInput
+	for _, option := range field.Options {
+		wanted[specKey(option.Title)] = true
+		existing := findOption(live, option.Title)
+		if existing == nil {
+			missing = append(missing, common.CustomFieldOptionInput{Title: option.Title, Color: option.Color})
+			continue
+		}
+		if option.Color != "" && !strings.EqualFold(option.Color, existing.Color) {
+			optionID := existing.ID
+			color := option.Color
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "option",
+				name:   field.Name + " / " + option.Title,
+				detail: fmt.Sprintf("color: %s → %s", existing.Color, option.Color),
+				apply: func(ctx *applyContext) error {
+					_, err := executeEditCustomFieldOption(ctx.client, EditCustomFieldOptionInput{CustomFieldID: fieldID, OptionID: optionID, Color: color})
+					return err
+				},
+			})
+		}
+	}
+
+	for _, option := range missing {
+		option := option
+		changes = append(changes, specChange{
+			op:     "+",
+			kind:   "option",
+			name:   field.Name + " / " + option.Title,
+			detail: option.Color,
+			apply: func(ctx *applyContext) error {
+				ctx.fieldsStale = true
+				return createCustomFieldOptions(ctx.client, fieldID, []common.CustomFieldOptionInput{option})
+			},
+		})
+	}
+
+	for _, option := range live.Options {
+		if wanted[specKey(option.Title)] {
+			continue
+		}
+		if !prune {
+			changes = append(changes, specChange{op: "#", kind: "option", name: field.Name + " / " + option.Title, detail: "not in spec"})
+			continue
+		}
+		optionID := option.ID
+		changes = append(changes, specChange{
+			op:   "-",
+			kind: "option",
+			name: field.Name + " / " + option.Title,
+			apply: func(ctx *applyContext) error {
+				ctx.fieldsStale = true
+				_, err := executeDeleteCustomFieldOption(ctx.client, fieldID, optionID, "")
+				return err
+			},
+		})
+	}
+
+	return changes
+}
+
+// planFieldGroups plans custom field groups and which fields they hold
+func planFieldGroups(spec *ProjectSpec, state *ProjectState, prune bool) []specChange {
+	var changes []specChange
+
+	liveGroups := state.fieldGroups()
+	findGroup := func(name string) *common.TodoField {
+		for i := range liveGroups {
+			if liveGroups[i].Name != nil && specKey(*liveGroups[i].Name) == specKey(name) {
+				return &liveGroups[i]
+			}
+		}
+		return nil
+	}
+
+	wanted := make(map[string]bool)
+	for _, group := range spec.FieldGroups {
+		group := group
+		wanted[specKey(group.Name)] = true
+
+		live := findGroup(group.Name)
+		if live == nil {
+			changes = append(changes, specChange{
+				op:     "+",
+				kind:   "field group",
+				name:   group.Name,
+				detail: group.Color,
+				apply: func(ctx *applyContext) error {
+					return editFieldGroups(ctx, func(fields []common.TodoField) []common.TodoField {
+						return append(fields, common.TodoField{
+							Type:          "CUSTOM_FIELD_GROUP",
+							CustomFieldID: strPtr(generateGroupID()),
+							Name:          strPtr(group.Name),
+							Color:         strPtr(group.Color),
+							TodoFields:    []common.TodoField{},
+						})
+					})
+				},
+			})
+			continue
+		}
+
+		liveColor := ""
+		if live.Color != nil {
+			liveColor = *live.Color
+		}
+		if group.Color != "" && !strings.EqualFold(group.Color, liveColor) {
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "field group",
+				name:   group.Name,
+				detail: fmt.Sprintf("color: %s → %s", liveColor, group.Color),
+				apply: func(ctx *applyContext) error {
+					return editFieldGroups(ctx, func(fields []common.TodoField) []common.TodoField {
+						if i := findGroupByName(fields, group.Name); i != -1 {
+							fields[i].Color = strPtr(group.Color)
+						}
+						return fields
+					})
+				},
+			})
+		}
+	}
+
+	for _, field := range spec.CustomFields {
+		field := field
+		if field.Group == "" {
+			continue
+		}
+		current := ""
+		if live := state.findCustomField(field.Name); live != nil {
+			current = state.fieldGroupOf(live.ID)
+		}
+		if specKey(current) == specKey(field.Group) {
+			continue
+		}
+		from := current
+		if from == "" {
+			from = "(none)"
+		}
+		changes = append(changes, specChange{
+			op:     "~",
+			kind:   "custom field",
+			name:   field.Name,
+			detail: fmt.Sprintf("group: %s → %s", from, field.Group),
+			apply: func(ctx *applyContext) error {
+				target, err := ctx.field(field.Name)
+				if err != nil {
+					return err
+				}
+				return editFieldGroups(ctx, func(fields []common.TodoField) []common.TodoField {
+					return moveFieldToGroup(fields, target.ID, field.Group)
+				})
+			},
+		})
+	}
+
+	if len(spec.FieldGroups) == 0 {
+		return changes
+	}
+	for _, live := range liveGroups {
+		if live.Name == nil || wanted[specKey(*live.Name)] {
+			continue
+		}
+		name := *live.Name
+		if !prune {
+			changes = append(changes, specChange{op: "#", kind: "field group", name: name, detail: "not in spec"})
+			continue
+		}
+		changes = append(changes, specChange{
+			op:     "-",
+			kind:   "field group",
+			name:   name,
+			detail: fmt.Sprintf("%d field(s) move to root level", len(live.TodoFields)),
+			apply: func(ctx *applyContext) error {
+				return editFieldGroups(ctx, func(fields []common.TodoField) []common.TodoField {
+					i := findGroupByName(fields, name)
+					if i == -1 {
+						return fields
+					}
+					nested := fields[i].TodoFields
+					fields = append(fields[:i], fields[i+1:]...)
+					return append(fields, nested...)
+				})
+			},
+		})
+	}
+
+	return changes
+}
+
+// editFieldGroups applies a change to the project's todoFields layout
+func editFieldGroups(ctx *applyContext, change func([]common.TodoField) []common.TodoField) error {
+	fields, err := fetchProjectTodoFields(ctx.client, ctx.projectID)
+	if err != nil {
+		return err
+	}
+	return updateProjectTodoFields(ctx.client, ctx.projectID, convertToInput(change(fields)))
+}
+
+// findGroupByName finds a group in todoFields by case-insensitive name
+func findGroupByName(fields []common.TodoField, name string) int {
+	for i, field := range fields {
+		if field.Type == "CUSTOM_FIELD_GROUP" && field.Name != nil && specKey(*field.Name) == specKey(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveFieldToGroup moves (or adds) a custom field into the named group
+func moveFieldToGroup(fields []common.TodoField, fieldID, groupName string) []common.TodoField {
+	entry := common.TodoField{Type: "CUSTOM_FIELD", CustomFieldID: strPtr(fieldID)}
+
+	fieldIdx, nestedIdx := findFieldIndex(fields, fieldID)
+	if fieldIdx != -1 {
+		if nestedIdx == -1 {
+			entry = fields[fieldIdx]
+			fields = append(fields[:fieldIdx], fields[fieldIdx+1:]...)
+		} else {
+			group := &fields[fieldIdx]
+			entry = group.TodoFields[nestedIdx]
+			group.TodoFields = append(group.TodoFields[:nestedIdx], group.TodoFields[nestedIdx+1:]...)
+		}
+	}
+
+	groupIdx := findGroupByName(fields, groupName)
+	if groupIdx == -1 {
+		return append(fields, entry)
+	}
+	fields[groupIdx].TodoFields = append(fields[groupIdx].TodoFields, entry)
+	return fields
+}
+
+// roleInputFromSpec builds the role mutation input from a spec
+func roleInputFromSpec(role RoleSpec) ProjectUserRoleInput {
+	return ProjectUserRoleInput{
+		Name:                      role.Name,
+		Description:               role.Description,
+		AllowInviteOthers:         role.AllowInviteOthers,
+		AllowMarkRecordsAsDone:    role.AllowMarkRecordsAsDone,
+		ShowOnlyAssignedTodos:     role.ShowOnlyAssignedTodos,
+		ShowOnlyMentionedComments: role.ShowOnlyMentionedComments,
+		CanDeleteRecords:          role.CanDeleteRecords,
+		IsActivityEnabled:         role.IsActivityEnabled,
+		IsChatEnabled:             role.IsChatEnabled,
+		IsDocsEnabled:             role.IsDocsEnabled,
+		IsFormsEnabled:            role.IsFormsEnabled,
+		IsWikiEnabled:             role.IsWikiEnabled,
+		IsFilesEnabled:            role.IsFilesEnabled,
+		IsRecordsEnabled:          role.IsRecordsEnabled,
+		IsPeopleEnabled:           role.IsPeopleEnabled,
+	}
+}
+
+// roleDiffs lists the permissions that differ between a spec role and a live role
+func roleDiffs(role RoleSpec, live *ProjectUserRole) []string {
+	var diffs []string
+	check := func(name string, want *bool, have bool) {
+		if want != nil && *want != have {
+			diffs = append(diffs, fmt.Sprintf("%s: %t → %t", name, have, *want))
+		}
+	}
+	if role.Description != "" && role.Description != live.Description {
+		diffs = append(diffs, fmt.Sprintf("description: %q → %q", live.Description, role.Description))
+	}
+	check("allowInviteOthers", role.AllowInviteOthers, live.AllowInviteOthers)
+	check("allowMarkRecordsAsDone", role.AllowMarkRecordsAsDone, live.AllowMarkRecordsAsDone)
+	check("showOnlyAssignedTodos", role.ShowOnlyAssignedTodos, live.ShowOnlyAssignedTodos)
+	check("showOnlyMentionedComments", role.ShowOnlyMentionedComments, live.ShowOnlyMentionedComments)
+	check("canDeleteRecords", role.CanDeleteRecords, live.CanDeleteRecords)
+	check("isActivityEnabled", role.IsActivityEnabled, live.IsActivityEnabled)
+	check("isChatEnabled", role.IsChatEnabled, live.IsChatEnabled)
+	check("isDocsEnabled", role.IsDocsEnabled, live.IsDocsEnabled)
+	check("isFormsEnabled", role.IsFormsEnabled, live.IsFormsEnabled)
+	check("isWikiEnabled", role.IsWikiEnabled, live.IsWikiEnabled)
+	check("isFilesEnabled", role.IsFilesEnabled, live.IsFilesEnabled)
+	check("isRecordsEnabled", role.IsRecordsEnabled, live.IsRecordsEnabled)
+	check("isPeopleEnabled", role.IsPeopleEnabled, live.IsPeopleEnabled)
+	return diffs
+}
+
+// planRoles plans custom role changes
+func planRoles(spec *ProjectSpec, state *ProjectState, prune bool) []specChange {
+	var changes []specChange
+	if len(spec.Roles) == 0 {
+		return changes
+	}
+
+	wanted := make(map[string]bool)
+	for _, role := range spec.Roles {
+		wanted[specKey(role.Name)] = true
+		input := roleInputFromSpec(role)
+
+		live := state.findRole(role.Name)
+		if live == nil {
+			changes = append(changes, specChange{
+				op:   "+",
+				kind: "role",
+				name: role.Name,
+				apply: func(ctx *applyContext) error {
+					input.ProjectID = ctx.projectID
+					return executeCreateProjectUserRole(ctx.client, input)
+				},
+			})
+			continue
+		}
+
+		if diffs := roleDiffs(role, live); len(diffs) > 0 {
+			input.RoleID = live.ID
+			changes = append(changes, specChange{
+				op:     "~",
+				kind:   "role",
+				name:   role.Name,
+				detail: strings.Join(diffs, ", "),
+				apply: func(ctx *applyContext) error {
+					input.ProjectID = ctx.projectID
+					return executeUpdateProjectUserRole(ctx.client, input)
+				},
+			})
+		}
+	}
+
+	for _, live := range state.Roles {
+		if wanted[specKey(live.Name)] {
+			continue
+		}
+		if !prune {
+			changes = append(changes, specChange{op: "#", kind: "role", name: live.Name, detail: "not in spec"})
+			continue
+		}
+		roleID := live.ID
+		changes = append(changes, specChange{
+			op:   "-",
+			kind: "role",
+			name: live.Name,
+			apply: func(ctx *applyContext) error {
+				return executeDeleteProjectUserRole(ctx.client, ctx.projectID, roleID)
+			},
+		})
+	}
+
+	return changes
+}
+
+// planAutomations matches automations on their trigger and actions
+func planAutomations(spec *ProjectSpec, state *ProjectState, prune bool) []specChange {
+	var changes []specChange
+	if len(spec.Automations) == 0 {
+		return changes
+	}
+
+	liveBySignature := make(map[string][]AutomationItem)
+	for _, item := range state.Automations {
+		live := automationToSpec(item)
+		signature := automationSignature(live.Trigger, live.Actions)
+		liveBySignature[signature] = append(liveBySignature[signature], item)
+	}
+
+	for _, automation := range spec.Automations {
+		automation := automation
+		signature := automationSignature(automation.Trigger, automation.Actions)
+		description := describeAutomationSpec(automation)
+
+		if matches := liveBySignature[signature]; len(matches) > 0 {
+			live := matches[0]
+			liveBySignature[signature] = matches[1:]
+			if automation.Active != nil && *automation.Active != live.IsActive {
+				automationID := live.ID
+				changes = append(changes, specChange{
+					op:     "~",
+					kind:   "automation",
+					name:   description,
+					detail: fmt.Sprintf("active: %t → %t", live.IsActive, *automation.Active),
+					apply: func(ctx *applyContext) error {
+						_, err := executeUpdateAutomation(ctx.client, EditAutomationInput{AutomationID: automationID, IsActive: automation.Active})
+						return err
+					},
+				})
+			}
+			continue
+		}
+
+		changes = append(changes, specChange{
+			op:   "+",
+			kind: "automation",
+			name: description,
+			apply: func(ctx *applyContext) error {
+				input, err := buildAutomationInput(ctx, automation)
+				if err != nil {
+					return err
+				}
+				created, err := executeCreateAutomation(ctx.client, *input)
+				if err != nil {
+					return err
+				}
+				if automation.Active != nil && !*automation.Active {
+					_, err = executeUpdateAutomation(ctx.client, EditAutomationInput{AutomationID: created.ID, IsActive: automation.Active})
+				}
+				return err
+			},
+		})
+	}
+
+	for _, items := range liveBySignature {
+		for _, item := range items {
+			description := describeAutomationSpec(automationToSpec(item))
+			if !prune {
+				changes = append(changes, specChange{op: "#", kind: "automation", name: description, detail: "not in spec"})
+				continue
+			}
+			automationID := item.ID
+			changes = append(changes, specChange{
+				op:   "-",
+				kind: "automation",
+				name: description,
+				apply: func(ctx *applyContext) error {
+					_, err := executeDeleteAutomation(ctx.client, automationID)
+					return err
+				},
+			})
+		}
+	}
+
+	return changes
+}
+
+// buildAutomationInput resolves the names in an automation spec to IDs
+func buildAutomationInput(ctx *applyContext, automation AutomationSpec) (*CreateAutomationInput, error) {
+	var err error
+	spec := automation.Trigger
+	trigger := CreateAutomationTriggerInput{Type: spec.Type}
+	if spec.List != "" {
+		if trigger.TodoListID, err = ctx.listID(spec.List); err != nil {
+			return nil, err
+		}
+	}
+	if trigger.TagIDs, err = ctx.tagIDs(spec.Tags); err != nil {
+		return nil, err
+	}
+	if spec.Field != "" {
+		field, err := ctx.field(spec.Field)
+		if err != nil {
+			return nil, err
+		}
+		trigger.CustomFieldID = field.ID
+	}
+	if trigger.CustomFieldOptionIDs, err = ctx.optionIDs(spec.Field, spec.Options); err != nil {
+		return nil, err
+	}
+	if spec.Color != "" {
+		trigger.Color = strPtr(spec.Color)
+	}
+	if spec.IncompleteOnly != nil {
+		trigger.Metadata = &AutomationTriggerMetadataInput{IncompleteOnly: spec.IncompleteOnly}
+	}
+
+	input := &CreateAutomationInput{Trigger: trigger}
+	for _, actionSpec := range automation.Actions {
+		action := CreateAutomationActionInput{
+			Type:        actionSpec.Type,
+			DuedIn:      actionSpec.DueIn,
+			AssigneeIDs: actionSpec.Assignees,
+		}
+		if actionSpec.List != "" {
+			if action.TodoListID, err = ctx.listID(actionSpec.List); err != nil {
+				return nil, err
+			}
+		}
+		if action.TagIDs, err = ctx.tagIDs(actionSpec.Tags); err != nil {
+			return nil, err
+		}
+		if actionSpec.Field != "" {
+			field, err := ctx.field(actionSpec.Field)
+			if err != nil {
+				return nil, err
+			}
+			action.CustomFieldID = field.ID
+		}
+		if action.CustomFieldOptionIDs, err = ctx.optionIDs(actionSpec.Field, actionSpec.Options); err != nil {
+			return nil, err
+		}
+		if actionSpec.Color != "" {
+			action.Color = strPtr(actionSpec.Color)
+		}
+		if actionSpec.Email != nil {
+			from := actionSpec.Email.From
+			if from == "" {
+				from = "<p>Blue</p>"
+			}
+			action.Metadata = &AutomationActionMetadataInput{Email: &AutomationEmailInput{
+				From:    from,
+				To:      actionSpec.Email.To,
+				Subject: actionSpec.Email.Subject,
+				Content: actionSpec.Email.Content,
+			}}
+		}
+		if actionSpec.HTTP != nil {
+			method := strings.ToUpper(actionSpec.HTTP.Method)
+			if method == "" {
+				method = "GET"
+			}
+			contentType := actionSpec.HTTP.ContentType
+			if contentType == "" {
+				contentType = "JSON"
+			}
+			action.HttpOption = &HttpOptionInput{
+				URL:         actionSpec.HTTP.URL,
+				Method:      method,
+				ContentType: contentType,
+				Body:        actionSpec.HTTP.Body,
+			}
+		}
+		input.Actions = append(input.Actions, action)
+	}
+
+	return input, nil
+}
+
+// planRecords plans seed records that do not exist yet. Existing records are left alone.
+func planRecords(client *common.Client, spec *ProjectSpec, state *ProjectState) ([]specChange, error) {
+	var changes []specChange
+	existing := make(map[string]map[string]bool)
+
+	for _, record := range spec.Records {
+		record := record
+		if live := state.findList(record.List); live != nil {
+			titles, ok := existing[live.ID]
+			if !ok {
+				var err error
+				if titles, err = fetchListRecordTitles(client, live.ID); err != nil {
+					return nil, fmt.Errorf("failed to fetch records of list '%s': %v", live.Title, err)
+				}
+				existing[live.ID] = titles
+			}
+			if titles[specKey(record.Title)] {
+				continue
+			}
+		}
+
+		changes = append(changes, specChange{
+			op:     "+",
+			kind:   "record",
+			name:   record.Title,
+			detail: "in " + record.List,
+			apply: func(ctx *applyContext) error {
+				return createSpecRecord(ctx, record)
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+// checkSpecReferences reports names used by automations and records that exist nowhere
+func checkSpecReferences(spec *ProjectSpec, state *ProjectState) []specChange {
+	var changes []specChange
+	known := func(kind, name string) bool {
+		switch kind {
+		case "list":
+			for _, list := range spec.Lists {
+				if specKey(list.Title) == specKey(name) {
+					return true
+				}
+			}
+			return state.findList(name) != nil
+		case "tag":
+			for _, tag := range spec.Tags {
+				if specKey(tag.Title) == specKey(name) {
+					return true
+				}
+			}
+			return state.findTag(name) != nil
+		default:
+			for _, field := range spec.CustomFields {
+				if specKey(field.Name) == specKey(name) {
+					return true
+				}
+			}
+			return state.findCustomField(name) != nil
+		}
+	}
+
+	reported := make(map[string]bool)
+	check := func(where, kind string, names ...string) {
+		for _, name := range names {
+			key := kind + "/" + specKey(name)
+			if name == "" || reported[key] || known(kind, name) {
+				continue
+			}
+			reported[key] = true
+			changes = append(changes, specChange{
+				op:     "!",
+				kind:   kind,
+				name:   name,
+				detail: "referenced by " + where + " but not defined in the spec or the project",
+			})
+		}
+	}
+
+	for _, automation := range spec.Automations {
+		where := "automation " + describeAutomationSpec(automation)
+		check(where, "list", automation.Trigger.List)
+		check(where, "tag", automation.Trigger.Tags...)
+		check(where, "custom field", automation.Trigger.Field)
+		for _, action := range automation.Actions {
+			check(where, "list", action.List)
+			check(where, "tag", action.Tags...)
+			check(where, "custom field", action.Field)
+		}
+	}
+	for _, record := range spec.Records {
+		where := "record " + record.Title
+		check(where, "list", record.List)
+		check(where, "tag", record.Tags...)
+		for name := range record.Fields {
+			check(where, "custom field", name)
+		}
+	}
+
+	return changes
+}
+
+// specValidationField combines a custom field from the spec with the live field of the
+// same name, giving the definition that record values must satisfy after apply
+func specValidationField(spec *ProjectSpec, state *ProjectState, name string) *common.CustomField {
+	var field common.CustomField
+	if live := state.findCustomField(name); live != nil {
+		field = *live
+		field.Options = append([]common.CustomFieldOption(nil), live.Options...)
+	}
+	for _, fieldSpec := range spec.CustomFields {
+		if specKey(fieldSpec.Name) != specKey(name) {
+			continue
+		}
+		field.Name, field.Type = fieldSpec.Name, fieldSpec.Type
+		if fieldSpec.Min != nil {
+			field.Min = fieldSpec.Min
+		}
+		if fieldSpec.Max != nil {
+			field.Max = fieldSpec.Max
+		}
+		if fieldSpec.Currency != "" {
+			field.Currency = fieldSpec.Currency
+		}
+		for _, option := range fieldSpec.Options {
+			if findOption(&field, option.Title) == nil {
+				field.Options = append(field.Options, common.CustomFieldOption{Title: option.Title})
+			}
+		}
+	}
+	if field.Type == "" {
+		return nil
+	}
+	return &field
+}
+
+// checkSpecRecordValues validates the custom field values of every seed record and
+// reports each invalid value, so a spec's problems are all shown in one plan
+func checkSpecRecordValues(spec *ProjectSpec, state *ProjectState) []specChange {
+	var changes []specChange
+	for _, record := range spec.Records {
+		names := make([]string, 0, len(record.Fields))
+		for name := range record.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			field := specValidationField(spec, state, name)
+			if field == nil {
+				// Unknown fields are reported by checkSpecReferences
+				continue
+			}
+			if _, err := encodeCustomFieldValue(field, record.Fields[name]); err != nil {
+				changes = append(changes, specChange{
+					op:     "!",
+					kind:   "record",
+					name:   record.Title,
+					detail: err.Error(),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// printPlan prints the plan as a diff and returns the number of actionable changes
+func printPlan(projectName string, changes []specChange, simple bool) int {
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.op]++
+	}
+
+	if !simple {
+		fmt.Printf("=== Plan: %s ===\n\n", projectName)
+	}
+	for _, change := range changes {
+		if simple && change.op == "#" {
+			continue
+		}
+		line := fmt.Sprintf("  %s %s %q", change.op, change.kind, change.name)
+		if change.detail != "" {
+			line += " (" + change.detail + ")"
+		}
+		fmt.Println(line)
+	}
+
+	actionable := counts["+"] + counts["~"] + counts["-"]
+	if actionable == 0 && counts["!"] == 0 {
+		fmt.Println("✅ No changes. The project matches the spec.")
+		return 0
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete", counts["+"], counts["~"], counts["-"])
+	if counts["!"] > 0 {
+		fmt.Printf(", %d conflict(s)", counts["!"])
+	}
+	fmt.Println(".")
+	if counts["#"] > 0 && !simple {
+		fmt.Printf("%d item(s) exist only in the project; use -prune to delete them.\n", counts["#"])
+	}
+	return actionable
+}
+
+// loadSpecAndState loads the spec, resolves the target project and fetches its live state.
+// The returned state has a nil Project when the project does not exist yet.
+func loadSpecAndState(specFile, projectID string) (*common.Client, *ProjectSpec, *ProjectState, error) {
+	spec, err := loadProjectSpec(specFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+
+	if projectID == "" {
+		projectID, err = findProjectByName(client, spec.Project.Name)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to look up project '%s': %v", spec.Project.Name, err)
+		}
+	}
+	if projectID == "" {
+		return client, spec, &ProjectState{}, nil
+	}
+
+	client.SetProject(projectID)
+	state, err := fetchProjectState(client, projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	client.SetProjectID(state.Project.ID)
+
+	return client, spec, state, nil
+}
+
+// RunPlan shows what apply would change to make a project match a spec
+func RunPlan(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	specFile := fs.String("file", "", "Project spec file, YAML or JSON (required)")
+	projectID := fs.String("project", "", "Project ID or slug (default: find the project by the spec's name)")
+	prune := fs.Bool("prune", false, "Also plan deletion of items that exist only in the project")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *specFile == "" {
+		fmt.Println("Usage: go run . plan -file SPEC.yaml [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . plan -file demo.yaml")
+		fmt.Println("  go run . plan -file demo.json -project my-project -prune")
+		return fmt.Errorf("required flags missing")
+	}
+
+	client, spec, state, err := loadSpecAndState(*specFile, *projectID)
+	if err != nil {
+		return err
+	}
+
+	changes, err := planProjectSpec(client, spec, state, *prune)
+	if err != nil {
+		return err
+	}
+
+	printPlan(spec.Project.Name, changes, *simple)
+	return nil
+}
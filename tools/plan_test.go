@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"demo-builder/common"
+)
+
+// planSummary renders changes as "op kind name: detail" lines for comparison
+func planSummary(changes []specChange) []string {
+	var lines []string
+	for _, change := range changes {
+		line := change.op + " " + change.kind + " " + change.name
+		if change.detail != "" {
+			line += ": " + change.detail
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestPlanLists(t *testing.T) {
+	state := &ProjectState{Lists: []common.TodoList{
+		{ID: "l1", Title: "Backlog", TodosCount: 4},
+		{ID: "l2", Title: "Doing", IsLocked: true},
+		{ID: "l3", Title: "Old", TodosCount: 2},
+	}}
+	spec := &ProjectSpec{Lists: []ListSpec{
+		{Title: "backlog"},
+		{Title: "Doing", Locked: boolPtr(false)},
+		{Title: "Done"},
+	}}
+
+	tests := []struct {
+		prune bool
+		want  []string
+	}{
+		{false, []string{"~ list Doing: locked: true → false", "+ list Done", "# list Old: 2 record(s), not in spec"}},
+		{true, []string{"~ list Doing: locked: true → false", "+ list Done", "- list Old: 2 record(s)"}},
+	}
+	for _, tt := range tests {
+		if got := planSummary(planLists(spec, state, tt.prune)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("planLists(prune=%t) = %q; want %q", tt.prune, got, tt.want)
+		}
+	}
+
+	if got := planLists(&ProjectSpec{}, state, true); len(got) != 0 {
+		t.Errorf("planLists with no lists in the spec = %q; want no changes", planSummary(got))
+	}
+}
+
+func TestPlanTags(t *testing.T) {
+	state := &ProjectState{Tags: []common.Tag{
+		{ID: "t1", Title: "Urgent", Color: "#FF0000"},
+		{ID: "t2", Title: "Later", Color: "#00FF00"},
+	}}
+	spec := &ProjectSpec{Tags: []TagSpec{
+		{Title: "urgent", Color: "#ff0000"},
+		{Title: "Later", Color: "#0000FF"},
+		{Title: "New"},
+	}}
+
+	want := []string{"~ tag Later: color: #00FF00 → #0000FF", "+ tag New: " + defaultTagColor}
+	if got := planSummary(planTags(spec, state, true)); !reflect.DeepEqual(got, want) {
+		t.Errorf("planTags = %q; want %q", got, want)
+	}
+}
+
+func TestPlanCustomFields(t *testing.T) {
+	min := 0.0
+	state := &ProjectState{CustomFields: []common.CustomField{
+		{ID: "f1", Name: "Budget", Type: "CURRENCY", Currency: "USD", Min: &min},
+		{ID: "f2", Name: "Stage", Type: "SELECT_SINGLE", Options: []common.CustomFieldOption{
+			{ID: "o1", Title: "Lead", Color: "blue"},
+			{ID: "o2", Title: "Lost", Color: "red"},
+		}},
+		{ID: "f3", Name: "Score", Type: "NUMBER"},
+		{ID: "f4", Name: "Legacy", Type: "TEXT_SINGLE"},
+	}}
+	spec := &ProjectSpec{CustomFields: []CustomFieldSpec{
+		{Name: "Budget", Type: "CURRENCY", Currency: "eur", Min: &min},
+		{Name: "Stage", Type: "SELECT_SINGLE", Options: []OptionSpec{
+			{Title: "lead", Color: "green"},
+			{Title: "Won", Color: "yellow"},
+		}},
+		{Name: "Score", Type: "RATING"},
+		{Name: "Priority", Type: "SELECT_SINGLE", Options: []OptionSpec{{Title: "High"}, {Title: "Low"}}},
+	}}
+
+	want := []string{
+		"~ custom field Budget: currency: USD → eur",
+		"~ option Stage / lead: color: blue → green",
+		"+ option Stage / Won: yellow",
+		"# option Stage / Lost: not in spec",
+		"! custom field Score: type is NUMBER in the project but RATING in the spec; delete and recreate it manually",
+		"+ custom field Priority: SELECT_SINGLE, 2 option(s)",
+		"# custom field Legacy: TEXT_SINGLE, not in spec",
+	}
+	if got := planSummary(planCustomFields(spec, state, false)); !reflect.DeepEqual(got, want) {
+		t.Errorf("planCustomFields = %q\nwant %q", got, want)
+	}
+}
+
+func TestPlanFieldGroups(t *testing.T) {
+	state := &ProjectState{
+		CustomFields: []common.CustomField{{ID: "f1", Name: "Budget"}, {ID: "f2", Name: "Stage"}},
+		TodoFields: []common.TodoField{
+			{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g1"), Name: strPtr("Sales"), Color: strPtr("blue"),
+				TodoFields: []common.TodoField{{Type: "CUSTOM_FIELD", CustomFieldID: strPtr("f1")}}},
+			{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g2"), Name: strPtr("Misc"), TodoFields: []common.TodoField{}},
+			{Type: "CUSTOM_FIELD", CustomFieldID: strPtr("f2")},
+		},
+	}
+	spec := &ProjectSpec{
+		FieldGroups: []FieldGroupSpec{{Name: "sales", Color: "red"}, {Name: "Delivery", Color: "green"}},
+		CustomFields: []CustomFieldSpec{
+			{Name: "Budget", Group: "Sales"},
+			{Name: "Stage", Group: "Delivery"},
+		},
+	}
+
+	want := []string{
+		"~ field group sales: color: blue → red",
+		"+ field group Delivery: green",
+		"~ custom field Stage: group: (none) → Delivery",
+		"- field group Misc: 0 field(s) move to root level",
+	}
+	if got := planSummary(planFieldGroups(spec, state, true)); !reflect.DeepEqual(got, want) {
+		t.Errorf("planFieldGroups = %q\nwant %q", got, want)
+	}
+}
+
+func TestRoleDiffs(t *testing.T) {
+	live := &ProjectUserRole{Name: "Client", Description: "External", AllowInviteOthers: true}
+	role := RoleSpec{Name: "Client", Description: "External", AllowInviteOthers: boolPtr(false), IsChatEnabled: boolPtr(false)}
+
+	want := []string{"allowInviteOthers: true → false"}
+	if got := roleDiffs(role, live); !reflect.DeepEqual(got, want) {
+		t.Errorf("roleDiffs = %q; want %q", got, want)
+	}
+}
+
+func TestMoveFieldToGroup(t *testing.T) {
+	fields := []common.TodoField{
+		{Type: "CUSTOM_FIELD", CustomFieldID: strPtr("f1")},
+		{Type: "CUSTOM_FIELD_GROUP", CustomFieldID: strPtr("g1"), Name: strPtr("Sales"), TodoFields: []common.TodoField{
+			{Type: "CUSTOM_FIELD", CustomFieldID: strPtr("f2")},
+		}},
+	}
+
+	fields = moveFieldToGroup(fields, "f1", "sales")
+	fields = moveFieldToGroup(fields, "f2", "Nowhere")
+	fields = moveFieldToGroup(fields, "f3", "Sales")
+
+	var top, nested []string
+	for _, field := range fields {
+		top = append(top, *field.CustomFieldID)
+		for _, child := range field.TodoFields {
+			nested = append(nested, *child.CustomFieldID)
+		}
+	}
+	if want := []string{"g1", "f2"}; !reflect.DeepEqual(top, want) {
+		t.Errorf("top level after moves = %q; want %q", top, want)
+	}
+	if want := []string{"f1", "f3"}; !reflect.DeepEqual(nested, want) {
+		t.Errorf("Sales after moves = %q; want %q", nested, want)
+	}
+}
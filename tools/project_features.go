@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// featureStates returns the enabled state of every feature type of a project
+func featureStates(project *EditedProject) ([]string, map[string]bool) {
+	types := projectFeatureTypes(project.Features)
+	enabled := make(map[string]bool)
+	for _, featureType := range types {
+		enabled[featureType] = true
+	}
+	for _, feature := range project.Features {
+		enabled[feature.Type] = feature.Enabled
+	}
+	return types, enabled
+}
+
+// matchFeatureType finds a supported feature type by case-insensitive name
+func matchFeatureType(types []string, name string) string {
+	for _, featureType := range types {
+		if strings.EqualFold(featureType, strings.TrimSpace(name)) {
+			return featureType
+		}
+	}
+	return ""
+}
+
+// splitFeatureNames splits a comma-separated list of feature names
+func splitFeatureNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// planFeatureChanges works out the feature toggles needed for a project
+func planFeatureChanges(project *EditedProject, enable, disable, only []string) ([]common.ProjectFeatureInput, error) {
+	types, enabled := featureStates(project)
+	want := make(map[string]bool)
+
+	resolve := func(names []string, state bool) error {
+		for _, name := range names {
+			featureType := matchFeatureType(types, name)
+			if featureType == "" {
+				return fmt.Errorf("unknown feature '%s' (valid: %s)", name, strings.Join(types, ", "))
+			}
+			if previous, ok := want[featureType]; ok && previous != state {
+				return fmt.Errorf("feature '%s' is both enabled and disabled", featureType)
+			}
+			want[featureType] = state
+		}
+		return nil
+	}
+
+	if only != nil {
+		if err := resolve(only, true); err != nil {
+			return nil, err
+		}
+		for _, featureType := range types {
+			if _, ok := want[featureType]; !ok {
+				want[featureType] = false
+			}
+		}
+	}
+	if err := resolve(enable, true); err != nil {
+		return nil, err
+	}
+	if err := resolve(disable, false); err != nil {
+		return nil, err
+	}
+
+	var changes []common.ProjectFeatureInput
+	for _, featureType := range types {
+		if state, ok := want[featureType]; ok && state != enabled[featureType] {
+			changes = append(changes, common.ProjectFeatureInput{Type: featureType, Enabled: state})
+		}
+	}
+	return changes, nil
+}
+
+// describeFeatureChanges formats changes as "+Forms -Chat"
+func describeFeatureChanges(changes []common.ProjectFeatureInput) string {
+	var parts []string
+	for _, change := range changes {
+		if change.Enabled {
+			parts = append(parts, "+"+change.Type)
+		} else {
+			parts = append(parts, "-"+change.Type)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// searchProjectIDs returns the IDs of the active projects whose name matches a search
+func searchProjectIDs(client *common.Client, search string) ([]string, error) {
+	var ids []string
+	for skip := 0; ; skip += 100 {
+		query := buildProjectQuery(client.GetCompanyID(), true, skip, 100, search, false, false, "name_ASC", "")
+		var response ProjectListResponse
+		if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+			return nil, err
+		}
+		for _, project := range response.ProjectList.Items {
+			ids = append(ids, project.ID)
+		}
+		if !response.ProjectList.PageInfo.HasNextPage {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// RunProjectFeatures lists or toggles project features, for one project or every project matching a search
+func RunProjectFeatures(args []string) error {
+	fs := flag.NewFlagSet("project-features", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug")
+	search := fs.String("search", "", "Apply to every active project whose name matches this search")
+	enable := fs.String("enable", "", "Features to enable (comma-separated)")
+	disable := fs.String("disable", "", "Features to disable (comma-separated)")
+	only := fs.String("only", "", "Preset: enable exactly these features and disable all others (comma-separated)")
+	dryRun := fs.Bool("dry-run", false, "Show the changes without applying them")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if (*projectID == "") == (*search == "") {
+		fmt.Println("Usage: go run . project-features (-project PROJECT | -search TEXT) [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . project-features -project my-project")
+		fmt.Println("  go run . project-features -project my-project -enable Forms,Docs -disable Chat")
+		fmt.Println("  go run . project-features -search \"Client\" -only Todo,Files,People -dry-run")
+		return fmt.Errorf("exactly one of -project or -search is required")
+	}
+
+	var onlyNames []string
+	if *only != "" {
+		onlyNames = splitFeatureNames(*only)
+	}
+	enableNames := splitFeatureNames(*enable)
+	disableNames := splitFeatureNames(*disable)
+	toggling := onlyNames != nil || len(enableNames) > 0 || len(disableNames) > 0
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+
+	projectIDs := []string{*projectID}
+	if *search != "" {
+		if projectIDs, err = searchProjectIDs(client, *search); err != nil {
+			return fmt.Errorf("failed to search projects: %v", err)
+		}
+		if len(projectIDs) == 0 {
+			return fmt.Errorf("no projects match '%s'", *search)
+		}
+	}
+
+	changed, failed := 0, 0
+	for _, id := range projectIDs {
+		client.SetProject(id)
+		project, err := getCurrentProject(client, id)
+		if err != nil {
+			if *search == "" {
+				return fmt.Errorf("failed to fetch project %s: %v", id, err)
+			}
+			fmt.Printf("❌ %s: failed to fetch project: %v\n", id, err)
+			failed++
+			continue
+		}
+
+		if !toggling {
+			types, enabled := featureStates(project)
+			if *simple {
+				for _, featureType := range types {
+					fmt.Printf("%s\t%s\t%t\n", project.Name, featureType, enabled[featureType])
+				}
+				continue
+			}
+			fmt.Printf("\n=== Features: %s ===\n", project.Name)
+			for _, featureType := range types {
+				marker := "✅"
+				if !enabled[featureType] {
+					marker = "❌"
+				}
+				fmt.Printf("  %s %s\n", marker, featureType)
+			}
+			continue
+		}
+
+		changes, err := planFeatureChanges(project, enableNames, disableNames, onlyNames)
+		if err != nil {
+			if *search == "" {
+				return fmt.Errorf("%s: %v", project.Name, err)
+			}
+			fmt.Printf("❌ %s: %v\n", project.Name, err)
+			failed++
+			continue
+		}
+		if len(changes) == 0 {
+			if !*simple {
+				fmt.Printf("  %s: no changes\n", project.Name)
+			}
+			continue
+		}
+
+		if !*dryRun {
+			client.SetProjectID(project.ID)
+			if _, err := executeEditProject(client, EditProjectInput{ProjectID: project.ID, Features: changes}); err != nil {
+				fmt.Printf("❌ %s: %v\n", project.Name, err)
+				failed++
+				continue
+			}
+		}
+		changed++
+		if *simple {
+			fmt.Printf("%s\t%s\n", project.ID, describeFeatureChanges(changes))
+		} else {
+			fmt.Printf("  %s: %s\n", project.Name, describeFeatureChanges(changes))
+		}
+	}
+
+	if toggling && !*simple {
+		if *dryRun {
+			fmt.Printf("\nDry run: %d of %d project(s) would change\n", changed, len(projectIDs))
+		} else {
+			fmt.Printf("\n✅ Updated %d of %d project(s)\n", changed, len(projectIDs))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d project(s) could not be updated", failed)
+	}
+	return nil
+}
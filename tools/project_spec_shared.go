@@ -0,0 +1,693 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectSpec is a declarative description of a whole project. Everything is
+// referenced by name so the same spec can be applied to any workspace.
+type ProjectSpec struct {
+	Project      ProjectSettingsSpec `json:"project" yaml:"project"`
+	Features     map[string]bool     `json:"features,omitempty" yaml:"features,omitempty"`
+	Lists        []ListSpec          `json:"lists,omitempty" yaml:"lists,omitempty"`
+	Tags         []TagSpec           `json:"tags,omitempty" yaml:"tags,omitempty"`
+	FieldGroups  []FieldGroupSpec    `json:"fieldGroups,omitempty" yaml:"fieldGroups,omitempty"`
+	CustomFields []CustomFieldSpec   `json:"customFields,omitempty" yaml:"customFields,omitempty"`
+	Roles        []RoleSpec          `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Automations  []AutomationSpec    `json:"automations,omitempty" yaml:"automations,omitempty"`
+	Records      []RecordSpec        `json:"records,omitempty" yaml:"records,omitempty"`
+}
+
+// ProjectSettingsSpec holds the project-level settings
+type ProjectSettingsSpec struct {
+	Name                    string `json:"name" yaml:"name"`
+	Description             string `json:"description,omitempty" yaml:"description,omitempty"`
+	Color                   string `json:"color,omitempty" yaml:"color,omitempty"`
+	Icon                    string `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Category                string `json:"category,omitempty" yaml:"category,omitempty"`
+	TodoAlias               string `json:"todoAlias,omitempty" yaml:"todoAlias,omitempty"`
+	HideRecordCount         *bool  `json:"hideRecordCount,omitempty" yaml:"hideRecordCount,omitempty"`
+	ShowTimeSpentInTodoList *bool  `json:"showTimeSpentInTodoList,omitempty" yaml:"showTimeSpentInTodoList,omitempty"`
+	ShowTimeSpentInProject  *bool  `json:"showTimeSpentInProject,omitempty" yaml:"showTimeSpentInProject,omitempty"`
+}
+
+// ListSpec describes a todo list
+type ListSpec struct {
+	Title  string `json:"title" yaml:"title"`
+	Locked *bool  `json:"locked,omitempty" yaml:"locked,omitempty"`
+}
+
+// TagSpec describes a tag
+type TagSpec struct {
+	Title string `json:"title" yaml:"title"`
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// FieldGroupSpec describes a custom field group
+type FieldGroupSpec struct {
+	Name  string `json:"name" yaml:"name"`
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// CustomFieldSpec describes a custom field and its options
+type CustomFieldSpec struct {
+	Name        string       `json:"name" yaml:"name"`
+	Type        string       `json:"type" yaml:"type"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	Group       string       `json:"group,omitempty" yaml:"group,omitempty"`
+	Min         *float64     `json:"min,omitempty" yaml:"min,omitempty"`
+	Max         *float64     `json:"max,omitempty" yaml:"max,omitempty"`
+	Currency    string       `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Prefix      string       `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Options     []OptionSpec `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// OptionSpec describes a select option
+type OptionSpec struct {
+	Title string `json:"title" yaml:"title"`
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// RoleSpec describes a custom project user role
+type RoleSpec struct {
+	Name                      string `json:"name" yaml:"name"`
+	Description               string `json:"description,omitempty" yaml:"description,omitempty"`
+	AllowInviteOthers         *bool  `json:"allowInviteOthers,omitempty" yaml:"allowInviteOthers,omitempty"`
+	AllowMarkRecordsAsDone    *bool  `json:"allowMarkRecordsAsDone,omitempty" yaml:"allowMarkRecordsAsDone,omitempty"`
+	ShowOnlyAssignedTodos     *bool  `json:"showOnlyAssignedTodos,omitempty" yaml:"showOnlyAssignedTodos,omitempty"`
+	ShowOnlyMentionedComments *bool  `json:"showOnlyMentionedComments,omitempty" yaml:"showOnlyMentionedComments,omitempty"`
+	CanDeleteRecords          *bool  `json:"canDeleteRecords,omitempty" yaml:"canDeleteRecords,omitempty"`
+	IsActivityEnabled         *bool  `json:"isActivityEnabled,omitempty" yaml:"isActivityEnabled,omitempty"`
+	IsChatEnabled             *bool  `json:"isChatEnabled,omitempty" yaml:"isChatEnabled,omitempty"`
+	IsDocsEnabled             *bool  `json:"isDocsEnabled,omitempty" yaml:"isDocsEnabled,omitempty"`
+	IsFormsEnabled            *bool  `json:"isFormsEnabled,omitempty" yaml:"isFormsEnabled,omitempty"`
+	IsWikiEnabled             *bool  `json:"isWikiEnabled,omitempty" yaml:"isWikiEnabled,omitempty"`
+	IsFilesEnabled            *bool  `json:"isFilesEnabled,omitempty" yaml:"isFilesEnabled,omitempty"`
+	IsRecordsEnabled          *bool  `json:"isRecordsEnabled,omitempty" yaml:"isRecordsEnabled,omitempty"`
+	IsPeopleEnabled           *bool  `json:"isPeopleEnabled,omitempty" yaml:"isPeopleEnabled,omitempty"`
+}
+
+// AutomationSpec describes an automation. Automations have no name in Blue,
+// so they are matched on their trigger and actions.
+type AutomationSpec struct {
+	Active  *bool                  `json:"active,omitempty" yaml:"active,omitempty"`
+	Trigger AutomationTriggerSpec  `json:"trigger" yaml:"trigger"`
+	Actions []AutomationActionSpec `json:"actions" yaml:"actions"`
+}
+
+// AutomationTriggerSpec describes an automation trigger
+type AutomationTriggerSpec struct {
+	Type           string   `json:"type" yaml:"type"`
+	List           string   `json:"list,omitempty" yaml:"list,omitempty"`
+	Tags           []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Field          string   `json:"field,omitempty" yaml:"field,omitempty"`
+	Options        []string `json:"options,omitempty" yaml:"options,omitempty"`
+	Color          string   `json:"color,omitempty" yaml:"color,omitempty"`
+	IncompleteOnly *bool    `json:"incompleteOnly,omitempty" yaml:"incompleteOnly,omitempty"`
+}
+
+// AutomationActionSpec describes an automation action
+type AutomationActionSpec struct {
+	Type      string               `json:"type" yaml:"type"`
+	List      string               `json:"list,omitempty" yaml:"list,omitempty"`
+	Tags      []string             `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Field     string               `json:"field,omitempty" yaml:"field,omitempty"`
+	Options   []string             `json:"options,omitempty" yaml:"options,omitempty"`
+	Color     string               `json:"color,omitempty" yaml:"color,omitempty"`
+	DueIn     *int                 `json:"dueIn,omitempty" yaml:"dueIn,omitempty"`
+	Assignees []string             `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+	Email     *AutomationEmailSpec `json:"email,omitempty" yaml:"email,omitempty"`
+	HTTP      *AutomationHTTPSpec  `json:"http,omitempty" yaml:"http,omitempty"`
+}
+
+// AutomationEmailSpec describes a SEND_EMAIL action
+type AutomationEmailSpec struct {
+	From    string   `json:"from,omitempty" yaml:"from,omitempty"`
+	To      []string `json:"to" yaml:"to"`
+	Subject string   `json:"subject" yaml:"subject"`
+	Content string   `json:"content" yaml:"content"`
+}
+
+// AutomationHTTPSpec describes a MAKE_HTTP_REQUEST action
+type AutomationHTTPSpec struct {
+	URL         string `json:"url" yaml:"url"`
+	Method      string `json:"method,omitempty" yaml:"method,omitempty"`
+	ContentType string `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Body        string `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// RecordSpec describes a seed record. Records are matched by title within their list.
+type RecordSpec struct {
+	Title       string                 `json:"title" yaml:"title"`
+	List        string                 `json:"list" yaml:"list"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Color       string                 `json:"color,omitempty" yaml:"color,omitempty"`
+	Start       string                 `json:"start,omitempty" yaml:"start,omitempty"`
+	Due         string                 `json:"due,omitempty" yaml:"due,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Checklists  []RecordChecklistSpec  `json:"checklists,omitempty" yaml:"checklists,omitempty"`
+	Comments    []string               `json:"comments,omitempty" yaml:"comments,omitempty"`
+}
+
+// RecordChecklistSpec describes a checklist on a seed record
+type RecordChecklistSpec struct {
+	Title string   `json:"title" yaml:"title"`
+	Items []string `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// ProjectState is the live configuration of a project, as fetched from the API
+type ProjectState struct {
+	Project      *EditedProject
+	Lists        []common.TodoList
+	Tags         []common.Tag
+	CustomFields []common.CustomField
+	TodoFields   []common.TodoField
+	Roles        []ProjectUserRole
+	Automations  []AutomationItem
+}
+
+// boolPtr returns a pointer to a copy of b
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// specKey normalises a name for matching spec entries against live objects
+func specKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// loadProjectSpec reads a spec file; .json files are parsed as JSON, everything else as YAML
+func loadProjectSpec(path string) (*ProjectSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec ProjectSpec
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("invalid JSON spec: %v", err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("invalid YAML spec: %v", err)
+		}
+	}
+
+	if err := validateProjectSpec(&spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// validateProjectSpec checks names, types and references inside a spec
+func validateProjectSpec(spec *ProjectSpec) error {
+	var problems []string
+	seen := func(kind string, names []string) map[string]bool {
+		set := make(map[string]bool)
+		for _, name := range names {
+			key := specKey(name)
+			if key == "" {
+				problems = append(problems, fmt.Sprintf("%s with an empty name", kind))
+				continue
+			}
+			if set[key] {
+				problems = append(problems, fmt.Sprintf("duplicate %s '%s'", kind, name))
+			}
+			set[key] = true
+		}
+		return set
+	}
+
+	var names []string
+	for _, list := range spec.Lists {
+		names = append(names, list.Title)
+	}
+	seen("list", names)
+
+	names = nil
+	for _, tag := range spec.Tags {
+		names = append(names, tag.Title)
+	}
+	seen("tag", names)
+
+	names = nil
+	for _, group := range spec.FieldGroups {
+		names = append(names, group.Name)
+	}
+	groups := seen("field group", names)
+
+	names = nil
+	fieldOptions := make(map[string]map[string]bool)
+	for _, field := range spec.CustomFields {
+		names = append(names, field.Name)
+		if !isValidCustomFieldType(field.Type) {
+			problems = append(problems, fmt.Sprintf("custom field '%s' has invalid type '%s'", field.Name, field.Type))
+		}
+		if field.Group != "" && !groups[specKey(field.Group)] {
+			problems = append(problems, fmt.Sprintf("custom field '%s' references unknown group '%s'", field.Name, field.Group))
+		}
+		var optionNames []string
+		for _, option := range field.Options {
+			optionNames = append(optionNames, option.Title)
+		}
+		fieldOptions[specKey(field.Name)] = seen(fmt.Sprintf("option of '%s'", field.Name), optionNames)
+	}
+	seen("custom field", names)
+
+	names = nil
+	for _, role := range spec.Roles {
+		names = append(names, role.Name)
+	}
+	seen("role", names)
+
+	for feature := range spec.Features {
+		if normalizeFeatureType(feature) == "" {
+			problems = append(problems, fmt.Sprintf("unknown feature '%s' (valid: %s)", feature, strings.Join(featureTypes, ", ")))
+		}
+	}
+
+	// References to lists, tags and fields may also point at objects that only
+	// exist in the live project, so only the referenced field's options are checked here
+	checkOptions := func(where, field string, options []string) {
+		if len(options) == 0 {
+			return
+		}
+		if field == "" {
+			problems = append(problems, fmt.Sprintf("%s lists options without a field", where))
+			return
+		}
+		known, ok := fieldOptions[specKey(field)]
+		if !ok {
+			return
+		}
+		for _, option := range options {
+			if !known[specKey(option)] {
+				problems = append(problems, fmt.Sprintf("%s references unknown option '%s' of '%s'", where, option, field))
+			}
+		}
+	}
+	for i, automation := range spec.Automations {
+		where := fmt.Sprintf("automation #%d", i+1)
+		if automation.Trigger.Type == "" {
+			problems = append(problems, where+" has no trigger type")
+		}
+		if len(automation.Actions) == 0 {
+			problems = append(problems, where+" has no actions")
+		}
+		checkOptions(where+" trigger", automation.Trigger.Field, automation.Trigger.Options)
+		for _, action := range automation.Actions {
+			if action.Type == "" {
+				problems = append(problems, where+" has an action without a type")
+			}
+			checkOptions(where+" action "+action.Type, action.Field, action.Options)
+		}
+	}
+
+	for _, record := range spec.Records {
+		if strings.TrimSpace(record.Title) == "" {
+			problems = append(problems, "record with an empty title")
+		}
+		if record.List == "" {
+			problems = append(problems, fmt.Sprintf("record '%s' has no list", record.Title))
+		}
+	}
+
+	if spec.Project.Name == "" {
+		problems = append(problems, "project.name is required")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid spec:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// isValidCustomFieldType reports whether the type is a known custom field type
+func isValidCustomFieldType(fieldType string) bool {
+	for _, t := range customFieldTypes {
+		if t == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFeatureType maps a feature name to its canonical type, or "" if unknown
+func normalizeFeatureType(name string) string {
+	for _, featureType := range featureTypes {
+		if strings.EqualFold(featureType, strings.TrimSpace(name)) {
+			return featureType
+		}
+	}
+	return ""
+}
+
+// findProjectByName returns the ID of the non-archived project with exactly this name
+func findProjectByName(client *common.Client, name string) (string, error) {
+	query := buildProjectQuery(client.GetCompanyID(), true, 0, 100, name, false, false, "name_ASC", "")
+
+	var response ProjectListResponse
+	if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+		return "", err
+	}
+
+	for _, project := range response.ProjectList.Items {
+		if strings.EqualFold(project.Name, name) {
+			return project.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// fetchProjectCustomFields returns every custom field of a project with its options
+func fetchProjectCustomFields(client *common.Client, projectID string) ([]common.CustomField, error) {
+	var fields []common.CustomField
+	for skip := 0; ; skip += 100 {
+		var response ReadCustomFieldsResponse
+		if err := client.ExecuteQueryWithResult(buildReadCustomFieldsQuery(projectID, skip, 100), nil, &response); err != nil {
+			return nil, err
+		}
+		fields = append(fields, response.CustomFields.Items...)
+		if !response.CustomFields.PageInfo.HasNextPage || len(response.CustomFields.Items) == 0 {
+			break
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Position < fields[j].Position
+	})
+	return fields, nil
+}
+
+// fetchProjectUserRoles returns the custom user roles of a project
+func fetchProjectUserRoles(client *common.Client, projectID string) ([]ProjectUserRole, error) {
+	query := `
+		query ProjectUserRoles($filter: ProjectUserRoleFilter!) {
+			projectUserRoles(filter: $filter) {
+				id
+				uid
+				name
+				description
+				allowInviteOthers
+				allowMarkRecordsAsDone
+				showOnlyAssignedTodos
+				showOnlyMentionedComments
+				isActivityEnabled
+				isChatEnabled
+				isDocsEnabled
+				isFormsEnabled
+				isWikiEnabled
+				isFilesEnabled
+				isRecordsEnabled
+				isPeopleEnabled
+				canDeleteRecords
+				createdAt
+				updatedAt
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"filter": map[string]interface{}{
+			"projectId": projectID,
+		},
+	}
+
+	var response struct {
+		ProjectUserRoles []ProjectUserRole `json:"projectUserRoles"`
+	}
+	if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+		return nil, err
+	}
+
+	return response.ProjectUserRoles, nil
+}
+
+// fetchProjectAutomations returns every automation of the project in the client context
+func fetchProjectAutomations(client *common.Client, projectID string) ([]AutomationItem, error) {
+	var automations []AutomationItem
+	for skip := 0; ; skip += 100 {
+		response, err := executeReadAutomations(client, projectID, skip, 100)
+		if err != nil {
+			return nil, err
+		}
+		items := response.AutomationList.Items
+		automations = append(automations, items...)
+		if len(items) < 100 || len(automations) >= response.AutomationList.TotalCount {
+			break
+		}
+	}
+	return automations, nil
+}
+
+// fetchProjectState loads the live configuration of a project
+func fetchProjectState(client *common.Client, projectID string) (*ProjectState, error) {
+	project, err := getCurrentProject(client, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project: %v", err)
+	}
+
+	state := &ProjectState{Project: project}
+
+	if state.Lists, err = fetchProjectLists(client, project.ID); err != nil {
+		return nil, fmt.Errorf("failed to fetch lists: %v", err)
+	}
+	if state.Tags, err = fetchProjectTags(client, project.ID); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %v", err)
+	}
+	if state.CustomFields, err = fetchProjectCustomFields(client, project.ID); err != nil {
+		return nil, fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	if state.TodoFields, err = fetchProjectTodoFields(client, project.ID); err != nil {
+		return nil, fmt.Errorf("failed to fetch field groups: %v", err)
+	}
+	if state.Roles, err = fetchProjectUserRoles(client, project.ID); err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %v", err)
+	}
+	if state.Automations, err = fetchProjectAutomations(client, project.ID); err != nil {
+		return nil, fmt.Errorf("failed to fetch automations: %v", err)
+	}
+
+	return state, nil
+}
+
+// findList returns the live list with this title
+func (s *ProjectState) findList(title string) *common.TodoList {
+	for i := range s.Lists {
+		if specKey(s.Lists[i].Title) == specKey(title) {
+			return &s.Lists[i]
+		}
+	}
+	return nil
+}
+
+// findTag returns the live tag with this title
+func (s *ProjectState) findTag(title string) *common.Tag {
+	for i := range s.Tags {
+		if specKey(s.Tags[i].Title) == specKey(title) {
+			return &s.Tags[i]
+		}
+	}
+	return nil
+}
+
+// findCustomField returns the live custom field with this name
+func (s *ProjectState) findCustomField(name string) *common.CustomField {
+	for i := range s.CustomFields {
+		if specKey(s.CustomFields[i].Name) == specKey(name) {
+			return &s.CustomFields[i]
+		}
+	}
+	return nil
+}
+
+// findRole returns the live role with this name
+func (s *ProjectState) findRole(name string) *ProjectUserRole {
+	for i := range s.Roles {
+		if specKey(s.Roles[i].Name) == specKey(name) {
+			return &s.Roles[i]
+		}
+	}
+	return nil
+}
+
+// fieldGroups returns the custom field groups in todoFields order
+func (s *ProjectState) fieldGroups() []common.TodoField {
+	var groups []common.TodoField
+	for _, field := range s.TodoFields {
+		if field.Type == "CUSTOM_FIELD_GROUP" {
+			groups = append(groups, field)
+		}
+	}
+	return groups
+}
+
+// fieldGroupOf returns the name of the group that holds a custom field, or ""
+func (s *ProjectState) fieldGroupOf(fieldID string) string {
+	for _, field := range s.TodoFields {
+		if field.Type != "CUSTOM_FIELD_GROUP" {
+			continue
+		}
+		for _, nested := range field.TodoFields {
+			if nested.CustomFieldID != nil && *nested.CustomFieldID == fieldID && field.Name != nil {
+				return *field.Name
+			}
+		}
+	}
+	return ""
+}
+
+// findOption returns the option of a custom field with this title
+func findOption(field *common.CustomField, title string) *common.CustomFieldOption {
+	for i := range field.Options {
+		if specKey(field.Options[i].Title) == specKey(title) {
+			return &field.Options[i]
+		}
+	}
+	return nil
+}
+
+// automationSignature builds a comparable description of an automation from names
+func automationSignature(trigger AutomationTriggerSpec, actions []AutomationActionSpec) string {
+	sortedKeys := func(values []string) string {
+		keys := make([]string, len(values))
+		for i, value := range values {
+			keys[i] = specKey(value)
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ",")
+	}
+
+	parts := []string{fmt.Sprintf("%s[list=%s;tags=%s;field=%s;options=%s;color=%s]",
+		trigger.Type, specKey(trigger.List), sortedKeys(trigger.Tags), specKey(trigger.Field),
+		sortedKeys(trigger.Options), specKey(trigger.Color))}
+
+	for _, action := range actions {
+		detail := fmt.Sprintf("%s[list=%s;tags=%s;field=%s;options=%s;color=%s",
+			action.Type, specKey(action.List), sortedKeys(action.Tags), specKey(action.Field),
+			sortedKeys(action.Options), specKey(action.Color))
+		if action.DueIn != nil {
+			detail += fmt.Sprintf(";due=%d", *action.DueIn)
+		}
+		if action.Email != nil {
+			detail += fmt.Sprintf(";email=%s;subject=%s", sortedKeys(action.Email.To), action.Email.Subject)
+		}
+		if action.HTTP != nil {
+			detail += fmt.Sprintf(";http=%s %s", strings.ToUpper(action.HTTP.Method), action.HTTP.URL)
+		}
+		parts = append(parts, detail+"]")
+	}
+
+	return strings.Join(parts, " -> ")
+}
+
+// automationToSpec converts a live automation to its by-name spec form
+func automationToSpec(item AutomationItem) AutomationSpec {
+	active := item.IsActive
+	spec := AutomationSpec{Active: &active}
+
+	trigger := item.Trigger
+	spec.Trigger.Type = trigger.Type
+	if trigger.TodoList != nil {
+		spec.Trigger.List = trigger.TodoList.Title
+	}
+	for _, tag := range trigger.Tags {
+		spec.Trigger.Tags = append(spec.Trigger.Tags, tag.Title)
+	}
+	if trigger.CustomField != nil {
+		spec.Trigger.Field = trigger.CustomField.Name
+	}
+	for _, option := range trigger.CustomFieldOptions {
+		spec.Trigger.Options = append(spec.Trigger.Options, option.Title)
+	}
+	if trigger.Color != nil {
+		spec.Trigger.Color = *trigger.Color
+	}
+	if trigger.Metadata != nil {
+		spec.Trigger.IncompleteOnly = trigger.Metadata.IncompleteOnly
+	}
+
+	for _, action := range item.Actions {
+		actionSpec := AutomationActionSpec{Type: action.Type, DueIn: action.DuedIn}
+		if action.TodoList != nil {
+			actionSpec.List = action.TodoList.Title
+		}
+		for _, tag := range action.Tags {
+			actionSpec.Tags = append(actionSpec.Tags, tag.Title)
+		}
+		if action.CustomField != nil {
+			actionSpec.Field = action.CustomField.Name
+		}
+		for _, option := range action.CustomFieldOptions {
+			actionSpec.Options = append(actionSpec.Options, option.Title)
+		}
+		if action.Color != nil {
+			actionSpec.Color = *action.Color
+		}
+		for _, assignee := range action.Assignees {
+			actionSpec.Assignees = append(actionSpec.Assignees, assignee.ID)
+		}
+		if action.Metadata != nil && action.Metadata.Email != nil {
+			email := action.Metadata.Email
+			actionSpec.Email = &AutomationEmailSpec{To: email.To, Subject: email.Subject, Content: email.Content}
+			if email.From != nil {
+				actionSpec.Email.From = *email.From
+			}
+		}
+		if action.HttpOption != nil {
+			actionSpec.HTTP = &AutomationHTTPSpec{URL: action.HttpOption.URL, Method: action.HttpOption.Method}
+			if action.HttpOption.ContentType != nil {
+				actionSpec.HTTP.ContentType = *action.HttpOption.ContentType
+			}
+			if action.HttpOption.Body != nil {
+				actionSpec.HTTP.Body = *action.HttpOption.Body
+			}
+		}
+		spec.Actions = append(spec.Actions, actionSpec)
+	}
+
+	return spec
+}
+
+// describeAutomationSpec returns a one-line summary of an automation
+func describeAutomationSpec(automation AutomationSpec) string {
+	trigger := automation.Trigger.Type
+	var refs []string
+	if automation.Trigger.List != "" {
+		refs = append(refs, "list "+automation.Trigger.List)
+	}
+	if len(automation.Trigger.Tags) > 0 {
+		refs = append(refs, "tags "+strings.Join(automation.Trigger.Tags, "/"))
+	}
+	if automation.Trigger.Field != "" {
+		refs = append(refs, "field "+automation.Trigger.Field)
+	}
+	if len(automation.Trigger.Options) > 0 {
+		refs = append(refs, "options "+strings.Join(automation.Trigger.Options, "/"))
+	}
+	if len(refs) > 0 {
+		trigger += " (" + strings.Join(refs, ", ") + ")"
+	}
+
+	var actions []string
+	for _, action := range automation.Actions {
+		actions = append(actions, action.Type)
+	}
+	return fmt.Sprintf("%s → %s", trigger, strings.Join(actions, ", "))
+}
@@ -0,0 +1,800 @@
+package tools
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// userAccessLevels are the valid values of the UserAccessLevel enum
+var userAccessLevels = []string{"OWNER", "ADMIN", "MEMBER", "CLIENT", "COMMENT_ONLY", "VIEW_ONLY"}
+
+// ProjectMember is a user of a project with their project access level and role
+type ProjectMember struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	FullName    string `json:"fullName"`
+	AccessLevel string `json:"projectLevel"`
+	Role        *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"projectUserRole"`
+}
+
+// roleID returns the ID of the member's custom role, or "" without one
+func (m *ProjectMember) roleID() string {
+	if m.Role == nil {
+		return ""
+	}
+	return m.Role.ID
+}
+
+// ProjectUserEntry is one user of a project-users operation, from the flags or a CSV row
+type ProjectUserEntry struct {
+	User  string
+	Level string
+	Role  string
+}
+
+// parseAccessLevel normalizes an access level such as "comment-only" to COMMENT_ONLY
+func parseAccessLevel(value string) (string, error) {
+	level := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"))
+	for _, valid := range userAccessLevels {
+		if level == valid {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("invalid access level '%s' (valid: %s)", value, strings.Join(userAccessLevels, ", "))
+}
+
+// fetchProjectMembers returns every user of a project with their access level and role
+func fetchProjectMembers(client *common.Client, projectID string) ([]ProjectMember, error) {
+	query := `
+		query ProjectMembers($projectId: String!, $first: Int, $skip: Int) {
+			projectUserList(projectId: $projectId, first: $first, skip: $skip, orderBy: firstName_ASC) {
+				users {
+					id
+					email
+					fullName
+					projectLevel(projectId: $projectId)
+					projectUserRole(projectId: $projectId) {
+						id
+						name
+					}
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}
+	`
+
+	var members []ProjectMember
+	for skip := 0; ; skip += 100 {
+		variables := map[string]interface{}{
+			"projectId": projectID,
+			"first":     100,
+			"skip":      skip,
+		}
+		var response struct {
+			ProjectUserList struct {
+				Users    []ProjectMember `json:"users"`
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"projectUserList"`
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		members = append(members, response.ProjectUserList.Users...)
+		if !response.ProjectUserList.PageInfo.HasNextPage || len(response.ProjectUserList.Users) == 0 {
+			break
+		}
+	}
+	return members, nil
+}
+
+// findProjectMember finds a project member by ID or (case-insensitive) email
+func findProjectMember(members []ProjectMember, ref string) *ProjectMember {
+	for i := range members {
+		if members[i].ID == ref || strings.EqualFold(members[i].Email, ref) {
+			return &members[i]
+		}
+	}
+	return nil
+}
+
+// findCompanyUser finds a user of the company by ID or email, paging through the company's users
+func findCompanyUser(client *common.Client, ref string) (*common.User, error) {
+	query := `
+		query FindCompanyUser($companyId: String!, $search: String, $first: Int, $skip: Int) {
+			companyUserList(companyId: $companyId, search: $search, first: $first, skip: $skip, orderBy: firstName_ASC) {
+				users {
+					id
+					email
+					firstName
+					lastName
+					fullName
+				}
+			}
+		}
+	`
+
+	const pageSize = 500
+	variables := map[string]interface{}{
+		"companyId": client.GetCompanyID(),
+		"first":     pageSize,
+	}
+	if strings.Contains(ref, "@") {
+		variables["search"] = ref
+	}
+
+	for skip := 0; ; skip += pageSize {
+		variables["skip"] = skip
+		var response struct {
+			CompanyUserList struct {
+				Users []common.User `json:"users"`
+			} `json:"companyUserList"`
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		users := response.CompanyUserList.Users
+		for i := range users {
+			if users[i].ID == ref || strings.EqualFold(users[i].Email, ref) {
+				return &users[i], nil
+			}
+		}
+		if len(users) < pageSize {
+			return nil, nil
+		}
+	}
+}
+
+// resolveProjectRole finds a custom project role by ID or name
+func resolveProjectRole(roles []ProjectUserRole, ref string) (*ProjectUserRole, error) {
+	var names []string
+	for i := range roles {
+		if roles[i].ID == ref || strings.EqualFold(roles[i].Name, ref) {
+			return &roles[i], nil
+		}
+		names = append(names, roles[i].Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("role '%s' not found: the project has no custom roles", ref)
+	}
+	return nil, fmt.Errorf("role '%s' not found (available: %s)", ref, strings.Join(names, ", "))
+}
+
+// readProjectUserEntries reads email,level,role rows from a CSV file. A header
+// row starting with "email" and lines starting with # are skipped.
+func readProjectUserEntries(path string) ([]ProjectUserEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %v", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []ProjectUserEntry
+	for line := 1; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV file: %v", err)
+		}
+		column := func(i int) string {
+			if i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		if column(0) == "" || (line == 1 && strings.EqualFold(column(0), "email")) {
+			continue
+		}
+		entries = append(entries, ProjectUserEntry{User: column(0), Level: column(1), Role: column(2)})
+	}
+	return entries, nil
+}
+
+// executeInviteToProject invites a user to a project, adding existing company users directly
+func executeInviteToProject(client *common.Client, email, projectID, accessLevel, roleID string) error {
+	mutation := `
+		mutation InviteUser($input: InviteUserInput!) {
+			inviteUser(input: $input)
+		}
+	`
+
+	input := map[string]interface{}{
+		"email":       email,
+		"accessLevel": accessLevel,
+		"projectId":   projectID,
+	}
+	if roleID != "" {
+		input["roleId"] = roleID
+	}
+
+	var response struct {
+		InviteUser bool `json:"inviteUser"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return err
+	}
+	if !response.InviteUser {
+		return fmt.Errorf("invitation failed - the API returned false")
+	}
+	return nil
+}
+
+// executeUpdateProjectAccessLevel changes a user's access level and role in the project in the client context
+func executeUpdateProjectAccessLevel(client *common.Client, userID, accessLevel, roleID string) error {
+	mutation := `
+		mutation UpdateProjectAccessLevel($input: UpdateProjectAccessLevelInput!) {
+			updateProjectAccessLevel(input: $input)
+		}
+	`
+
+	input := map[string]interface{}{
+		"userId":      userID,
+		"accessLevel": accessLevel,
+	}
+	if roleID != "" {
+		input["roleId"] = roleID
+	}
+
+	var response struct {
+		UpdateProjectAccessLevel bool `json:"updateProjectAccessLevel"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return err
+	}
+	if !response.UpdateProjectAccessLevel {
+		return fmt.Errorf("the API reported that the access level was not changed")
+	}
+	return nil
+}
+
+// executeRemoveProjectUser removes a user from a project
+func executeRemoveProjectUser(client *common.Client, projectID, userID string) error {
+	mutation := `
+		mutation RemoveProjectUser($input: RemoveProjectUserInput!) {
+			removeProjectUser(input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"projectId": projectID,
+			"userId":    userID,
+		},
+	}
+	var response struct {
+		RemoveProjectUser common.MutationResult `json:"removeProjectUser"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+	if !response.RemoveProjectUser.Success {
+		return fmt.Errorf("the API reported that the user was not removed")
+	}
+	return nil
+}
+
+// executeUpdateCompanyAccessLevel changes a user's access level in the company in the client context
+func executeUpdateCompanyAccessLevel(client *common.Client, userID, accessLevel string) error {
+	mutation := `
+		mutation UpdateCompanyAccessLevel($input: UpdateCompanyAccessLevelInput!) {
+			updateCompanyAccessLevel(input: $input)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"userId":      userID,
+			"accessLevel": accessLevel,
+		},
+	}
+	var response struct {
+		UpdateCompanyAccessLevel bool `json:"updateCompanyAccessLevel"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+	if !response.UpdateCompanyAccessLevel {
+		return fmt.Errorf("the API reported that the access level was not changed")
+	}
+	return nil
+}
+
+// executeRemoveCompanyUser removes a user from a company and all of its projects
+func executeRemoveCompanyUser(client *common.Client, companyID, userID string) error {
+	mutation := `
+		mutation RemoveCompanyUser($input: RemoveCompanyUserInput!) {
+			removeCompanyUser(input: $input)
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"companyId": companyID,
+			"userId":    userID,
+		},
+	}
+	var response struct {
+		RemoveCompanyUser *bool `json:"removeCompanyUser"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+	if response.RemoveCompanyUser != nil && !*response.RemoveCompanyUser {
+		return fmt.Errorf("the API reported that the user was not removed")
+	}
+	return nil
+}
+
+// executeAddSelfToProject adds the current user to a project of their company
+func executeAddSelfToProject(client *common.Client, projectID string) error {
+	mutation := `
+		mutation AddSelfToProject($projectId: ID!) {
+			addSelfToProject(projectId: $projectId)
+		}
+	`
+
+	var response struct {
+		AddSelfToProject bool `json:"addSelfToProject"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, map[string]interface{}{"projectId": projectID}, &response); err != nil {
+		return err
+	}
+	if !response.AddSelfToProject {
+		return fmt.Errorf("the API reported that you were not added")
+	}
+	return nil
+}
+
+// executeLeaveProject removes the current user from the project in the client context
+func executeLeaveProject(client *common.Client) error {
+	mutation := `
+		mutation LeaveProject {
+			leaveProject
+		}
+	`
+
+	var response struct {
+		LeaveProject *bool `json:"leaveProject"`
+	}
+	if err := client.ExecuteQueryWithResult(mutation, nil, &response); err != nil {
+		return err
+	}
+	if response.LeaveProject != nil && !*response.LeaveProject {
+		return fmt.Errorf("the API reported that you did not leave the project")
+	}
+	return nil
+}
+
+// printProjectUsersUsage prints the usage of the project-users command group
+func printProjectUsersUsage() {
+	fmt.Println("Usage: go run . project-users <subcommand> [flags]")
+	fmt.Println("\nSubcommands:")
+	fmt.Println("  list        List the users of a project with their access level and role")
+	fmt.Println("  add         Add users to a project (company members are added, others invited)")
+	fmt.Println("  remove      Remove users from a project (or the company with -company)")
+	fmt.Println("  set-level   Change the access level of project users (or company users with -company)")
+	fmt.Println("  set-role    Change the custom role of project users")
+	fmt.Println("  join        Add yourself to a project of your company")
+	fmt.Println("  leave       Remove yourself from a project")
+	fmt.Println("\nUsers are given by email or ID with -users, or as email,level,role rows with -csv.")
+	fmt.Println("\nExamples:")
+	fmt.Println("  go run . project-users list -project acme")
+	fmt.Println("  go run . project-users add -project acme -users ann@acme.com,bob@acme.com -level CLIENT -role \"Client Viewer\"")
+	fmt.Println("  go run . project-users add -project acme -csv acme-team.csv")
+	fmt.Println("  go run . project-users set-level -project acme -users bob@acme.com -level COMMENT_ONLY")
+	fmt.Println("  go run . project-users set-role -project acme -users ann@acme.com -role \"Approver\"")
+	fmt.Println("  go run . project-users remove -project acme -users bob@acme.com -confirm")
+	fmt.Println("  go run . project-users remove -company -users bob@acme.com -confirm")
+	fmt.Println("  go run . project-users join -project acme")
+	fmt.Println("  go run . project-users leave -project acme -confirm")
+}
+
+// RunProjectUsers manages the users of a project
+func RunProjectUsers(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		printProjectUsersUsage()
+		return fmt.Errorf("subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		return runProjectUsersList(args[1:])
+	case "add", "remove", "set-level", "set-role":
+		return runProjectUsersChange(args[0], args[1:])
+	case "join", "leave":
+		return runProjectUsersSelf(args[0], args[1:])
+	default:
+		printProjectUsersUsage()
+		return fmt.Errorf("unknown subcommand '%s'", args[0])
+	}
+}
+
+// runProjectUsersList prints the users of a project
+func runProjectUsersList(args []string) error {
+	fs := flag.NewFlagSet("project-users list", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" {
+		fmt.Println("Usage: go run . project-users list -project PROJECT [-simple]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	project, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+	members, err := fetchProjectMembers(client, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project users: %v", err)
+	}
+
+	if *simple {
+		for _, member := range members {
+			role := ""
+			if member.Role != nil {
+				role = member.Role.Name
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", member.ID, member.Email, member.AccessLevel, role)
+		}
+		return nil
+	}
+
+	fmt.Printf("\n=== Users of %s (%d) ===\n", project.Name, len(members))
+	for _, member := range members {
+		fmt.Printf("  %-30s %-35s %-12s", truncateString(member.FullName, 30), member.Email, member.AccessLevel)
+		if member.Role != nil {
+			fmt.Printf(" %s", member.Role.Name)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// runProjectUsersSelf implements join and leave for the current user
+func runProjectUsersSelf(command string, args []string) error {
+	fs := flag.NewFlagSet("project-users "+command, flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	confirm := fs.Bool("confirm", false, "Confirm leaving (required for leave)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" {
+		fmt.Printf("Usage: go run . project-users %s -project PROJECT [flags]\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	project, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		// A project you are not in may not be readable yet; join accepts its ID as is
+		if command != "join" {
+			return fmt.Errorf("failed to fetch project: %v", err)
+		}
+		project = &EditedProject{ID: *projectID, Name: *projectID}
+	}
+	client.SetProjectID(project.ID)
+
+	if command == "leave" {
+		if !*confirm {
+			fmt.Printf("⚠️  This will remove you from project '%s'\n", project.Name)
+			return fmt.Errorf("confirmation is required. Use -confirm flag to leave the project")
+		}
+		if err := executeLeaveProject(client); err != nil {
+			return fmt.Errorf("failed to leave project: %v", err)
+		}
+	} else if err := executeAddSelfToProject(client, project.ID); err != nil {
+		return fmt.Errorf("failed to join project: %v", err)
+	}
+
+	switch {
+	case *simple:
+		fmt.Printf("%s\t%s\n", project.ID, command)
+	case command == "leave":
+		fmt.Printf("✅ Left project '%s'\n", project.Name)
+	default:
+		fmt.Printf("✅ Joined project '%s'\n", project.Name)
+	}
+	return nil
+}
+
+// runProjectUsersChange implements add, remove, set-level and set-role for one or many users
+func runProjectUsersChange(command string, args []string) error {
+	fs := flag.NewFlagSet("project-users "+command, flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required unless -company)")
+	users := fs.String("users", "", "Comma-separated user emails or IDs")
+	csvFile := fs.String("csv", "", "CSV file with email,level,role rows (header optional)")
+	level := fs.String("level", "", "Access level: OWNER, ADMIN, MEMBER, CLIENT, COMMENT_ONLY, VIEW_ONLY (add defaults to MEMBER)")
+	role := fs.String("role", "", "Custom project role (name or ID)")
+	company := fs.Bool("company", false, "remove/set-level: act on the company membership instead of the project")
+	confirm := fs.Bool("confirm", false, "Confirm removal (required for remove)")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	companyMode := *company && (command == "remove" || command == "set-level")
+	if *company && !companyMode {
+		return fmt.Errorf("-company is only supported by remove and set-level")
+	}
+	if (*users == "") == (*csvFile == "") || (*projectID == "" && !companyMode) {
+		fmt.Printf("Usage: go run . project-users %s -project PROJECT (-users USERS | -csv FILE) [flags]\n", command)
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nCSV format (one user per row, level and role optional):")
+		fmt.Println("  email,level,role")
+		fmt.Println("  ann@acme.com,CLIENT,Client Viewer")
+		fmt.Println("  bob@acme.com,COMMENT_ONLY,")
+		return fmt.Errorf("required flags missing")
+	}
+
+	var entries []ProjectUserEntry
+	if *csvFile != "" {
+		var err error
+		if entries, err = readProjectUserEntries(*csvFile); err != nil {
+			return err
+		}
+	} else {
+		for _, user := range strings.Split(*users, ",") {
+			if user = strings.TrimSpace(user); user != "" {
+				entries = append(entries, ProjectUserEntry{User: user})
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no users given")
+	}
+
+	// Flags are the defaults for CSV rows that leave level or role empty
+	for i := range entries {
+		if entries[i].Level == "" {
+			entries[i].Level = *level
+		}
+		if entries[i].Level == "" && command == "add" {
+			entries[i].Level = "MEMBER"
+		}
+		if entries[i].Level != "" {
+			normalized, err := parseAccessLevel(entries[i].Level)
+			if err != nil {
+				return fmt.Errorf("%s: %v", entries[i].User, err)
+			}
+			entries[i].Level = normalized
+		}
+		if entries[i].Role == "" {
+			entries[i].Role = *role
+		}
+		if command == "set-level" && entries[i].Level == "" {
+			return fmt.Errorf("%s: an access level is required (-level or the CSV level column)", entries[i].User)
+		}
+		if command == "set-role" && entries[i].Role == "" {
+			return fmt.Errorf("%s: a role is required (-role or the CSV role column)", entries[i].User)
+		}
+	}
+
+	if command == "remove" && !*confirm {
+		target := "project " + *projectID
+		if companyMode {
+			target = "the company and all of its projects"
+		}
+		fmt.Printf("⚠️  This will remove %d user(s) from %s\n", len(entries), target)
+		return fmt.Errorf("removal confirmation is required. Use -confirm flag to confirm removal")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+
+	if companyMode {
+		return applyCompanyUserChanges(client, command, entries, *simple)
+	}
+
+	client.SetProject(*projectID)
+	project, err := getCurrentProject(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+	client.SetProjectID(project.ID)
+
+	members, err := fetchProjectMembers(client, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project users: %v", err)
+	}
+	var roles []ProjectUserRole
+	for _, entry := range entries {
+		if entry.Role != "" && command != "remove" {
+			if roles, err = fetchProjectUserRoles(client, project.ID); err != nil {
+				return fmt.Errorf("failed to fetch project roles: %v", err)
+			}
+			break
+		}
+	}
+
+	if !*simple {
+		fmt.Printf("Project: %s\n\n", project.Name)
+	}
+	done, failed := 0, 0
+	for _, entry := range entries {
+		result, err := applyProjectUserEntry(client, project, members, roles, command, entry)
+		if err != nil {
+			failed++
+			if *simple {
+				fmt.Printf("%s\terror\t%v\n", entry.User, err)
+			} else {
+				fmt.Printf("❌ %s: %v\n", entry.User, err)
+			}
+			continue
+		}
+		done++
+		if *simple {
+			fmt.Printf("%s\t%s\n", entry.User, result)
+		} else {
+			fmt.Printf("✅ %s: %s\n", entry.User, result)
+		}
+	}
+
+	if !*simple {
+		fmt.Printf("\n%d of %d user(s) processed\n", done, len(entries))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d user(s) failed", failed)
+	}
+	return nil
+}
+
+// applyProjectUserEntry applies one add, remove, set-level or set-role entry and describes the result
+func applyProjectUserEntry(client *common.Client, project *EditedProject, members []ProjectMember, roles []ProjectUserRole, command string, entry ProjectUserEntry) (string, error) {
+	member := findProjectMember(members, entry.User)
+
+	roleID, roleName := "", ""
+	if entry.Role != "" && command != "remove" {
+		role, err := resolveProjectRole(roles, entry.Role)
+		if err != nil {
+			return "", err
+		}
+		roleID, roleName = role.ID, role.Name
+	}
+	describe := func(level string) string {
+		if roleName != "" {
+			return fmt.Sprintf("%s (%s)", level, roleName)
+		}
+		return level
+	}
+
+	if member == nil {
+		if command != "add" {
+			return "", fmt.Errorf("not a user of project %s", project.Name)
+		}
+		email := entry.User
+		if !strings.Contains(email, "@") {
+			user, err := findCompanyUser(client, entry.User)
+			if err != nil {
+				return "", fmt.Errorf("failed to look up user: %v", err)
+			}
+			if user == nil {
+				return "", fmt.Errorf("no company user with ID %s (use an email to invite someone new)", entry.User)
+			}
+			email = user.Email
+		}
+		if err := executeInviteToProject(client, email, project.ID, entry.Level, roleID); err != nil {
+			return "", fmt.Errorf("failed to add: %v", err)
+		}
+		return "added as " + describe(entry.Level), nil
+	}
+
+	switch command {
+	case "remove":
+		if err := executeRemoveProjectUser(client, project.ID, member.ID); err != nil {
+			return "", fmt.Errorf("failed to remove: %v", err)
+		}
+		return "removed", nil
+	case "set-role":
+		if entry.Level == "" {
+			entry.Level = member.AccessLevel
+		}
+	}
+
+	// add, set-level and set-role all update an existing member in place,
+	// keeping their current role unless a new one is given
+	if roleID == "" {
+		roleID = member.roleID()
+		if member.Role != nil {
+			roleName = member.Role.Name
+		}
+	}
+	if entry.Level == member.AccessLevel && roleID == member.roleID() {
+		return "unchanged, already " + describe(entry.Level), nil
+	}
+	if err := executeUpdateProjectAccessLevel(client, member.ID, entry.Level, roleID); err != nil {
+		return "", fmt.Errorf("failed to update: %v", err)
+	}
+	return fmt.Sprintf("%s → %s", member.AccessLevel, describe(entry.Level)), nil
+}
+
+// applyCompanyUserChanges removes users from, or changes their access level in, the company
+func applyCompanyUserChanges(client *common.Client, command string, entries []ProjectUserEntry, simple bool) error {
+	companyID := client.GetCompanyID()
+	done, failed := 0, 0
+	for _, entry := range entries {
+		result, err := func() (string, error) {
+			user, err := findCompanyUser(client, entry.User)
+			if err != nil {
+				return "", fmt.Errorf("failed to look up user: %v", err)
+			}
+			if user == nil {
+				return "", fmt.Errorf("not a user of company %s", companyID)
+			}
+			if command == "remove" {
+				if err := executeRemoveCompanyUser(client, companyID, user.ID); err != nil {
+					return "", fmt.Errorf("failed to remove: %v", err)
+				}
+				return "removed from the company", nil
+			}
+			if err := executeUpdateCompanyAccessLevel(client, user.ID, entry.Level); err != nil {
+				return "", fmt.Errorf("failed to update: %v", err)
+			}
+			return "company access level set to " + entry.Level, nil
+		}()
+		if err != nil {
+			failed++
+			if simple {
+				fmt.Printf("%s\terror\t%v\n", entry.User, err)
+			} else {
+				fmt.Printf("❌ %s: %v\n", entry.User, err)
+			}
+			continue
+		}
+		done++
+		if simple {
+			fmt.Printf("%s\t%s\n", entry.User, result)
+		} else {
+			fmt.Printf("✅ %s: %s\n", entry.User, result)
+		}
+	}
+
+	if !simple {
+		fmt.Printf("\n%d of %d user(s) processed\n", done, len(entries))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d user(s) failed", failed)
+	}
+	return nil
+}
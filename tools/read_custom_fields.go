@@ -34,6 +34,12 @@ func buildReadCustomFieldsQuery(projectID string, skip int, take int) string {
 				max
 				currency
 				prefix
+				formula
+				regionCode
+				countryCodes
+				startDate
+				endDate
+				timezone
 				createdAt
 				updatedAt
 				customFieldOptions {
@@ -159,6 +165,7 @@ func RunReadCustomFields(args []string) error {
 	simple := fs.Bool("simple", false, "Show only essential information for record creation")
 	examples := fs.Bool("examples", false, "Show example usage for create-record and update-record commands")
 	format := fs.String("format", "table", "Output format: table, json, csv")
+	explain := fs.Bool("explain", false, "Only show FORMULA fields, with their formulas written out using field names")
 	fs.Parse(args)
 
 	// Validate required parameters
@@ -176,6 +183,16 @@ func RunReadCustomFields(args []string) error {
 	client := NewClient(config)
 	client.SetProject(*projectID)
 
+	// Formulas can reference any field, so explaining them needs every page
+	if *explain {
+		fields, err := fetchProjectCustomFields(client, *projectID)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %v", err)
+		}
+		printFormulaExplanations(fields)
+		return nil
+	}
+
 	// Calculate skip value from page
 	skip := (*page - 1) * *pageSize
 	take := *pageSize
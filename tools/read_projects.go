@@ -3,6 +3,7 @@ package tools
 import (
 	"flag"
 	"fmt"
+	"strings"
 	
 	"demo-builder/common"
 )
@@ -18,7 +19,7 @@ type ProjectListResponse struct {
 }
 
 // Build query with pagination, search, and sorting
-func buildProjectQuery(companyID string, simple bool, skip int, take int, search string, showArchived bool, showTemplates bool, sortBy string) string {
+func buildProjectQuery(companyID string, simple bool, skip int, take int, search string, showArchived bool, showTemplates bool, sortBy string, folderID string) string {
 	fields := "id name"
 	if !simple {
 		fields = `id
@@ -46,6 +47,9 @@ func buildProjectQuery(companyID string, simple bool, skip int, take int, search
 	if !showTemplates {
 		filter += `, isTemplate: false`
 	}
+	if folderID != "" {
+		filter += fmt.Sprintf(`, folderId: "%s"`, folderID)
+	}
 
 	query := fmt.Sprintf(`query ProjectListQuery {
 		projectList(
@@ -86,6 +90,8 @@ func RunReadProjects(args []string) error {
 	all := fs.Bool("all", false, "Show all projects (including archived and templates)")
 	showArchived := fs.Bool("archived", false, "Include archived projects")
 	showTemplates := fs.Bool("templates", false, "Include template projects")
+	tree := fs.Bool("tree", false, "Show projects nested in their folders")
+	folder := fs.String("folder", "", "Only show projects in this folder (ID or title)")
 	
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
@@ -118,6 +124,25 @@ func RunReadProjects(args []string) error {
 	// Create client
 	client := common.NewClient(config)
 
+	// Resolve the folder filter
+	folderID := ""
+	if *folder != "" || *tree {
+		folders, err := fetchProjectFolders(client, client.GetCompanyID())
+		if err != nil {
+			return fmt.Errorf("failed to fetch folders: %w", err)
+		}
+		if *folder != "" {
+			match, err := resolveFolder(folders, *folder)
+			if err != nil {
+				return err
+			}
+			folderID = match.ID
+		}
+		if *tree {
+			return printProjectTree(client, folders, folderID, *search)
+		}
+	}
+
 	// Calculate skip value from page
 	skip := (*page - 1) * *pageSize
 	take := *pageSize
@@ -129,7 +154,7 @@ func RunReadProjects(args []string) error {
 	}
 
 	// Build and execute query
-	query := buildProjectQuery(client.GetCompanyID(), *simple, skip, take, *search, *showArchived, *showTemplates, *sortBy)
+	query := buildProjectQuery(client.GetCompanyID(), *simple, skip, take, *search, *showArchived, *showTemplates, *sortBy, folderID)
 
 	// Execute query
 	var response ProjectListResponse
@@ -145,6 +170,9 @@ func RunReadProjects(args []string) error {
 	if *search != "" {
 		fmt.Printf("Search: '%s'\n", *search)
 	}
+	if *folder != "" {
+		fmt.Printf("Folder: %s\n", *folder)
+	}
 	if *sortBy != "name_ASC" {
 		fmt.Printf("Sort: %s\n", *sortBy)
 	}
@@ -218,4 +246,39 @@ func RunReadProjects(args []string) error {
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// printProjectTree shows active projects nested in their folders, optionally below one folder
+func printProjectTree(client *common.Client, folders []ProjectFolder, folderID, search string) error {
+	projects, err := fetchPositionedProjects(client, client.GetCompanyID())
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	if search != "" {
+		var matched []PositionedProject
+		for _, project := range projects {
+			if strings.Contains(strings.ToLower(project.Name), strings.ToLower(search)) {
+				matched = append(matched, project)
+			}
+		}
+		projects = matched
+	}
+
+	fmt.Printf("\n=== Projects in %s ===\n", client.GetCompanyID())
+	if search != "" {
+		fmt.Printf("Search: '%s'\n", search)
+	}
+	fmt.Println()
+
+	if folderID != "" {
+		for _, folder := range folders {
+			if folder.ID == folderID {
+				fmt.Printf("📁 %s  (%s)\n", folder.Title, folder.ID)
+			}
+		}
+		printFolderTree(folders, projects, folderID, "   ")
+		return nil
+	}
+	printFolderTree(folders, projects, "", "")
+	return nil
+}
@@ -12,8 +12,9 @@ import (
 
 // SimpleCustomFieldValue represents a custom field value with simplified structure  
 type SimpleCustomFieldValue struct {
-	ID    string      `json:"id"`
-	Value interface{} `json:"value"`
+	ID            string             `json:"id"`
+	Value         interface{}        `json:"value"`
+	SelectedTodos []ReferencedRecord `json:"selectedTodos,omitempty"`
 }
 
 // RecordCustomFieldInfo holds field metadata for display
@@ -223,6 +224,15 @@ func displayRecordDetails(record DetailedRecord, simple bool, customFieldInfo ma
 					fieldDisplay = fmt.Sprintf("%s (%s) [%s]", info.Name, info.Type, cfv.ID)
 				}
 				
+				// REFERENCE fields list the records they point to
+				if len(cfv.SelectedTodos) > 0 {
+					fmt.Printf("- %s: %d record(s)\n", fieldDisplay, len(cfv.SelectedTodos))
+					for _, ref := range cfv.SelectedTodos {
+						fmt.Printf("    → %s [%s]\n", ref.Title, ref.ID)
+					}
+					continue
+				}
+
 				parsedValue := parseRecordCustomFieldValue(cfv.Value)
 				if parsedValue != nil {
 					fmt.Printf("- %s: %v\n", fieldDisplay, parsedValue)
@@ -318,6 +328,10 @@ func buildRecordDetailQuery(simple bool, recordID string) string {
 				customFields {
 					id
 					value
+					selectedTodos {
+						id
+						title
+					}
 				}
 			}
 		}
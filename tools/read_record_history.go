@@ -0,0 +1,624 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"demo-builder/common"
+)
+
+// HistoryUser is the actor attached to an activity entry
+type HistoryUser struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+}
+
+// HistoryEntry is a single action or comment from todoActivity
+type HistoryEntry struct {
+	Typename    string       `json:"__typename"`
+	ID          string       `json:"id"`
+	Type        string       `json:"type,omitempty"`
+	OldValue    *string      `json:"oldValue,omitempty"`
+	NewValue    *string      `json:"newValue,omitempty"`
+	Automated   bool         `json:"automated,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	CreatedAt   string       `json:"createdAt"`
+	User        *HistoryUser `json:"user,omitempty"`
+	AffectedBy  *HistoryUser `json:"affectedBy,omitempty"`
+	CustomField *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"customField,omitempty"`
+}
+
+// TodoActivityResponse represents the response from the todoActivity query
+type TodoActivityResponse struct {
+	TodoActivity struct {
+		Items      []HistoryEntry `json:"items"`
+		TotalCount int            `json:"totalCount"`
+	} `json:"todoActivity"`
+}
+
+// RecordSnapshot holds the field values of a record at a point in time
+type RecordSnapshot struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	DuedAt       string            `json:"duedAt"`
+	Done         bool              `json:"done"`
+	List         string            `json:"list"`
+	Tags         []string          `json:"tags"`
+	Assignees    []string          `json:"assignees"`
+	CustomFields map[string]string `json:"customFields"`
+}
+
+// RecordHistoryTarget is the current record state plus the IDs needed to query its activity
+type RecordHistoryTarget struct {
+	Snapshot  *RecordSnapshot
+	ProjectID string
+	CompanyID string
+	Timezone  string
+	CreatedAt string
+}
+
+// historyTypeGroups maps friendly -type names to TodoActionType values
+var historyTypeGroups = map[string][]string{
+	"title":         {"UPDATE_TITLE"},
+	"description":   {"UPDATE_DESCRIPTION"},
+	"assignees":     {"ASSIGN_AN_ASSIGNEE", "UNASSIGN_AN_ASSIGNEE"},
+	"due-date":      {"SET_DUE_DATE", "CHANGE_DUE_DATE", "REMOVE_DUE_DATE"},
+	"status":        {"MARK_AS_COMPLETE", "MARK_AS_INCOMPLETE"},
+	"list":          {"CHANGE_TODO_LIST", "MOVE_TODO"},
+	"tags":          {"ADD_TAG", "REMOVE_TAG", "UPDATE_TAG", "DELETE_TAG"},
+	"checklists":    {"CREATE_CHECKLIST", "UPDATE_CHECKLIST", "DELETE_CHECKLIST", "CREATE_CHECKLIST_ITEM", "UPDATE_CHECKLIST_ITEM", "DELETE_CHECKLIST_ITEM", "MARK_CHECKLIST_ITEM_AS_DONE", "MARK_CHECKLIST_ITEM_AS_UNDONE", "SET_CHECKLIST_ITEM_DUE_DATE", "ASSIGN_CHECKLIST_ITEM", "UNASSIGN_CHECKLIST_ITEM"},
+	"custom-fields": {"SET_CUSTOM_FIELD"},
+	"dependencies":  {"CREATE_DEPENDENCY", "DELETE_DEPENDENCY", "UPDATE_DEPENDENCY"},
+	"comments":      {"COMMENT"},
+}
+
+// parseHistoryTypes converts the -type flag into a set of action types
+func parseHistoryTypes(value string) (map[string]bool, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	types := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if group, ok := historyTypeGroups[strings.ToLower(part)]; ok {
+			for _, t := range group {
+				types[t] = true
+			}
+			continue
+		}
+		upper := strings.ToUpper(part)
+		known := false
+		for _, group := range historyTypeGroups {
+			for _, t := range group {
+				if t == upper {
+					known = true
+				}
+			}
+		}
+		if !known && upper != "REPEAT_TODO" && upper != "COPY_TODO" && upper != "SEND_EMAIL" {
+			return nil, fmt.Errorf("unknown -type '%s' (use a TodoActionType like SET_CUSTOM_FIELD or a group: title, description, assignees, due-date, status, list, tags, checklists, custom-fields, dependencies, comments)", part)
+		}
+		types[upper] = true
+	}
+	return types, nil
+}
+
+// fetchRecordHistory loads every activity entry for a record in chronological order
+func fetchRecordHistory(client *common.Client, companyID, projectID, recordID string, includeComments bool) ([]HistoryEntry, error) {
+	query := `
+		query TodoActivity($filter: TodoActivityFilter!, $limit: Int, $skip: Int) {
+			todoActivity(filter: $filter, orderBy: { createdAt: ASC, updatedAt: ASC }, limit: $limit, skip: $skip) {
+				totalCount
+				items {
+					__typename
+					... on TodoAction {
+						id
+						type
+						oldValue
+						newValue
+						automated
+						createdAt
+						user { id fullName email }
+						affectedBy { id fullName email }
+						customField { id name type }
+					}
+					... on Comment {
+						id
+						text
+						createdAt
+						user { id fullName email }
+					}
+				}
+			}
+		}
+	`
+
+	activityType := "activities"
+	if includeComments {
+		activityType = "everything"
+	}
+
+	const pageSize = 100
+	var entries []HistoryEntry
+	for skip := 0; ; skip += pageSize {
+		variables := map[string]interface{}{
+			"filter": map[string]interface{}{
+				"companyId": companyID,
+				"projectId": projectID,
+				"todoId":    recordID,
+				"type":      activityType,
+			},
+			"limit": pageSize,
+			"skip":  skip,
+		}
+
+		var response TodoActivityResponse
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, response.TodoActivity.Items...)
+		if len(response.TodoActivity.Items) < pageSize || len(entries) >= response.TodoActivity.TotalCount {
+			break
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt < entries[j].CreatedAt
+	})
+	return entries, nil
+}
+
+// historyEntryType returns the action type, or COMMENT for comments
+func historyEntryType(entry HistoryEntry) string {
+	if entry.Typename == "Comment" {
+		return "COMMENT"
+	}
+	return entry.Type
+}
+
+// historyActor returns a display name for whoever made the change
+func historyActor(entry HistoryEntry) string {
+	if entry.User != nil && entry.User.FullName != "" {
+		return entry.User.FullName
+	}
+	if entry.User != nil && entry.User.Email != "" {
+		return entry.User.Email
+	}
+	if entry.Automated {
+		return "Automation"
+	}
+	return "Unknown"
+}
+
+// historyValue formats an optional old/new value
+func historyValue(value *string) string {
+	if value == nil || *value == "" {
+		return "(empty)"
+	}
+	return fmt.Sprintf("%q", common.TruncateString(*value, 80))
+}
+
+// describeHistoryEntry renders the change part of a history line
+func describeHistoryEntry(entry HistoryEntry) string {
+	if entry.Typename == "Comment" {
+		return fmt.Sprintf("commented: %q", common.TruncateString(strings.TrimSpace(entry.Text), 100))
+	}
+
+	subject := ""
+	if entry.CustomField != nil {
+		subject = entry.CustomField.Name + ": "
+	}
+	if entry.AffectedBy != nil && entry.AffectedBy.FullName != "" {
+		subject = entry.AffectedBy.FullName + ": "
+	}
+
+	switch {
+	case entry.OldValue != nil && entry.NewValue != nil:
+		return fmt.Sprintf("%s%s → %s", subject, historyValue(entry.OldValue), historyValue(entry.NewValue))
+	case entry.NewValue != nil:
+		return fmt.Sprintf("%s%s", subject, historyValue(entry.NewValue))
+	case entry.OldValue != nil:
+		return fmt.Sprintf("%swas %s", subject, historyValue(entry.OldValue))
+	}
+	return strings.TrimSuffix(subject, ": ")
+}
+
+// removeValue removes the first matching value from a slice
+func removeValue(values []string, value string) []string {
+	for i, v := range values {
+		if strings.EqualFold(v, value) {
+			return append(values[:i:i], values[i+1:]...)
+		}
+	}
+	return values
+}
+
+// undoHistoryEntry reverts the effect of a single action on a snapshot
+func undoHistoryEntry(snapshot *RecordSnapshot, entry HistoryEntry) {
+	oldValue, newValue := "", ""
+	if entry.OldValue != nil {
+		oldValue = *entry.OldValue
+	}
+	if entry.NewValue != nil {
+		newValue = *entry.NewValue
+	}
+
+	switch entry.Type {
+	case "UPDATE_TITLE":
+		snapshot.Title = oldValue
+	case "UPDATE_DESCRIPTION":
+		snapshot.Description = oldValue
+	case "SET_DUE_DATE", "CHANGE_DUE_DATE", "REMOVE_DUE_DATE":
+		snapshot.DuedAt = oldValue
+	case "MARK_AS_COMPLETE":
+		snapshot.Done = false
+	case "MARK_AS_INCOMPLETE":
+		snapshot.Done = true
+	case "CHANGE_TODO_LIST", "MOVE_TODO":
+		if oldValue != "" {
+			snapshot.List = oldValue
+		}
+	case "ADD_TAG":
+		snapshot.Tags = removeValue(snapshot.Tags, newValue)
+	case "REMOVE_TAG":
+		snapshot.Tags = append(snapshot.Tags, oldValue)
+	case "ASSIGN_AN_ASSIGNEE":
+		name := newValue
+		if entry.AffectedBy != nil && entry.AffectedBy.FullName != "" {
+			name = entry.AffectedBy.FullName
+		}
+		snapshot.Assignees = removeValue(snapshot.Assignees, name)
+	case "UNASSIGN_AN_ASSIGNEE":
+		name := oldValue
+		if entry.AffectedBy != nil && entry.AffectedBy.FullName != "" {
+			name = entry.AffectedBy.FullName
+		}
+		snapshot.Assignees = append(snapshot.Assignees, name)
+	case "SET_CUSTOM_FIELD":
+		if entry.CustomField != nil {
+			snapshot.CustomFields[entry.CustomField.Name] = oldValue
+		}
+	}
+}
+
+// fetchRecordHistoryTarget loads the current state of a record along with its project and company IDs
+func fetchRecordHistoryTarget(client *common.Client, recordID string) (*RecordHistoryTarget, error) {
+	query := `
+		query GetRecordState($id: String!) {
+			todo(id: $id) {
+				id
+				title
+				text
+				duedAt
+				done
+				timezone
+				createdAt
+				users { fullName }
+				tags { title }
+				todoList {
+					title
+					project {
+						id
+						company { id }
+					}
+				}
+				customFields {
+					id
+					name
+					value
+				}
+			}
+		}
+	`
+
+	var response struct {
+		Todo struct {
+			ID        string `json:"id"`
+			Title     string `json:"title"`
+			Text      string `json:"text"`
+			DuedAt    string `json:"duedAt"`
+			Done      bool   `json:"done"`
+			Timezone  string `json:"timezone"`
+			CreatedAt string `json:"createdAt"`
+			Users     []struct {
+				FullName string `json:"fullName"`
+			} `json:"users"`
+			Tags []struct {
+				Title string `json:"title"`
+			} `json:"tags"`
+			TodoList struct {
+				Title   string `json:"title"`
+				Project struct {
+					ID      string `json:"id"`
+					Company struct {
+						ID string `json:"id"`
+					} `json:"company"`
+				} `json:"project"`
+			} `json:"todoList"`
+			CustomFields []struct {
+				ID    string      `json:"id"`
+				Name  string      `json:"name"`
+				Value interface{} `json:"value"`
+			} `json:"customFields"`
+		} `json:"todo"`
+	}
+
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"id": recordID}, &response); err != nil {
+		return nil, err
+	}
+	todo := response.Todo
+	if todo.ID == "" {
+		return nil, fmt.Errorf("record not found: %s", recordID)
+	}
+
+	snapshot := &RecordSnapshot{
+		Title:        todo.Title,
+		Description:  todo.Text,
+		DuedAt:       todo.DuedAt,
+		Done:         todo.Done,
+		List:         todo.TodoList.Title,
+		CustomFields: make(map[string]string),
+	}
+	for _, user := range todo.Users {
+		snapshot.Assignees = append(snapshot.Assignees, user.FullName)
+	}
+	for _, tag := range todo.Tags {
+		snapshot.Tags = append(snapshot.Tags, tag.Title)
+	}
+	for _, cf := range todo.CustomFields {
+		value := parseRecordCustomFieldValue(cf.Value)
+		if value == nil {
+			snapshot.CustomFields[cf.Name] = ""
+		} else {
+			snapshot.CustomFields[cf.Name] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	return &RecordHistoryTarget{
+		Snapshot:  snapshot,
+		ProjectID: todo.TodoList.Project.ID,
+		CompanyID: todo.TodoList.Project.Company.ID,
+		Timezone:  todo.Timezone,
+		CreatedAt: todo.CreatedAt,
+	}, nil
+}
+
+// displayRecordSnapshot prints a reconstructed record state
+func displayRecordSnapshot(snapshot *RecordSnapshot) {
+	fmt.Printf("Title: %s\n", snapshot.Title)
+	fmt.Printf("List: %s\n", snapshot.List)
+	fmt.Printf("Done: %t\n", snapshot.Done)
+	if snapshot.DuedAt != "" {
+		fmt.Printf("Due: %s\n", snapshot.DuedAt)
+	}
+	if len(snapshot.Assignees) > 0 {
+		fmt.Printf("Assignees: %s\n", strings.Join(snapshot.Assignees, ", "))
+	}
+	if len(snapshot.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(snapshot.Tags, ", "))
+	}
+	if snapshot.Description != "" {
+		fmt.Printf("Description: %s\n", common.TruncateString(snapshot.Description, 200))
+	}
+	if len(snapshot.CustomFields) > 0 {
+		names := make([]string, 0, len(snapshot.CustomFields))
+		for name := range snapshot.CustomFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("\nCustom Fields:\n")
+		for _, name := range names {
+			value := snapshot.CustomFields[name]
+			if value == "" {
+				value = "(empty)"
+			}
+			fmt.Printf("  %s: %s\n", name, value)
+		}
+	}
+}
+
+func RunReadRecordHistory(args []string) error {
+	fs := flag.NewFlagSet("read-record-history", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID (required)")
+	projectID := fs.String("project", "", "Project ID or slug (optional - detected from the record)")
+	types := fs.String("type", "", "Comma-separated action types or groups (title, description, assignees, due-date, status, list, tags, checklists, custom-fields, dependencies, comments)")
+	field := fs.String("field", "", "Only show changes to this custom field (name or ID)")
+	actor := fs.String("user", "", "Only show changes made by this user (name, email or ID)")
+	since := fs.String("since", "", "Only show activity on or after this date: "+common.DateExamples)
+	until := fs.String("until", "", "Only show activity on or before this date")
+	asOf := fs.String("as-of", "", "Reconstruct the record's field values at this point in time")
+	includeComments := fs.Bool("comments", false, "Include comments in the log")
+	limit := fs.Int("limit", 0, "Show only the most recent N entries (0 = all)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *recordID == "" {
+		fmt.Println("Error: -record flag is required")
+		fmt.Println("\nUsage:")
+		fmt.Println("  go run . read-record-history -record RECORD_ID [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Full history of a record")
+		fmt.Println("  go run . read-record-history -record rec123")
+		fmt.Println("")
+		fmt.Println("  # Who changed the deal value in the last month?")
+		fmt.Println("  go run . read-record-history -record rec123 -type custom-fields -field \"Deal Value\" -since -1mo")
+		fmt.Println("")
+		fmt.Println("  # What did the record look like at the start of the quarter?")
+		fmt.Println("  go run . read-record-history -record rec123 -as-of \"start of quarter\"")
+		return fmt.Errorf("required flags missing")
+	}
+
+	typeFilter, err := parseHistoryTypes(*types)
+	if err != nil {
+		return err
+	}
+	if typeFilter != nil && typeFilter["COMMENT"] {
+		*includeComments = true
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	client := common.NewClient(config)
+	if *projectID != "" {
+		client.SetProject(*projectID)
+	}
+
+	target, err := fetchRecordHistoryTarget(client, *recordID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record: %v", err)
+	}
+	if *projectID == "" {
+		client.SetProjectID(target.ProjectID)
+	}
+	companyID := target.CompanyID
+	if companyID == "" {
+		companyID = client.GetCompanyID()
+	}
+	snapshot, timezone := target.Snapshot, target.Timezone
+	loc := common.LoadTimezone(timezone)
+
+	var sinceTime, untilTime, asOfTime time.Time
+	if *since != "" {
+		if sinceTime, err = common.ParseDate(*since, timezone); err != nil {
+			return fmt.Errorf("invalid -since: %v", err)
+		}
+	}
+	if *until != "" {
+		if untilTime, err = common.ParseDateRangeEnd(*until, timezone); err != nil {
+			return fmt.Errorf("invalid -until: %v", err)
+		}
+	}
+	if *asOf != "" {
+		if asOfTime, err = common.ParseDate(*asOf, timezone); err != nil {
+			return fmt.Errorf("invalid -as-of: %v", err)
+		}
+	}
+
+	entries, err := fetchRecordHistory(client, companyID, target.ProjectID, *recordID, *includeComments)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record history: %v", err)
+	}
+
+	// As-of mode: walk back from the current state, undoing newer actions
+	if *asOf != "" {
+		if created, err := time.Parse(time.RFC3339, target.CreatedAt); err == nil && created.After(asOfTime) {
+			fmt.Printf("Record %s did not exist yet at %s (created %s)\n", *recordID, asOfTime.Format("2006-01-02 15:04 MST"), created.In(loc).Format("2006-01-02 15:04 MST"))
+			return nil
+		}
+
+		undone := 0
+		for i := len(entries) - 1; i >= 0; i-- {
+			entryTime, err := time.Parse(time.RFC3339, entries[i].CreatedAt)
+			if err != nil || !entryTime.After(asOfTime) {
+				break
+			}
+			if entries[i].Typename == "Comment" {
+				continue
+			}
+			undoHistoryEntry(snapshot, entries[i])
+			undone++
+		}
+
+		if *jsonOutput {
+			output, err := json.MarshalIndent(snapshot, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode JSON: %v", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		fmt.Printf("\n=== Record %s as of %s ===\n", *recordID, asOfTime.Format("2006-01-02 15:04 MST"))
+		fmt.Printf("(reconstructed by reverting %d later change(s))\n\n", undone)
+		displayRecordSnapshot(snapshot)
+		return nil
+	}
+
+	// Apply filters
+	var filtered []HistoryEntry
+	for _, entry := range entries {
+		if typeFilter != nil && !typeFilter[historyEntryType(entry)] {
+			continue
+		}
+		if *field != "" && (entry.CustomField == nil || (entry.CustomField.ID != *field && !strings.EqualFold(entry.CustomField.Name, *field))) {
+			continue
+		}
+		if *actor != "" {
+			if entry.User == nil {
+				continue
+			}
+			needle := strings.ToLower(*actor)
+			if entry.User.ID != *actor && !strings.Contains(strings.ToLower(entry.User.FullName), needle) && !strings.Contains(strings.ToLower(entry.User.Email), needle) {
+				continue
+			}
+		}
+		entryTime, err := time.Parse(time.RFC3339, entry.CreatedAt)
+		if err == nil {
+			if !sinceTime.IsZero() && entryTime.Before(sinceTime) {
+				continue
+			}
+			if !untilTime.IsZero() && entryTime.After(untilTime) {
+				continue
+			}
+		}
+		filtered = append(filtered, entry)
+	}
+
+	if *limit > 0 && len(filtered) > *limit {
+		filtered = filtered[len(filtered)-*limit:]
+	}
+
+	if *jsonOutput {
+		output, err := json.MarshalIndent(filtered, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if !*simple {
+		fmt.Printf("\n=== History for '%s' ===\n", snapshot.Title)
+		fmt.Printf("Record ID: %s\n", *recordID)
+		fmt.Printf("Showing: %d of %d entries\n\n", len(filtered), len(entries))
+	}
+
+	if len(filtered) == 0 {
+		fmt.Println("No matching activity found.")
+		return nil
+	}
+
+	for _, entry := range filtered {
+		timestamp := entry.CreatedAt
+		if t, err := time.Parse(time.RFC3339, entry.CreatedAt); err == nil {
+			timestamp = t.In(loc).Format("2006-01-02 15:04")
+		}
+		if *simple {
+			fmt.Printf("%s %s %s %s\n", timestamp, historyActor(entry), historyEntryType(entry), describeHistoryEntry(entry))
+			continue
+		}
+		fmt.Printf("%s  %-20s  %-26s  %s\n", timestamp, common.TruncateString(historyActor(entry), 17), historyEntryType(entry), describeHistoryEntry(entry))
+	}
+
+	return nil
+}
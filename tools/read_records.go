@@ -86,6 +86,9 @@ func RunReadRecords(args []string) error {
 	tagIDs := fs.String("tags", "", "Filter by tag IDs (comma-separated)")
 	done := fs.String("done", "", "Filter by completion status (true/false)")
 	archived := fs.String("archived", "", "Filter by archived status (true/false)")
+	dueFrom := fs.String("due-from", "", "Only records due on or after this date: "+common.DateExamples)
+	dueTo := fs.String("due-to", "", "Only records due on or before this date (whole day if no time is given)")
+	timezone := fs.String("timezone", "", "Timezone for relative dates, e.g. Europe/London (default: local timezone)")
 	orderBy := fs.String("order", "updatedAt_DESC", "Order by field (position_ASC, position_DESC, title_ASC, title_DESC, createdAt_ASC, createdAt_DESC, updatedAt_ASC, updatedAt_DESC, duedAt_ASC, duedAt_DESC)")
 	limit := fs.Int("limit", 20, "Maximum number of records to return")
 	skip := fs.Int("skip", 0, "Number of records to skip (for pagination)")
@@ -121,6 +124,13 @@ func RunReadRecords(args []string) error {
 		fmt.Println("  # Show numerical statistics for custom fields")
 		fmt.Println("  go run . read-records -project PROJECT_ID -stats")
 		fmt.Println()
+		fmt.Println("Date Range Examples:")
+		fmt.Println("  # Records due this week")
+		fmt.Println("  go run . read-records -project PROJECT_ID -due-from \"start of week\" -due-to \"end of week\"")
+		fmt.Println()
+		fmt.Println("  # Records due in the next 14 days")
+		fmt.Println("  go run . read-records -project PROJECT_ID -due-from today -due-to +14d")
+		fmt.Println()
 		fmt.Println("Operators: EQ, NE, GT, GTE, LT, LTE, IN, NIN, CONTAINS, IS, NOT")
 		return nil
 	}
@@ -200,6 +210,20 @@ func RunReadRecords(args []string) error {
 			filter["archived"] = false
 		}
 	}
+	if *dueFrom != "" {
+		dueStart, err := common.ParseDate(*dueFrom, *timezone)
+		if err != nil {
+			return fmt.Errorf("invalid -due-from: %v", err)
+		}
+		filter["dueStart"] = common.FormatAPIDate(dueStart)
+	}
+	if *dueTo != "" {
+		dueEnd, err := common.ParseDateRangeEnd(*dueTo, *timezone)
+		if err != nil {
+			return fmt.Errorf("invalid -due-to: %v", err)
+		}
+		filter["dueEnd"] = common.FormatAPIDate(dueEnd)
+	}
 
 	// Build sort array based on orderBy string
 	var sort []string
@@ -270,6 +294,12 @@ func RunReadRecords(args []string) error {
 	if *tagIDs != "" {
 		fmt.Printf("Tag IDs: %s\n", *tagIDs)
 	}
+	if *dueFrom != "" {
+		fmt.Printf("Due From: %s (%s)\n", *dueFrom, filter["dueStart"])
+	}
+	if *dueTo != "" {
+		fmt.Printf("Due To: %s (%s)\n", *dueTo, filter["dueEnd"])
+	}
 	if *customFieldFilter != "" {
 		fmt.Printf("Custom Field Filter: %s\n", *customFieldFilter)
 		if clientSideFilter != nil {
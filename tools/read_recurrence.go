@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"time"
+
+	"demo-builder/common"
+)
+
+// StoredRecurrence is the shape of the repeating JSON stored on a record
+type StoredRecurrence struct {
+	Type     string                      `json:"type"`
+	Fields   []string                    `json:"fields"`
+	From     string                      `json:"from"`
+	Interval *RepeatingTodoIntervalInput `json:"interval"`
+	End      *RepeatingTodoEndInput      `json:"end"`
+}
+
+// RunReadRecurrence shows the repeat rule of a record and its upcoming occurrences
+func RunReadRecurrence(args []string) error {
+	fs := flag.NewFlagSet("read-recurrence", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID (required)")
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	preview := fs.Int("preview", 5, "Number of upcoming occurrences to show")
+	raw := fs.Bool("raw", false, "Also print the raw recurrence JSON")
+	fs.Parse(args)
+
+	if *recordID == "" || *projectID == "" {
+		fmt.Println("Error: -record and -project flags are required")
+		fmt.Println("\nUsage:")
+		fmt.Println("  go run . read-recurrence -record RECORD_ID -project PROJECT_ID [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	record, err := fetchRecurrenceRecord(client, *recordID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record: %v", err)
+	}
+
+	fmt.Printf("\n=== Recurrence for '%s' ===\n", record.Title)
+	fmt.Printf("Record ID: %s\n", record.ID)
+	if record.TodoList.ID != "" {
+		fmt.Printf("List: %s (%s)\n", record.TodoList.Title, record.TodoList.ID)
+	}
+
+	if !record.IsRepeating || record.Repeating == nil {
+		fmt.Println("Repeating: No")
+		fmt.Printf("\nMake it repeat with:\n")
+		fmt.Printf("  go run . set-recurrence -record %s -project %s -every \"week\"\n", record.ID, *projectID)
+		return nil
+	}
+
+	fmt.Println("Repeating: Yes")
+
+	rawJSON, err := json.Marshal(record.Repeating)
+	if err != nil {
+		return fmt.Errorf("failed to read recurrence data: %v", err)
+	}
+
+	var stored StoredRecurrence
+	if err := json.Unmarshal(rawJSON, &stored); err != nil || stored.Type == "" {
+		// Unknown shape - show what the API returned
+		pretty, _ := json.MarshalIndent(record.Repeating, "", "  ")
+		fmt.Printf("Rule:\n%s\n", pretty)
+		return nil
+	}
+
+	loc := common.LoadTimezone(record.Timezone)
+
+	fmt.Printf("Schedule: %s\n", describeRecurrence(stored.Type, stored.Interval))
+	fromTime, fromErr := time.Parse(time.RFC3339, stored.From)
+	if fromErr == nil {
+		fromTime = fromTime.In(loc)
+		fmt.Printf("From: %s\n", fromTime.Format("Mon 2006-01-02 15:04 MST"))
+	}
+	if stored.End != nil {
+		switch stored.End.Type {
+		case "ON":
+			fmt.Printf("Until: %s\n", stored.End.On)
+		case "AFTER":
+			fmt.Printf("Ends after: %d occurrence(s)\n", stored.End.After)
+		default:
+			fmt.Printf("Ends: never\n")
+		}
+	}
+	if len(stored.Fields) > 0 {
+		fmt.Printf("Copies: %v\n", stored.Fields)
+	}
+
+	if *preview > 0 && fromErr == nil {
+		// Only show occurrences that are still in the future
+		upcoming := recurrenceOccurrences(stored.Type, stored.Interval, fromTime, time.Now(), stored.End, *preview)
+		fmt.Printf("\nNext %d occurrence(s):\n", len(upcoming))
+		printOccurrencePreview(upcoming)
+	}
+
+	if *raw {
+		pretty, _ := json.MarshalIndent(record.Repeating, "", "  ")
+		fmt.Printf("\nRaw:\n%s\n", pretty)
+	}
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// RecordLink is a REFERENCE link between two records
+type RecordLink struct {
+	FieldID   string
+	FieldName string
+	Record    ReferencedRecord
+}
+
+// referencingRecord is the part of a record needed to follow its REFERENCE links
+type referencingRecord struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	TodoList struct {
+		Title   string `json:"title"`
+		Project struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"project"`
+	} `json:"todoList"`
+	CustomFields []struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		Type          string `json:"type"`
+		SelectedTodos []struct {
+			ID       string `json:"id"`
+			Title    string `json:"title"`
+			TodoList struct {
+				Title   string `json:"title"`
+				Project struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"project"`
+			} `json:"todoList"`
+		} `json:"selectedTodos"`
+	} `json:"customFields"`
+}
+
+// referenceSelection is the record selection used to follow REFERENCE links
+const referenceSelection = `
+	id
+	title
+	todoList {
+		title
+		project {
+			id
+			name
+		}
+	}
+	customFields {
+		id
+		name
+		type
+		selectedTodos {
+			id
+			title
+			todoList {
+				title
+				project {
+					id
+					name
+				}
+			}
+		}
+	}
+`
+
+// fetchReferencingRecord fetches a record with the records its REFERENCE fields point to
+func fetchReferencingRecord(client *common.Client, recordID string) (*referencingRecord, error) {
+	query := fmt.Sprintf(`
+		query RecordReferences($id: String!) {
+			todo(id: $id) {
+				%s
+			}
+		}
+	`, referenceSelection)
+
+	var response struct {
+		Todo referencingRecord `json:"todo"`
+	}
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"id": recordID}, &response); err != nil {
+		return nil, err
+	}
+	if response.Todo.ID == "" {
+		return nil, fmt.Errorf("record not found: %s", recordID)
+	}
+	return &response.Todo, nil
+}
+
+// outboundLinks returns the records a record points to through its REFERENCE fields
+func outboundLinks(record *referencingRecord) []RecordLink {
+	var links []RecordLink
+	for _, field := range record.CustomFields {
+		for _, todo := range field.SelectedTodos {
+			linked := ReferencedRecord{ID: todo.ID, Title: todo.Title, TodoListTitle: todo.TodoList.Title}
+			linked.Project = &struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			}{ID: todo.TodoList.Project.ID, Name: todo.TodoList.Project.Name}
+			links = append(links, RecordLink{FieldID: field.ID, FieldName: field.Name, Record: linked})
+		}
+	}
+	return links
+}
+
+// inboundLinks returns the records pointing to a record through REFERENCE fields.
+// Only fields whose reference candidates include the record are scanned, optionally
+// limited to the given projects.
+func inboundLinks(client *common.Client, record *referencingRecord, projects []string) ([]RecordLink, error) {
+	referenceFields, err := fetchReferenceFields(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reference fields: %v", err)
+	}
+
+	// Group the fields that can point to the record by the project they belong to
+	fieldsByProject := make(map[string]map[string]string)
+	var projectOrder []string
+	for _, field := range referenceFields {
+		if field.ReferenceProject == nil || field.ReferenceProject.ID != record.TodoList.Project.ID {
+			continue
+		}
+		if len(projects) > 0 && !containsFold(projects, field.Project.ID) && !containsFold(projects, field.Project.Name) {
+			continue
+		}
+		candidate, err := isReferenceCandidateID(client, field.ID, record.ID, record.Title)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check '%s': %v", field.Name, err)
+		}
+		if !candidate {
+			continue
+		}
+		if fieldsByProject[field.Project.ID] == nil {
+			fieldsByProject[field.Project.ID] = make(map[string]string)
+			projectOrder = append(projectOrder, field.Project.ID)
+		}
+		fieldsByProject[field.Project.ID][field.ID] = field.Name
+	}
+
+	var links []RecordLink
+	for _, projectID := range projectOrder {
+		client.SetProjectID(projectID)
+		lists, err := fetchProjectLists(client, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch lists: %v", err)
+		}
+		for _, list := range lists {
+			todos, err := fetchListTodos[referencingRecord](client, list.ID, referenceSelection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch records of '%s': %v", list.Title, err)
+			}
+			for i := range todos {
+				todo := &todos[i]
+				for _, link := range outboundLinks(todo) {
+					if link.Record.ID != record.ID {
+						continue
+					}
+					if _, ok := fieldsByProject[projectID][link.FieldID]; !ok {
+						continue
+					}
+					source := ReferencedRecord{ID: todo.ID, Title: todo.Title, TodoListTitle: todo.TodoList.Title}
+					source.Project = &struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					}{ID: todo.TodoList.Project.ID, Name: todo.TodoList.Project.Name}
+					links = append(links, RecordLink{FieldID: link.FieldID, FieldName: link.FieldName, Record: source})
+				}
+			}
+		}
+	}
+	return links, nil
+}
+
+// printRecordLinks prints each link with the field it goes through
+func printRecordLinks(links []RecordLink, arrow string, simple bool) {
+	for _, link := range links {
+		project := "-"
+		if link.Record.Project != nil {
+			project = link.Record.Project.Name
+		}
+		if simple {
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", arrow, link.FieldName, link.Record.ID, link.Record.Title, project)
+			continue
+		}
+		fmt.Printf("  %s %s [%s]\n", arrow, link.Record.Title, link.Record.ID)
+		fmt.Printf("     Field: %s | List: %s | Project: %s\n", link.FieldName, link.Record.TodoListTitle, project)
+	}
+}
+
+// RunReadReferences lists the records a record points to and the records pointing to it
+func RunReadReferences(args []string) error {
+	fs := flag.NewFlagSet("read-references", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID (or pass it as the first argument)")
+	noInbound := fs.Bool("no-inbound", false, "Only list outbound references (skips scanning referencing projects)")
+	projects := fs.String("projects", "", "Comma-separated project IDs or names to scan for inbound references (default: all)")
+	simple := fs.Bool("simple", false, "Simple output format (one tab-separated line per link)")
+
+	// Allow the record before or after the flags
+	fs.Parse(args)
+	if fs.NArg() > 0 && *recordID == "" {
+		*recordID = fs.Arg(0)
+		fs.Parse(fs.Args()[1:])
+	}
+
+	if *recordID == "" {
+		fmt.Println("Usage: go run . read-references <record-id> [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Records this record points to and records pointing to it")
+		fmt.Println("  go run . read-references RECORD_ID")
+		fmt.Println("")
+		fmt.Println("  # Outbound references only")
+		fmt.Println("  go run . read-references RECORD_ID -no-inbound")
+		fmt.Println("")
+		fmt.Println("  # Only look for inbound references in two projects")
+		fmt.Println("  go run . read-references RECORD_ID -projects \"Deals,Support\" -simple")
+		return fmt.Errorf("required flags missing")
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+
+	record, err := fetchReferencingRecord(client, *recordID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record: %v", err)
+	}
+	client.SetProjectID(record.TodoList.Project.ID)
+
+	outbound := outboundLinks(record)
+
+	var inbound []RecordLink
+	if !*noInbound {
+		var projectList []string
+		if *projects != "" {
+			for _, project := range strings.Split(*projects, ",") {
+				projectList = append(projectList, strings.TrimSpace(project))
+			}
+		}
+		if inbound, err = inboundLinks(client, record, projectList); err != nil {
+			return err
+		}
+	}
+
+	if *simple {
+		printRecordLinks(outbound, "out", true)
+		printRecordLinks(inbound, "in", true)
+		return nil
+	}
+
+	fmt.Printf("=== References of '%s' [%s] ===\n", record.Title, record.ID)
+	fmt.Printf("Project: %s | List: %s\n", record.TodoList.Project.Name, record.TodoList.Title)
+
+	fmt.Printf("\nOutbound (%d):\n", len(outbound))
+	if len(outbound) == 0 {
+		fmt.Println("  (none)")
+	}
+	printRecordLinks(outbound, "→", false)
+
+	if *noInbound {
+		return nil
+	}
+	fmt.Printf("\nInbound (%d):\n", len(inbound))
+	if len(inbound) == 0 {
+		fmt.Println("  (none)")
+	}
+	printRecordLinks(inbound, "←", false)
+	return nil
+}
@@ -0,0 +1,411 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"demo-builder/common"
+)
+
+// RepeatingTodoIntervalInput mirrors the RepeatingTodoIntervalInput GraphQL input
+type RepeatingTodoIntervalInput struct {
+	Count int      `json:"count"`
+	Type  string   `json:"type"`
+	Days  []string `json:"days,omitempty"`
+	Month string   `json:"month,omitempty"`
+}
+
+// RepeatingTodoEndInput mirrors the RepeatingTodoEndInput GraphQL input
+type RepeatingTodoEndInput struct {
+	Type  string `json:"type"`
+	On    string `json:"on,omitempty"`
+	After int    `json:"after,omitempty"`
+}
+
+// RepeatingTodoInput is shared by createRepeatingTodo and updateRepeatingTodo
+type RepeatingTodoInput struct {
+	TodoID     string                      `json:"todoId"`
+	TodoListID string                      `json:"todoListId"`
+	Type       string                      `json:"type"`
+	Fields     []string                    `json:"fields"`
+	From       string                      `json:"from"`
+	Interval   *RepeatingTodoIntervalInput `json:"interval,omitempty"`
+	End        *RepeatingTodoEndInput      `json:"end,omitempty"`
+}
+
+// RecurrenceRecord holds the record details needed to manage a recurrence
+type RecurrenceRecord struct {
+	ID          string                 `json:"id"`
+	Title       string                 `json:"title"`
+	StartedAt   string                 `json:"startedAt"`
+	DuedAt      string                 `json:"duedAt"`
+	Timezone    string                 `json:"timezone"`
+	IsRepeating bool                   `json:"isRepeating"`
+	Repeating   map[string]interface{} `json:"repeating"`
+	TodoList    common.TodoListInfo    `json:"todoList"`
+}
+
+// Allowed values for the -copy flag mapped to RepeatingTodoAllowedField
+var recurrenceCopyFields = map[string]string{
+	"assignees":     "ASSIGNEES",
+	"tags":          "TAGS",
+	"custom-fields": "CUSTOM_FIELDS",
+	"customfields":  "CUSTOM_FIELDS",
+	"fields":        "CUSTOM_FIELDS",
+	"description":   "DESCRIPTION",
+	"checklists":    "CHECKLISTS",
+	"comments":      "COMMENTS",
+}
+
+// parseRecurrenceEvery converts a friendly expression such as "2 weeks on Mon,Thu"
+// into a repeat type and optional custom interval
+func parseRecurrenceEvery(expr string) (string, *RepeatingTodoIntervalInput, error) {
+	normalized := strings.ToLower(strings.TrimSpace(expr))
+	normalized = strings.TrimPrefix(normalized, "every ")
+	normalized = strings.ReplaceAll(normalized, ",", " ")
+	tokens := strings.Fields(normalized)
+	if len(tokens) == 0 {
+		return "", nil, fmt.Errorf("recurrence expression is empty")
+	}
+
+	if len(tokens) == 1 {
+		switch tokens[0] {
+		case "day", "daily":
+			return "DAILY", nil, nil
+		case "weekday", "weekdays":
+			return "WEEKDAYS", nil, nil
+		case "week", "weekly":
+			return "WEEKLY", nil, nil
+		case "month", "monthly":
+			return "MONTHLY", nil, nil
+		case "year", "yearly", "annually":
+			return "YEARLY", nil, nil
+		}
+	}
+
+	interval := &RepeatingTodoIntervalInput{Count: 1}
+	if count, err := strconv.Atoi(tokens[0]); err == nil {
+		if count < 1 {
+			return "", nil, fmt.Errorf("interval count must be at least 1, got %d", count)
+		}
+		interval.Count = count
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return "", nil, fmt.Errorf("missing unit in '%s' (expected days, weeks, months or years)", expr)
+	}
+
+	switch strings.TrimSuffix(tokens[0], "s") {
+	case "day":
+		interval.Type = "DAYS"
+	case "week":
+		interval.Type = "WEEKS"
+	case "month":
+		interval.Type = "MONTHS"
+	case "year":
+		interval.Type = "YEARS"
+	default:
+		return "", nil, fmt.Errorf("unknown unit '%s' in '%s' (expected days, weeks, months or years)", tokens[0], expr)
+	}
+	tokens = tokens[1:]
+
+	for len(tokens) > 0 {
+		switch tokens[0] {
+		case "on":
+			if interval.Type != "WEEKS" {
+				return "", nil, fmt.Errorf("'on <days>' is only supported for weekly intervals")
+			}
+			tokens = tokens[1:]
+			for len(tokens) > 0 {
+				weekday, ok := common.ParseWeekday(tokens[0])
+				if !ok {
+					break
+				}
+				// RepeatingTodoDayType uses three-letter names such as "Mon"
+				interval.Days = append(interval.Days, weekday.String()[:3])
+				tokens = tokens[1:]
+			}
+			if len(interval.Days) == 0 {
+				return "", nil, fmt.Errorf("expected day names after 'on' in '%s'", expr)
+			}
+		case "by":
+			if interval.Type != "MONTHS" || len(tokens) < 2 {
+				return "", nil, fmt.Errorf("'by date' or 'by weekday' is only supported for monthly intervals")
+			}
+			switch tokens[1] {
+			case "date", "day":
+				interval.Month = "BY_DD"
+			case "weekday":
+				interval.Month = "BY_DDDD"
+			default:
+				return "", nil, fmt.Errorf("unknown monthly mode '%s' (expected 'by date' or 'by weekday')", tokens[1])
+			}
+			tokens = tokens[2:]
+		default:
+			return "", nil, fmt.Errorf("unexpected '%s' in '%s'", tokens[0], expr)
+		}
+	}
+
+	// Plain single intervals map onto the built-in repeat types
+	if interval.Count == 1 && len(interval.Days) == 0 && interval.Month == "" {
+		switch interval.Type {
+		case "DAYS":
+			return "DAILY", nil, nil
+		case "WEEKS":
+			return "WEEKLY", nil, nil
+		case "MONTHS":
+			return "MONTHLY", nil, nil
+		case "YEARS":
+			return "YEARLY", nil, nil
+		}
+	}
+
+	if interval.Type == "MONTHS" && interval.Month == "" {
+		interval.Month = "BY_DD"
+	}
+
+	return "CUSTOM", interval, nil
+}
+
+// parseRecurrenceCopyFields converts the -copy list into RepeatingTodoAllowedField values
+func parseRecurrenceCopyFields(copyStr string) ([]string, error) {
+	fields := []string{}
+	if strings.TrimSpace(copyStr) == "" {
+		return fields, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(copyStr, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || name == "title" {
+			// The title is always carried over to the next occurrence
+			continue
+		}
+		field, ok := recurrenceCopyFields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown copy field '%s'. Valid options: title, assignees, tags, custom-fields, description, checklists, comments", name)
+		}
+		if !seen[field] {
+			fields = append(fields, field)
+			seen[field] = true
+		}
+	}
+
+	return fields, nil
+}
+
+// describeRecurrence renders a repeat type and interval as a readable phrase
+func describeRecurrence(repeatType string, interval *RepeatingTodoIntervalInput) string {
+	switch repeatType {
+	case "DAILY":
+		return "every day"
+	case "WEEKDAYS":
+		return "every weekday (Mon-Fri)"
+	case "WEEKLY":
+		return "every week"
+	case "MONTHLY":
+		return "every month"
+	case "YEARLY":
+		return "every year"
+	}
+
+	if interval == nil {
+		return strings.ToLower(repeatType)
+	}
+
+	unit := strings.ToLower(strings.TrimSuffix(interval.Type, "S"))
+	description := fmt.Sprintf("every %d %ss", interval.Count, unit)
+	if interval.Count == 1 {
+		description = "every " + unit
+	}
+	if len(interval.Days) > 0 {
+		description += " on " + strings.Join(interval.Days, ", ")
+	}
+	if interval.Month == "BY_DDDD" {
+		description += " (same weekday of the month)"
+	}
+	return description
+}
+
+// addMonthsClamped adds months while keeping the day inside the target month
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(target.Year(), target.Month(), day, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+}
+
+// nthWeekdayOfMonth returns the nth weekday of the month containing t, falling back to the last one
+func nthWeekdayOfMonth(t time.Time, weekday time.Weekday, n int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	candidate := first.AddDate(0, 0, offset+(n-1)*7)
+	for candidate.Month() != first.Month() {
+		candidate = candidate.AddDate(0, 0, -7)
+	}
+	return candidate
+}
+
+// recurrenceOccurrences computes up to limit occurrence dates later than after, stepping
+// from the start date so the rule keeps its phase and honouring the end condition.
+// Occurrences between from and after still count towards an "after N times" end.
+func recurrenceOccurrences(repeatType string, interval *RepeatingTodoIntervalInput, from, after time.Time, end *RepeatingTodoEndInput, limit int) []time.Time {
+	var until time.Time
+	maxCount := 0
+	if end != nil {
+		switch end.Type {
+		case "ON":
+			if parsed, err := time.Parse(time.RFC3339, end.On); err == nil {
+				until = parsed
+			}
+		case "AFTER":
+			maxCount = end.After
+		}
+	}
+
+	var occurrences []time.Time
+	count := 0
+	emit := func(t time.Time) bool {
+		if !until.IsZero() && t.After(until) {
+			return false
+		}
+		count++
+		if maxCount > 0 && count > maxCount {
+			return false
+		}
+		if t.After(after) {
+			occurrences = append(occurrences, t)
+		}
+		return len(occurrences) < limit
+	}
+
+	if limit <= 0 {
+		return occurrences
+	}
+
+	// Safety cap so malformed rules can never loop forever; high enough to
+	// step a daily rule started decades ago up to today
+	const maxIterations = 50000
+
+	switch {
+	case repeatType == "DAILY":
+		for i := 1; i <= maxIterations && emit(from.AddDate(0, 0, i)); i++ {
+		}
+	case repeatType == "WEEKDAYS":
+		for i := 1; i <= maxIterations; i++ {
+			next := from.AddDate(0, 0, i)
+			if next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+				continue
+			}
+			if !emit(next) {
+				break
+			}
+		}
+	case repeatType == "WEEKLY":
+		for i := 1; i <= maxIterations && emit(from.AddDate(0, 0, 7*i)); i++ {
+		}
+	case repeatType == "MONTHLY":
+		for i := 1; i <= maxIterations && emit(addMonthsClamped(from, i)); i++ {
+		}
+	case repeatType == "YEARLY":
+		for i := 1; i <= maxIterations && emit(addMonthsClamped(from, 12*i)); i++ {
+		}
+	case interval == nil:
+		return occurrences
+	case interval.Type == "DAYS":
+		for i := 1; i <= maxIterations && emit(from.AddDate(0, 0, i*interval.Count)); i++ {
+		}
+	case interval.Type == "WEEKS" && len(interval.Days) > 0:
+		selected := make(map[time.Weekday]bool)
+		for _, day := range interval.Days {
+			weekday, _ := common.ParseWeekday(day)
+			selected[weekday] = true
+		}
+		firstWeek := common.StartOfWeek(from, time.Sunday)
+		for i := 1; i <= maxIterations*7; i++ {
+			next := from.AddDate(0, 0, i)
+			weeks := int(common.StartOfWeek(next, time.Sunday).Sub(firstWeek).Hours()+12) / (24 * 7)
+			if weeks%interval.Count != 0 || !selected[next.Weekday()] {
+				continue
+			}
+			if !emit(next) {
+				break
+			}
+		}
+	case interval.Type == "WEEKS":
+		for i := 1; i <= maxIterations && emit(from.AddDate(0, 0, 7*i*interval.Count)); i++ {
+		}
+	case interval.Type == "MONTHS" && interval.Month == "BY_DDDD":
+		n := (from.Day()-1)/7 + 1
+		for i := 1; i <= maxIterations; i++ {
+			month := addMonthsClamped(time.Date(from.Year(), from.Month(), 1, from.Hour(), from.Minute(), from.Second(), 0, from.Location()), i*interval.Count)
+			if !emit(nthWeekdayOfMonth(month, from.Weekday(), n)) {
+				break
+			}
+		}
+	case interval.Type == "MONTHS":
+		for i := 1; i <= maxIterations && emit(addMonthsClamped(from, i*interval.Count)); i++ {
+		}
+	case interval.Type == "YEARS":
+		for i := 1; i <= maxIterations && emit(addMonthsClamped(from, 12*i*interval.Count)); i++ {
+		}
+	}
+
+	return occurrences
+}
+
+// fetchRecurrenceRecord loads the record fields needed to create or inspect a recurrence
+func fetchRecurrenceRecord(client *common.Client, recordID string) (*RecurrenceRecord, error) {
+	query := `
+		query GetRecordRecurrence($id: String!) {
+			todo(id: $id) {
+				id
+				title
+				startedAt
+				duedAt
+				timezone
+				isRepeating
+				repeating
+				todoList {
+					id
+					uid
+					title
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": recordID,
+	}
+
+	var response struct {
+		Todo RecurrenceRecord `json:"todo"`
+	}
+	if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Todo.ID == "" {
+		return nil, fmt.Errorf("record not found: %s", recordID)
+	}
+
+	return &response.Todo, nil
+}
+
+// printOccurrencePreview prints the upcoming occurrence dates
+func printOccurrencePreview(occurrences []time.Time) {
+	if len(occurrences) == 0 {
+		fmt.Println("  (no upcoming occurrences)")
+		return
+	}
+	for i, occurrence := range occurrences {
+		fmt.Printf("  %d. %s\n", i+1, occurrence.Format("Mon 2006-01-02 15:04 MST"))
+	}
+}
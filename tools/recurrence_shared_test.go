@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceEvery(t *testing.T) {
+	tests := []struct {
+		expr       string
+		repeatType string
+		interval   *RepeatingTodoIntervalInput
+	}{
+		{"daily", "DAILY", nil},
+		{"every weekday", "WEEKDAYS", nil},
+		{"week", "WEEKLY", nil},
+		{"annually", "YEARLY", nil},
+		{"1 month", "MONTHLY", nil},
+		{"every 1 weeks", "WEEKLY", nil},
+		{"3 days", "CUSTOM", &RepeatingTodoIntervalInput{Count: 3, Type: "DAYS"}},
+		{"2 weeks on Mon,Thu", "CUSTOM", &RepeatingTodoIntervalInput{Count: 2, Type: "WEEKS", Days: []string{"Mon", "Thu"}}},
+		{"week on monday friday", "CUSTOM", &RepeatingTodoIntervalInput{Count: 1, Type: "WEEKS", Days: []string{"Mon", "Fri"}}},
+		{"2 months", "CUSTOM", &RepeatingTodoIntervalInput{Count: 2, Type: "MONTHS", Month: "BY_DD"}},
+		{"month by weekday", "CUSTOM", &RepeatingTodoIntervalInput{Count: 1, Type: "MONTHS", Month: "BY_DDDD"}},
+		{"Every 2 Years", "CUSTOM", &RepeatingTodoIntervalInput{Count: 2, Type: "YEARS"}},
+	}
+	for _, tt := range tests {
+		repeatType, interval, err := parseRecurrenceEvery(tt.expr)
+		if err != nil {
+			t.Errorf("parseRecurrenceEvery(%q): unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if repeatType != tt.repeatType || !reflect.DeepEqual(interval, tt.interval) {
+			t.Errorf("parseRecurrenceEvery(%q) = %s, %+v; want %s, %+v", tt.expr, repeatType, interval, tt.repeatType, tt.interval)
+		}
+	}
+
+	for _, expr := range []string{"", "0 days", "3", "2 fortnights", "2 days on Mon", "week on", "2 weeks by date", "month by hour", "2 weeks soon"} {
+		if _, _, err := parseRecurrenceEvery(expr); err == nil {
+			t.Errorf("parseRecurrenceEvery(%q): want an error", expr)
+		}
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 0, 0, 0, time.UTC)
+	}
+	// Friday
+	from := day(2025, 1, 31)
+
+	tests := []struct {
+		name       string
+		repeatType string
+		interval   *RepeatingTodoIntervalInput
+		after      time.Time
+		end        *RepeatingTodoEndInput
+		limit      int
+		want       []time.Time
+	}{
+		{
+			name: "daily", repeatType: "DAILY", after: from, limit: 3,
+			want: []time.Time{day(2025, 2, 1), day(2025, 2, 2), day(2025, 2, 3)},
+		},
+		{
+			name: "weekdays skip the weekend", repeatType: "WEEKDAYS", after: from, limit: 2,
+			want: []time.Time{day(2025, 2, 3), day(2025, 2, 4)},
+		},
+		{
+			name: "monthly clamps to the month end", repeatType: "MONTHLY", after: from, limit: 3,
+			want: []time.Time{day(2025, 2, 28), day(2025, 3, 31), day(2025, 4, 30)},
+		},
+		{
+			name: "every 2 weeks on Mon and Thu", repeatType: "CUSTOM", after: from, limit: 4,
+			interval: &RepeatingTodoIntervalInput{Count: 2, Type: "WEEKS", Days: []string{"Mon", "Thu"}},
+			want:     []time.Time{day(2025, 2, 10), day(2025, 2, 13), day(2025, 2, 24), day(2025, 2, 27)},
+		},
+		{
+			name: "monthly by weekday keeps the fifth Friday or the last one", repeatType: "CUSTOM", after: from, limit: 2,
+			interval: &RepeatingTodoIntervalInput{Count: 1, Type: "MONTHS", Month: "BY_DDDD"},
+			want:     []time.Time{day(2025, 2, 28), day(2025, 3, 28)},
+		},
+		{
+			name: "only occurrences after the cut-off", repeatType: "WEEKLY", after: day(2025, 2, 20), limit: 2,
+			want: []time.Time{day(2025, 2, 21), day(2025, 2, 28)},
+		},
+		{
+			name: "past occurrences count towards an AFTER end", repeatType: "WEEKLY", after: day(2025, 2, 20), limit: 5,
+			end:  &RepeatingTodoEndInput{Type: "AFTER", After: 3},
+			want: []time.Time{day(2025, 2, 21)},
+		},
+		{
+			name: "an ON end stops the series", repeatType: "YEARLY", after: from, limit: 5,
+			end:  &RepeatingTodoEndInput{Type: "ON", On: "2027-06-01T00:00:00Z"},
+			want: []time.Time{day(2026, 1, 31), day(2027, 1, 31)},
+		},
+		{
+			name: "no limit", repeatType: "DAILY", after: from, limit: 0,
+		},
+	}
+	for _, tt := range tests {
+		got := recurrenceOccurrences(tt.repeatType, tt.interval, from, tt.after, tt.end, tt.limit)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// ReferencedRecord is a record that a REFERENCE field can point to
+type ReferencedRecord struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	TodoListTitle string `json:"todoListTitle"`
+	Project       *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// ReferenceField is a REFERENCE custom field together with the project it points to
+type ReferenceField struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	ReferenceMultiple bool   `json:"referenceMultiple"`
+	Project           struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+	ReferenceProject *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"referenceProject"`
+}
+
+// lookupTargets maps the built-in lookup targets to their CustomFieldLookupType
+var lookupTargets = map[string]string{
+	"due date":    "TODO_DUE_DATE",
+	"duedate":     "TODO_DUE_DATE",
+	"created at":  "TODO_CREATED_AT",
+	"createdat":   "TODO_CREATED_AT",
+	"updated at":  "TODO_UPDATED_AT",
+	"updatedat":   "TODO_UPDATED_AT",
+	"tags":        "TODO_TAG",
+	"assignees":   "TODO_ASSIGNEE",
+	"description": "TODO_DESCRIPTION",
+	"list":        "TODO_LIST",
+}
+
+// referenceCandidatesPageSize is how many reference candidates are requested per page
+const referenceCandidatesPageSize = 100
+
+// fetchReferenceCandidates returns every record a REFERENCE field can point to whose title matches search
+func fetchReferenceCandidates(client *common.Client, fieldID, search string) ([]ReferencedRecord, error) {
+	query := `
+		query CustomFieldReferenceTodos($filter: CustomFieldReferenceTodosFilterInput!, $skip: Int, $take: Int) {
+			customFieldReferenceTodos(filter: $filter, skip: $skip, take: $take) {
+				items {
+					id
+					title
+					todoListTitle
+					project {
+						id
+						name
+					}
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}
+	`
+
+	filter := map[string]interface{}{"customFieldId": fieldID}
+	if search != "" {
+		filter["search"] = search
+	}
+
+	var candidates []ReferencedRecord
+	for skip := 0; ; skip += referenceCandidatesPageSize {
+		var response struct {
+			CustomFieldReferenceTodos struct {
+				Items    []ReferencedRecord `json:"items"`
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"customFieldReferenceTodos"`
+		}
+		variables := map[string]interface{}{"filter": filter, "skip": skip, "take": referenceCandidatesPageSize}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		page := response.CustomFieldReferenceTodos
+		candidates = append(candidates, page.Items...)
+		if !page.PageInfo.HasNextPage || len(page.Items) == 0 {
+			return candidates, nil
+		}
+	}
+}
+
+// fetchRecordTitle returns the title of a record, or "" if there is no record with this ID
+func fetchRecordTitle(client *common.Client, recordID string) (string, error) {
+	query := `
+		query RecordTitle($id: String!) {
+			todo(id: $id) {
+				id
+				title
+			}
+		}
+	`
+
+	var response struct {
+		Todo *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"todo"`
+	}
+	if err := client.ExecuteQueryWithResult(query, map[string]interface{}{"id": recordID}, &response); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return "", nil
+		}
+		return "", err
+	}
+	if response.Todo == nil {
+		return "", nil
+	}
+	return response.Todo.Title, nil
+}
+
+// isReferenceCandidateID reports whether the record with this ID is one a REFERENCE field can point to.
+// The candidates are searched by the record's title, so only records with that title are scanned.
+func isReferenceCandidateID(client *common.Client, fieldID, recordID, title string) (bool, error) {
+	candidates, err := fetchReferenceCandidates(client, fieldID, title)
+	if err != nil {
+		return false, err
+	}
+	for _, candidate := range candidates {
+		if candidate.ID == recordID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resolveReferenceRecord resolves a record title or ID to the ID of a record the field can point to
+func resolveReferenceRecord(client *common.Client, field *common.CustomField, ref string) (string, error) {
+	candidates, err := fetchReferenceCandidates(client, field.ID, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to search records for '%s': %v", field.Name, err)
+	}
+
+	var matches []ReferencedRecord
+	for _, candidate := range candidates {
+		if candidate.ID == ref {
+			return candidate.ID, nil
+		}
+		if strings.EqualFold(strings.TrimSpace(candidate.Title), ref) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0].ID, nil
+	case len(matches) > 1:
+		var lists []string
+		for _, match := range matches {
+			lists = append(lists, fmt.Sprintf("%s in '%s'", match.ID, match.TodoListTitle))
+		}
+		return "", fmt.Errorf("'%s': %d records are titled '%s' (%s); use a record ID", field.Name, len(matches), ref, strings.Join(lists, ", "))
+	case strings.ContainsAny(ref, " \t"):
+		return "", fmt.Errorf("'%s': no record titled '%s' found", field.Name, ref)
+	}
+
+	// Not a known title; accept it only as the ID of a record the field can point to
+	title, err := fetchRecordTitle(client, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up record '%s': %v", ref, err)
+	}
+	if title != "" {
+		candidate, err := isReferenceCandidateID(client, field.ID, ref, title)
+		if err != nil {
+			return "", fmt.Errorf("failed to search records for '%s': %v", field.Name, err)
+		}
+		if candidate {
+			return ref, nil
+		}
+		return "", fmt.Errorf("'%s': record %s ('%s') is not in the project the field refers to", field.Name, ref, title)
+	}
+	return "", fmt.Errorf("'%s': no record titled or with ID '%s' found", field.Name, ref)
+}
+
+// resolveReferenceValues replaces record titles in REFERENCE values with record IDs
+func resolveReferenceValues(client *common.Client, fields []common.CustomField, values []common.CustomFieldValue) ([]common.CustomFieldValue, error) {
+	resolved := make([]common.CustomFieldValue, len(values))
+	for i, value := range values {
+		resolved[i] = value
+		field := findCustomField(fields, value.CustomFieldID)
+		if field == nil || field.Type != "REFERENCE" {
+			continue
+		}
+
+		ids := []string{}
+		for _, ref := range splitCustomFieldList(customFieldValueStrings(value.Value)) {
+			id, err := resolveReferenceRecord(client, field, ref)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		resolved[i].Value = ids
+	}
+	return resolved, nil
+}
+
+// fetchReferenceFields returns every REFERENCE field in the company with the project it points to
+func fetchReferenceFields(client *common.Client) ([]ReferenceField, error) {
+	query := `
+		query ReferenceFields($filter: CustomFieldsFilterInput!, $skip: Int, $take: Int) {
+			customFieldQueries {
+				customFields(filter: $filter, skip: $skip, take: $take) {
+					items {
+						id
+						name
+						referenceMultiple
+						project {
+							id
+							name
+						}
+						referenceProject {
+							id
+							name
+						}
+					}
+					pageInfo {
+						hasNextPage
+					}
+				}
+			}
+		}
+	`
+
+	var fields []ReferenceField
+	for skip := 0; ; skip += 100 {
+		variables := map[string]interface{}{
+			"filter": map[string]interface{}{
+				"companyIds": []string{client.GetCompanyID()},
+				"types":      []string{"REFERENCE"},
+			},
+			"skip": skip,
+			"take": 100,
+		}
+		var response struct {
+			CustomFieldQueries struct {
+				CustomFields struct {
+					Items    []ReferenceField `json:"items"`
+					PageInfo struct {
+						HasNextPage bool `json:"hasNextPage"`
+					} `json:"pageInfo"`
+				} `json:"customFields"`
+			} `json:"customFieldQueries"`
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		page := response.CustomFieldQueries.CustomFields
+		fields = append(fields, page.Items...)
+		if !page.PageInfo.HasNextPage || len(page.Items) == 0 {
+			break
+		}
+	}
+	return fields, nil
+}
+
+// buildLookupOption resolves a "Reference Field.Target" expression to a lookup option.
+// The target is a field of the referenced project or one of the built-in record
+// properties (due date, created at, updated at, tags, assignees, description, list).
+func buildLookupOption(client *common.Client, projectID, expression string) (*CustomFieldLookupOptionInput, string, error) {
+	if !strings.Contains(strings.Trim(expression, "."), ".") {
+		return nil, "", fmt.Errorf("invalid lookup '%s' (expected \"Reference Field.Target Field\")", expression)
+	}
+
+	fields, err := fetchProjectCustomFields(client, projectID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch custom fields: %v", err)
+	}
+	refField, targetName, err := splitLookupExpression(fields, expression)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if lookupType, ok := lookupTargets[strings.ToLower(targetName)]; ok {
+		return &CustomFieldLookupOptionInput{ReferenceID: refField.ID, LookupType: lookupType}, refField.Name + "." + targetName, nil
+	}
+
+	referenceFields, err := fetchReferenceFields(client)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch reference fields: %v", err)
+	}
+	var referencedProjectID string
+	for _, field := range referenceFields {
+		if field.ID == refField.ID && field.ReferenceProject != nil {
+			referencedProjectID = field.ReferenceProject.ID
+		}
+	}
+	if referencedProjectID == "" {
+		return nil, "", fmt.Errorf("'%s' does not point to a project", refField.Name)
+	}
+
+	targets, err := fetchProjectCustomFields(client, referencedProjectID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch custom fields of the referenced project: %v", err)
+	}
+	target := findCustomField(targets, targetName)
+	if target == nil {
+		return nil, "", fmt.Errorf("'%s' is neither a field of the referenced project nor a record property", targetName)
+	}
+	return &CustomFieldLookupOptionInput{ReferenceID: refField.ID, LookupID: target.ID, LookupType: "TODO_CUSTOM_FIELD"}, refField.Name + "." + target.Name, nil
+}
+
+// splitLookupExpression splits "Reference Field.Target" at the dot that follows the
+// name of a REFERENCE field, so either name may itself contain dots. When several
+// splits match, the longest reference field name wins.
+func splitLookupExpression(fields []common.CustomField, expression string) (*common.CustomField, string, error) {
+	var refField, other *common.CustomField
+	var targetName string
+	for i := range expression {
+		if expression[i] != '.' {
+			continue
+		}
+		refName := strings.TrimSpace(expression[:i])
+		target := strings.TrimSpace(expression[i+1:])
+		if refName == "" || target == "" {
+			continue
+		}
+		field := findCustomField(fields, refName)
+		switch {
+		case field == nil:
+		case field.Type == "REFERENCE":
+			refField, targetName = field, target
+		case other == nil:
+			other = field
+		}
+	}
+	if refField != nil {
+		return refField, targetName, nil
+	}
+	if other != nil {
+		return nil, "", fmt.Errorf("'%s' is a %s field; lookups need a REFERENCE field", other.Name, other.Type)
+	}
+	dot := strings.Index(expression, ".")
+	if dot <= 0 || dot == len(expression)-1 {
+		return nil, "", fmt.Errorf("invalid lookup '%s' (expected \"Reference Field.Target Field\")", expression)
+	}
+	return nil, "", fmt.Errorf("reference field '%s' not found in the project", strings.TrimSpace(expression[:dot]))
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"testing"
+
+	"demo-builder/common"
+)
+
+func TestSplitLookupExpression(t *testing.T) {
+	fields := []common.CustomField{
+		{ID: "f1", Name: "Client", Type: "REFERENCE"},
+		{ID: "f2", Name: "Acme Inc. Account", Type: "REFERENCE"},
+		{ID: "f3", Name: "v1.2", Type: "REFERENCE"},
+		{ID: "f4", Name: "v1.2.3", Type: "REFERENCE"},
+		{ID: "f5", Name: "Budget", Type: "CURRENCY"},
+	}
+
+	tests := []struct {
+		expression string
+		refID      string
+		target     string
+	}{
+		{"Client.Due Date", "f1", "Due Date"},
+		{" client . Budget ", "f1", "Budget"},
+		{"Client.Rev. 2 Total", "f1", "Rev. 2 Total"},
+		{"Acme Inc. Account.Owner", "f2", "Owner"},
+		{"v1.2.Status", "f3", "Status"},
+		{"v1.2.3.Status", "f4", "Status"},
+		{"f1.tags", "f1", "tags"},
+	}
+	for _, tt := range tests {
+		refField, target, err := splitLookupExpression(fields, tt.expression)
+		if err != nil {
+			t.Errorf("splitLookupExpression(%q): unexpected error: %v", tt.expression, err)
+			continue
+		}
+		if refField.ID != tt.refID || target != tt.target {
+			t.Errorf("splitLookupExpression(%q) = %s, %q; want %s, %q", tt.expression, refField.ID, target, tt.refID, tt.target)
+		}
+	}
+
+	errors := []struct {
+		expression string
+		want       string
+	}{
+		{"Client", `invalid lookup 'Client' (expected "Reference Field.Target Field")`},
+		{"Client.", `invalid lookup 'Client.' (expected "Reference Field.Target Field")`},
+		{"Budget.Amount", "'Budget' is a CURRENCY field; lookups need a REFERENCE field"},
+		{"Vendor.Name", "reference field 'Vendor' not found in the project"},
+	}
+	for _, tt := range errors {
+		_, _, err := splitLookupExpression(fields, tt.expression)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("splitLookupExpression(%q) error = %v; want %q", tt.expression, err, tt.want)
+		}
+	}
+}
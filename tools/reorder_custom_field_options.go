@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// RunReorderCustomFieldOptions sets the display order of a select field's options
+func RunReorderCustomFieldOptions(args []string) error {
+	fs := flag.NewFlagSet("reorder-custom-field-options", flag.ExitOnError)
+	fieldRef := fs.String("field", "", "Custom field ID, or name with -project (required)")
+	projectID := fs.String("project", "", "Project ID or slug (needed to find the field by name)")
+	order := fs.String("order", "", "Comma-separated option titles or IDs in the new order; unlisted options keep their order after them")
+	alphabetical := fs.Bool("alphabetical", false, "Sort the options by title instead of using -order")
+	dryRun := fs.Bool("dry-run", false, "Show the new order without changing anything")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *fieldRef == "" || (*order == "" && !*alphabetical) {
+		fmt.Println("Usage: go run . reorder-custom-field-options -field FIELD (-order \"A,B,C\" | -alphabetical) [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Put the pipeline stages in order")
+		fmt.Println("  go run . reorder-custom-field-options -field FIELD_ID -order \"Lead,Qualified,Proposal,Won,Lost\"")
+		fmt.Println("")
+		fmt.Println("  # Sort the options of a field found by name")
+		fmt.Println("  go run . reorder-custom-field-options -project PROJECT_ID -field \"Industry\" -alphabetical -dry-run")
+		return fmt.Errorf("required flags missing")
+	}
+	if *order != "" && *alphabetical {
+		return fmt.Errorf("use either -order or -alphabetical, not both")
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+	if *projectID != "" {
+		client.SetProject(*projectID)
+	}
+
+	field, err := fetchOptionField(client, *fieldRef, *projectID)
+	if err != nil {
+		return err
+	}
+
+	// Listed options come first, followed by the rest in their current order
+	var ordered []PositionedFieldOption
+	listed := make(map[string]bool)
+	if *alphabetical {
+		ordered = append(ordered, field.Options...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return strings.ToLower(ordered[i].Title) < strings.ToLower(ordered[j].Title)
+		})
+	} else {
+		for _, ref := range strings.Split(*order, ",") {
+			if strings.TrimSpace(ref) == "" {
+				continue
+			}
+			option, err := findFieldOption(field, ref)
+			if err != nil {
+				return err
+			}
+			if listed[option.ID] {
+				return fmt.Errorf("option '%s' is listed more than once", option.Title)
+			}
+			listed[option.ID] = true
+			ordered = append(ordered, *option)
+		}
+		for _, option := range field.Options {
+			if !listed[option.ID] {
+				ordered = append(ordered, option)
+			}
+		}
+	}
+
+	var moved int
+	for i, option := range ordered {
+		if field.Options[i].ID != option.ID {
+			moved++
+		}
+	}
+	if !*simple {
+		fmt.Printf("=== Option order of '%s' ===\n", field.Name)
+		for i, option := range ordered {
+			marker := " "
+			if field.Options[i].ID != option.ID {
+				marker = "~"
+			}
+			fmt.Printf("%s %d. %s\n", marker, i+1, option.Title)
+		}
+		fmt.Println()
+	}
+	if moved == 0 {
+		fmt.Println("✅ Options are already in this order")
+		return nil
+	}
+	if *dryRun {
+		fmt.Printf("Dry run: %d option(s) would move\n", moved)
+		return nil
+	}
+
+	// Renumber every option so the order no longer depends on the old positions
+	for i, option := range ordered {
+		position := float64(i+1) * 65535
+		if option.Position == position {
+			continue
+		}
+		input := EditCustomFieldOptionInput{CustomFieldID: field.ID, OptionID: option.ID, Position: &position}
+		if _, err := executeEditCustomFieldOption(client, input); err != nil {
+			return fmt.Errorf("failed to move option '%s': %v", option.Title, err)
+		}
+	}
+
+	if *simple {
+		fmt.Printf("✅ Reordered %d options of %s\n", len(ordered), field.ID)
+	} else {
+		fmt.Printf("✅ Reordered the options of '%s' (%d moved)\n", field.Name, moved)
+	}
+	return nil
+}
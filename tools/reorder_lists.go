@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// RunReorderLists puts the lists of a project in the given order. Lists not
+// named in -order keep their relative order after the named ones.
+func RunReorderLists(args []string) error {
+	fs := flag.NewFlagSet("reorder-lists", flag.ExitOnError)
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	order := fs.String("order", "", "Comma-separated list titles (or IDs) in the desired order (required)")
+	dryRun := fs.Bool("dry-run", false, "Show the new order without changing anything")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *projectID == "" || *order == "" {
+		fmt.Println("Usage: go run . reorder-lists -project PROJECT -order \"Backlog,Doing,Review,Done\" [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . reorder-lists -project my-project -order \"Backlog,Doing,Review,Done\"")
+		fmt.Println("  go run . reorder-lists -project my-project -order \"Done,Backlog\" -dry-run")
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	lists, err := fetchProjectLists(client, *projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch lists: %v", err)
+	}
+
+	var ordered []common.TodoList
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(*order, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		list := findListByIDOrTitle(lists, value)
+		if list == nil {
+			return fmt.Errorf("list '%s' not found in project %s", value, *projectID)
+		}
+		if seen[list.ID] {
+			return fmt.Errorf("list '%s' is listed more than once", value)
+		}
+		seen[list.ID] = true
+		ordered = append(ordered, *list)
+	}
+	var rest []string
+	for _, list := range lists {
+		if !seen[list.ID] {
+			ordered = append(ordered, list)
+			rest = append(rest, list.Title)
+		}
+	}
+
+	// Standard increment is 65535.0 as per Blue's implementation
+	increment := 65535.0
+	moved := 0
+	for i, list := range ordered {
+		position := float64(i+1) * increment
+		if list.Position == position {
+			if !*simple {
+				fmt.Printf("  %d. %s\n", i+1, list.Title)
+			}
+			continue
+		}
+		if !*simple {
+			fmt.Printf("  %d. %s (%.0f → %.0f)\n", i+1, list.Title, list.Position, position)
+		}
+		moved++
+		if *dryRun {
+			continue
+		}
+		if _, err := executeUpdateTodoList(client, UpdateTodoListInput{TodoListID: list.ID, Position: &position}); err != nil {
+			return fmt.Errorf("failed to move list '%s': %v", list.Title, err)
+		}
+	}
+
+	switch {
+	case *simple:
+		fmt.Println(moved)
+	case *dryRun:
+		fmt.Printf("\nDry run: %d list(s) would move\n", moved)
+	default:
+		fmt.Printf("\n✅ Lists reordered, %d moved\n", moved)
+	}
+	if len(rest) > 0 && !*simple {
+		fmt.Printf("⚠️  Not in -order, kept at the end: %s\n", strings.Join(rest, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// PositionedProject is a project with its position and folder
+type PositionedProject struct {
+	ID       string  `json:"id"`
+	Slug     string  `json:"slug"`
+	Name     string  `json:"name"`
+	Position float64 `json:"position"`
+	Folder   *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"folder"`
+}
+
+// fetchPositionedProjects returns the active, non-template projects of a company sorted by position
+func fetchPositionedProjects(client *common.Client, companyID string) ([]PositionedProject, error) {
+	query := `
+		query PositionedProjects($filter: ProjectListFilter!, $skip: Int, $take: Int) {
+			projectList(filter: $filter, skip: $skip, take: $take, sort: [position_ASC]) {
+				items {
+					id
+					slug
+					name
+					position
+					folder {
+						id
+						title
+					}
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}
+	`
+
+	var projects []PositionedProject
+	for skip := 0; ; skip += 100 {
+		variables := map[string]interface{}{
+			"filter": map[string]interface{}{
+				"companyIds": []string{companyID},
+				"archived":   false,
+				"isTemplate": false,
+			},
+			"skip": skip,
+			"take": 100,
+		}
+
+		var response struct {
+			ProjectList struct {
+				Items    []PositionedProject `json:"items"`
+				PageInfo struct {
+					HasNextPage bool `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"projectList"`
+		}
+		if err := client.ExecuteQueryWithResult(query, variables, &response); err != nil {
+			return nil, err
+		}
+		projects = append(projects, response.ProjectList.Items...)
+		if !response.ProjectList.PageInfo.HasNextPage {
+			break
+		}
+	}
+	return projects, nil
+}
+
+// findPositionedProject looks up a project by ID, slug or case-insensitive name
+func findPositionedProject(projects []PositionedProject, value string) *PositionedProject {
+	for i := range projects {
+		if projects[i].ID == value || projects[i].Slug == value {
+			return &projects[i]
+		}
+	}
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, value) {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// executeRepositionProjects asks the API to spread project positions evenly
+func executeRepositionProjects(client *common.Client) error {
+	mutation := `
+		mutation RepositionProjects {
+			repositionProjects {
+				id
+			}
+		}
+	`
+
+	var response struct {
+		RepositionProjects []struct {
+			ID string `json:"id"`
+		} `json:"repositionProjects"`
+	}
+	return client.ExecuteQueryWithResult(mutation, nil, &response)
+}
+
+// executeUpdateProjectPosition moves a project to a new position, keeping it in its folder
+func executeUpdateProjectPosition(client *common.Client, projectID string, position float64, folderID string) error {
+	mutation := `
+		mutation UpdateProjectPosition($projectId: String!, $position: Float!, $folderId: String) {
+			updateProjectPosition(projectId: $projectId, position: $position, folderId: $folderId) {
+				id
+				position
+			}
+		}
+	`
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"position":  position,
+	}
+	if folderID != "" {
+		variables["folderId"] = folderID
+	}
+
+	var response struct {
+		UpdateProjectPosition struct {
+			ID       string  `json:"id"`
+			Position float64 `json:"position"`
+		} `json:"updateProjectPosition"`
+	}
+	return client.ExecuteQueryWithResult(mutation, variables, &response)
+}
+
+// RunReorderProjects puts projects in the given order. The listed projects keep
+// the position slots they already occupy, so unlisted projects do not move.
+func RunReorderProjects(args []string) error {
+	fs := flag.NewFlagSet("reorder-projects", flag.ExitOnError)
+	order := fs.String("order", "", "Comma-separated project slugs (or IDs/names) in the desired order (required)")
+	normalize := fs.Bool("normalize", false, "Spread all project positions evenly before reordering")
+	dryRun := fs.Bool("dry-run", false, "Show the new order without changing anything")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *order == "" {
+		fmt.Println("Usage: go run . reorder-projects -order \"slug-a,slug-b,slug-c\" [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  go run . reorder-projects -order \"sales-pipeline,onboarding,support\"")
+		fmt.Println("  go run . reorder-projects -order \"sales-pipeline,onboarding\" -dry-run")
+		return fmt.Errorf("required flags missing")
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+	client := common.NewClient(config)
+
+	if *normalize && !*dryRun {
+		if err := executeRepositionProjects(client); err != nil {
+			return fmt.Errorf("failed to normalize project positions: %v", err)
+		}
+	}
+
+	projects, err := fetchPositionedProjects(client, client.GetCompanyID())
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %v", err)
+	}
+
+	var ordered []*PositionedProject
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(*order, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		project := findPositionedProject(projects, value)
+		if project == nil {
+			return fmt.Errorf("project '%s' not found (archived projects and templates cannot be reordered)", value)
+		}
+		if seen[project.ID] {
+			return fmt.Errorf("project '%s' is listed more than once", value)
+		}
+		seen[project.ID] = true
+		ordered = append(ordered, project)
+	}
+	if len(ordered) < 2 {
+		return fmt.Errorf("at least two projects are needed to reorder")
+	}
+
+	// Reuse the slots the listed projects already occupy
+	slots := make([]float64, len(ordered))
+	for i, project := range ordered {
+		slots[i] = project.Position
+	}
+	sort.Float64s(slots)
+	for i := 1; i < len(slots); i++ {
+		if slots[i] == slots[i-1] {
+			if *dryRun {
+				return fmt.Errorf("some projects share a position; run without -dry-run and with -normalize to fix them")
+			}
+			return fmt.Errorf("some projects share a position; re-run with -normalize")
+		}
+	}
+
+	moved := 0
+	for i, project := range ordered {
+		if project.Position == slots[i] {
+			if !*simple {
+				fmt.Printf("  %d. %s\n", i+1, project.Name)
+			}
+			continue
+		}
+		if !*simple {
+			fmt.Printf("  %d. %s (%.0f → %.0f)\n", i+1, project.Name, project.Position, slots[i])
+		}
+		if *dryRun {
+			moved++
+			continue
+		}
+		folderID := ""
+		if project.Folder != nil {
+			folderID = project.Folder.ID
+		}
+		if err := executeUpdateProjectPosition(client, project.ID, slots[i], folderID); err != nil {
+			return fmt.Errorf("failed to move project '%s': %v", project.Name, err)
+		}
+		moved++
+	}
+
+	switch {
+	case *simple:
+		fmt.Println(moved)
+	case *dryRun:
+		fmt.Printf("\nDry run: %d project(s) would move\n", moved)
+	default:
+		fmt.Printf("\n✅ Reordered %d project(s), %d moved\n", len(ordered), moved)
+	}
+	return nil
+}
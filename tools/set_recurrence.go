@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"demo-builder/common"
+)
+
+// executeSetRecurrence creates or updates the repeat rule of a record
+func executeSetRecurrence(client *common.Client, input RepeatingTodoInput, update bool) error {
+	mutation := `
+		mutation CreateRepeatingTodo($input: CreateRepeatingTodoInput!) {
+			createRepeatingTodo(input: $input)
+		}
+	`
+	resultKey := "createRepeatingTodo"
+	if update {
+		mutation = `
+			mutation UpdateRepeatingTodo($input: UpdateRepeatingTodoInput!) {
+				updateRepeatingTodo(input: $input)
+			}
+		`
+		resultKey = "updateRepeatingTodo"
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response map[string]bool
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return err
+	}
+
+	if !response[resultKey] {
+		return fmt.Errorf("%s returned false", resultKey)
+	}
+
+	return nil
+}
+
+// RunSetRecurrence makes a record repeat on a schedule
+func RunSetRecurrence(args []string) error {
+	fs := flag.NewFlagSet("set-recurrence", flag.ExitOnError)
+	recordID := fs.String("record", "", "Record ID to make repeating (required)")
+	projectID := fs.String("project", "", "Project ID or slug (required)")
+	every := fs.String("every", "", "Recurrence, e.g. \"day\", \"weekdays\", \"2 weeks on Mon,Thu\", \"3 months by weekday\" (required)")
+	from := fs.String("from", "", "First occurrence date, ISO-8601 or relative like \"next monday 9:00\" (default: record due date, start date, or now)")
+	until := fs.String("until", "", "Stop repeating after this date, ISO-8601 or relative like \"end of year\"")
+	count := fs.Int("count", 0, "Stop repeating after this many occurrences")
+	copyFields := fs.String("copy", "", "Comma-separated parts to copy: title, assignees, tags, custom-fields, description, checklists, comments")
+	preview := fs.Int("preview", 5, "Number of upcoming occurrences to preview")
+	dryRun := fs.Bool("dry-run", false, "Preview occurrences without applying the recurrence")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *recordID == "" || *projectID == "" || *every == "" {
+		fmt.Println("Error: -record, -project and -every flags are required")
+		fmt.Println("\nUsage:")
+		fmt.Println("  go run . set-recurrence -record RECORD_ID -project PROJECT_ID -every EXPRESSION [flags]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Repeat every weekday")
+		fmt.Println("  go run . set-recurrence -record rec123 -project proj456 -every weekdays")
+		fmt.Println("")
+		fmt.Println("  # Every other week on Monday until mid 2027, copying assignees and checklists")
+		fmt.Println("  go run . set-recurrence -record rec123 -project proj456 -every \"2 weeks on Mon\" -until 2027-06-30 -copy title,assignees,checklists")
+		fmt.Println("")
+		fmt.Println("  # Preview the next 10 occurrences only")
+		fmt.Println("  go run . set-recurrence -record rec123 -project proj456 -every \"month\" -preview 10 -dry-run")
+		return fmt.Errorf("required flags missing")
+	}
+
+	if *until != "" && *count > 0 {
+		return fmt.Errorf("use either -until or -count, not both")
+	}
+
+	repeatType, interval, err := parseRecurrenceEvery(*every)
+	if err != nil {
+		return fmt.Errorf("invalid -every value: %v", err)
+	}
+
+	fields, err := parseRecurrenceCopyFields(*copyFields)
+	if err != nil {
+		return err
+	}
+
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	client := common.NewClient(config)
+	client.SetProject(*projectID)
+
+	record, err := fetchRecurrenceRecord(client, *recordID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record: %v", err)
+	}
+
+	loc := common.LoadTimezone(record.Timezone)
+
+	// Work out the anchor date for the schedule
+	var fromTime time.Time
+	switch {
+	case *from != "":
+		fromTime, err = common.ParseDate(*from, record.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid -from value: %v", err)
+		}
+	case record.DuedAt != "":
+		fromTime, err = time.Parse(time.RFC3339, record.DuedAt)
+	case record.StartedAt != "":
+		fromTime, err = time.Parse(time.RFC3339, record.StartedAt)
+	default:
+		fromTime = time.Now()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read record dates: %v", err)
+	}
+	fromTime = fromTime.In(loc)
+
+	end := &RepeatingTodoEndInput{Type: "NEVER"}
+	if *until != "" {
+		untilTime, err := common.ParseDateRangeEnd(*until, record.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid -until value: %v", err)
+		}
+		if untilTime.Before(fromTime) {
+			return fmt.Errorf("-until (%s) is before the first occurrence (%s)", untilTime.Format("2006-01-02"), fromTime.Format("2006-01-02"))
+		}
+		end = &RepeatingTodoEndInput{Type: "ON", On: common.FormatAPIDate(untilTime)}
+	} else if *count > 0 {
+		end = &RepeatingTodoEndInput{Type: "AFTER", After: *count}
+	}
+
+	input := RepeatingTodoInput{
+		TodoID:     record.ID,
+		TodoListID: record.TodoList.ID,
+		Type:       repeatType,
+		Fields:     fields,
+		From:       common.FormatAPIDate(fromTime),
+		Interval:   interval,
+		End:        end,
+	}
+
+	// Only preview occurrences that are still ahead, even when the start date is in the past
+	after := time.Now().In(loc)
+	if fromTime.After(after) {
+		after = fromTime
+	}
+	occurrences := recurrenceOccurrences(repeatType, interval, fromTime, after, end, *preview)
+
+	if !*simple || *dryRun {
+		fmt.Printf("=== Recurrence for '%s' ===\n", record.Title)
+		fmt.Printf("Schedule: %s\n", describeRecurrence(repeatType, interval))
+		fmt.Printf("From: %s\n", fromTime.Format("Mon 2006-01-02 15:04 MST"))
+		switch end.Type {
+		case "ON":
+			fmt.Printf("Until: %s\n", *until)
+		case "AFTER":
+			fmt.Printf("Ends after: %d occurrence(s)\n", end.After)
+		default:
+			fmt.Printf("Ends: never\n")
+		}
+		if len(fields) > 0 {
+			fmt.Printf("Copies: %v\n", fields)
+		}
+		if *preview > 0 {
+			fmt.Printf("\nNext %d occurrence(s):\n", len(occurrences))
+			printOccurrencePreview(occurrences)
+		}
+		fmt.Println()
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run - no changes applied.")
+		return nil
+	}
+
+	if err := executeSetRecurrence(client, input, record.IsRepeating); err != nil {
+		return fmt.Errorf("failed to set recurrence: %v", err)
+	}
+
+	if *simple {
+		fmt.Printf("Recurrence set on record %s (%s)\n", record.ID, describeRecurrence(repeatType, interval))
+	} else if record.IsRepeating {
+		fmt.Printf("✅ Recurrence updated successfully!\n")
+	} else {
+		fmt.Printf("✅ Recurrence created successfully!\n")
+	}
+
+	return nil
+}
@@ -273,7 +273,7 @@ func RunUpdateAutomation(args []string) error {
 	
 	// Action options
 	actionType := fs.String("action-type", "", "Update action type")
-	actionDueIn := fs.Int("action-due-in", -1, "Due in days for action (-1 to keep current)")
+	actionDueIn := DaysFlag(fs, "action-due-in", -1, "Due in days for action, e.g. 3, 3d, 2w (-1 to keep current)")
 	actionColor := fs.String("action-color", "", "Action color")
 	actionTodoList := fs.String("action-todo-list", "", "Todo list ID for action")
 	actionTags := fs.String("action-tags", "", "Comma-separated tag IDs")
@@ -32,7 +32,7 @@ func RunUpdateAutomationMulti(args []string) error {
 	actionTodoList := fs.String("action-todo-list", "", "Action todo list ID (same as action1-todo-list)")
 	actionTags := fs.String("action-tags", "", "Action comma-separated tag IDs (same as action1-tags)")
 	actionAssignees := fs.String("action-assignees", "", "Action comma-separated assignee IDs (same as action1-assignees)")
-	actionDueIn := fs.Int("action-due-in", -1, "Due in days for action (-1 to keep current)")
+	actionDueIn := DaysFlag(fs, "action-due-in", -1, "Due in days for action, e.g. 3, 3d, 2w (-1 to keep current)")
 	
 	// Numbered action flags
 	action1Type := fs.String("action1-type", "", "First action type")
@@ -40,21 +40,21 @@ func RunUpdateAutomationMulti(args []string) error {
 	action1TodoList := fs.String("action1-todo-list", "", "First action todo list ID")
 	action1Tags := fs.String("action1-tags", "", "First action comma-separated tag IDs")
 	action1Assignees := fs.String("action1-assignees", "", "First action comma-separated assignee IDs")
-	action1DueIn := fs.Int("action1-due-in", -1, "First action due in days (-1 to keep current)")
+	action1DueIn := DaysFlag(fs, "action1-due-in", -1, "First action due in days, e.g. 3, 3d, 2w (-1 to keep current)")
 	
 	action2Type := fs.String("action2-type", "", "Second action type")
 	action2Color := fs.String("action2-color", "", "Second action color") 
 	action2TodoList := fs.String("action2-todo-list", "", "Second action todo list ID")
 	action2Tags := fs.String("action2-tags", "", "Second action comma-separated tag IDs")
 	action2Assignees := fs.String("action2-assignees", "", "Second action comma-separated assignee IDs")
-	action2DueIn := fs.Int("action2-due-in", -1, "Second action due in days (-1 to keep current)")
+	action2DueIn := DaysFlag(fs, "action2-due-in", -1, "Second action due in days, e.g. 3, 3d, 2w (-1 to keep current)")
 	
 	action3Type := fs.String("action3-type", "", "Third action type")
 	action3Color := fs.String("action3-color", "", "Third action color")
 	action3TodoList := fs.String("action3-todo-list", "", "Third action todo list ID")
 	action3Tags := fs.String("action3-tags", "", "Third action comma-separated tag IDs")
 	action3Assignees := fs.String("action3-assignees", "", "Third action comma-separated assignee IDs")
-	action3DueIn := fs.Int("action3-due-in", -1, "Third action due in days (-1 to keep current)")
+	action3DueIn := DaysFlag(fs, "action3-due-in", -1, "Third action due in days, e.g. 3, 3d, 2w (-1 to keep current)")
 	
 	// Per-action email options
 	// Unnumbered email for single action convenience  
@@ -60,6 +60,54 @@ func executeUpdateChecklistItem(client *Client, input EditChecklistItemInput) (*
 	return &response.EditChecklistItem, nil
 }
 
+// UpdateChecklistItemDueDateInput represents the input for setting checklist item dates
+type UpdateChecklistItemDueDateInput struct {
+	ChecklistItemID string  `json:"checklistItemId"`
+	StartedAt       *string `json:"startedAt,omitempty"`
+	DuedAt          *string `json:"duedAt,omitempty"`
+}
+
+// UpdateChecklistItemDueDateResponse represents the response from updating checklist item dates
+type UpdateChecklistItemDueDateResponse struct {
+	UpdateChecklistItemDueDate ChecklistItem `json:"updateChecklistItemDueDate"`
+}
+
+// Execute GraphQL mutation to set the start/due dates of a checklist item
+func executeUpdateChecklistItemDueDate(client *Client, input UpdateChecklistItemDueDateInput) (*ChecklistItem, error) {
+	mutation := `
+		mutation UpdateChecklistItemDueDate($input: UpdateChecklistItemDueDateInput!) {
+			updateChecklistItemDueDate(input: $input) {
+				id
+				uid
+				title
+				position
+				done
+				startedAt
+				duedAt
+				createdAt
+				updatedAt
+				createdBy {
+					id
+					uid
+					fullName
+					email
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response UpdateChecklistItemDueDateResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.UpdateChecklistItemDueDate, nil
+}
+
 // RunUpdateChecklistItem handles the update-checklist-item command
 func RunUpdateChecklistItem(args []string) error {
 	// Define flags
@@ -69,6 +117,9 @@ func RunUpdateChecklistItem(args []string) error {
 	position := fs.Float64("position", -1, "New position for the checklist item")
 	checklistID := fs.String("move-to-checklist", "", "Move item to a different checklist (checklist ID)")
 	done := fs.String("done", "", "Mark item as done (true/false)")
+	startDate := fs.String("start-date", "", "Start date: "+DateExamples)
+	dueDate := fs.String("due-date", "", "Due date: "+DateExamples)
+	timezone := fs.String("timezone", "", "Timezone for relative dates, e.g. Europe/London (default: local timezone)")
 	projectID := fs.String("project", "", "Project ID or slug (optional - for context)")
 	simple := fs.Bool("simple", false, "Show simple output")
 
@@ -82,8 +133,30 @@ func RunUpdateChecklistItem(args []string) error {
 	}
 
 	// Check if at least one update field is provided
-	if *title == "" && *position == -1 && *checklistID == "" && *done == "" {
-		return fmt.Errorf("at least one field to update must be provided (title, position, move-to-checklist, or done)")
+	editProvided := *title != "" || *position != -1 || *checklistID != "" || *done != ""
+	datesProvided := *startDate != "" || *dueDate != ""
+	if !editProvided && !datesProvided {
+		return fmt.Errorf("at least one field to update must be provided (title, position, move-to-checklist, done, start-date, or due-date)")
+	}
+
+	// Resolve date expressions before touching the item
+	var dateInput *UpdateChecklistItemDueDateInput
+	if datesProvided {
+		dateInput = &UpdateChecklistItemDueDateInput{ChecklistItemID: *itemID}
+		if *startDate != "" {
+			startedAt, err := ParseDateFlag(*startDate, *timezone)
+			if err != nil {
+				return fmt.Errorf("invalid -start-date: %v", err)
+			}
+			dateInput.StartedAt = &startedAt
+		}
+		if *dueDate != "" {
+			duedAt, err := ParseDateFlag(*dueDate, *timezone)
+			if err != nil {
+				return fmt.Errorf("invalid -due-date: %v", err)
+			}
+			dateInput.DuedAt = &duedAt
+		}
 	}
 
 	// Load config and create client
@@ -142,6 +215,12 @@ func RunUpdateChecklistItem(args []string) error {
 		if *done != "" {
 			fmt.Printf("Done Status: %s\n", *done)
 		}
+		if dateInput != nil && dateInput.StartedAt != nil {
+			fmt.Printf("New Start: %s\n", *dateInput.StartedAt)
+		}
+		if dateInput != nil && dateInput.DuedAt != nil {
+			fmt.Printf("New Due: %s\n", *dateInput.DuedAt)
+		}
 		if *projectID != "" {
 			fmt.Printf("Project: %s\n", *projectID)
 		}
@@ -149,9 +228,18 @@ func RunUpdateChecklistItem(args []string) error {
 	}
 
 	// Execute update
-	item, err := executeUpdateChecklistItem(client, input)
-	if err != nil {
-		return fmt.Errorf("failed to update checklist item: %v", err)
+	var item *ChecklistItem
+	if editProvided {
+		item, err = executeUpdateChecklistItem(client, input)
+		if err != nil {
+			return fmt.Errorf("failed to update checklist item: %v", err)
+		}
+	}
+	if dateInput != nil {
+		item, err = executeUpdateChecklistItemDueDate(client, *dateInput)
+		if err != nil {
+			return fmt.Errorf("failed to update checklist item dates: %v", err)
+		}
 	}
 
 	// Display results
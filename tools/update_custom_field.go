@@ -26,6 +26,43 @@ type UpdateCustomFieldResponse struct {
 	EditCustomField common.CustomField `json:"editCustomField"`
 }
 
+// Execute GraphQL mutation to edit a custom field
+func executeUpdateCustomField(client *common.Client, input UpdateCustomFieldInput) (*common.CustomField, error) {
+	mutation := `
+		mutation EditCustomField($input: EditCustomFieldInput!) {
+			editCustomField(input: $input) {
+				id
+				uid
+				name
+				type
+				description
+				position
+				min
+				max
+				currency
+				prefix
+				updatedAt
+				customFieldOptions {
+					id
+					title
+					color
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var result UpdateCustomFieldResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.EditCustomField, nil
+}
+
 // RunUpdateCustomField executes the update custom field command
 func RunUpdateCustomField(args []string) error {
 	flagSet := flag.NewFlagSet("update-custom-field", flag.ExitOnError)
@@ -119,41 +156,13 @@ func RunUpdateCustomField(args []string) error {
 		input.SequenceStartingNumber = &seq
 	}
 
-	mutation := `
-		mutation EditCustomField($input: EditCustomFieldInput!) {
-			editCustomField(input: $input) {
-				id
-				uid
-				name
-				type
-				description
-				position
-				min
-				max
-				currency
-				prefix
-				updatedAt
-				customFieldOptions {
-					id
-					title
-					color
-				}
-			}
-		}
-	`
-
-	variables := map[string]interface{}{
-		"input": input,
-	}
-
-	var result UpdateCustomFieldResponse
-	err = client.ExecuteQueryWithResult(mutation, variables, &result)
+	updated, err := executeUpdateCustomField(client, input)
 	if err != nil {
 		return fmt.Errorf("failed to execute query: %v", err)
 	}
 
 	// Output results
-	field := result.EditCustomField
+	field := *updated
 	if *simple {
 		fmt.Printf("✅ Updated custom field %s\n", field.ID)
 	} else {
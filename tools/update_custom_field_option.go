@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"demo-builder/common"
+)
+
+// RunUpdateCustomFieldOption renames, recolours or moves a single custom field option
+func RunUpdateCustomFieldOption(args []string) error {
+	fs := flag.NewFlagSet("update-custom-field-option", flag.ExitOnError)
+	fieldRef := fs.String("field", "", "Custom field ID, or name with -project (required)")
+	projectID := fs.String("project", "", "Project ID or slug (needed to find the field by name)")
+	optionRef := fs.String("option", "", "Option ID or title to update (required)")
+	title := fs.String("title", "", "New option title")
+	color := fs.String("color", "", "New option colour (name like 'red' or hex code)")
+	position := fs.String("position", "", "New position: a number, or 'first', 'last', 'before:<option>' or 'after:<option>'")
+	simple := fs.Bool("simple", false, "Simple output format")
+	fs.Parse(args)
+
+	if *fieldRef == "" || *optionRef == "" || (*title == "" && *color == "" && *position == "") {
+		fmt.Println("Usage: go run . update-custom-field-option -field FIELD -option OPTION [-title TITLE] [-color COLOR] [-position POSITION]")
+		fmt.Println("\nFlags:")
+		fs.PrintDefaults()
+		fmt.Println("\nExamples:")
+		fmt.Println("  # Rename an option")
+		fmt.Println("  go run . update-custom-field-option -field FIELD_ID -option \"Hot\" -title \"Hot lead\"")
+		fmt.Println("")
+		fmt.Println("  # Recolour an option, finding the field by name")
+		fmt.Println("  go run . update-custom-field-option -project PROJECT_ID -field \"Stage\" -option \"Won\" -color green")
+		fmt.Println("")
+		fmt.Println("  # Move an option before another one")
+		fmt.Println("  go run . update-custom-field-option -field FIELD_ID -option \"Lost\" -position \"before:Won\"")
+		return fmt.Errorf("required flags missing")
+	}
+
+	// Load configuration
+	config, err := common.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	// Create client
+	client := common.NewClient(config)
+	if *projectID != "" {
+		client.SetProject(*projectID)
+	}
+
+	field, err := fetchOptionField(client, *fieldRef, *projectID)
+	if err != nil {
+		return err
+	}
+	option, err := findFieldOption(field, *optionRef)
+	if err != nil {
+		return err
+	}
+
+	input := EditCustomFieldOptionInput{
+		CustomFieldID: field.ID,
+		OptionID:      option.ID,
+		Title:         *title,
+		Color:         *color,
+	}
+	if *position != "" {
+		newPosition, err := resolveOptionPosition(field, option, *position)
+		if err != nil {
+			return err
+		}
+		input.Position = &newPosition
+	}
+
+	updated, err := executeEditCustomFieldOption(client, input)
+	if err != nil {
+		return fmt.Errorf("failed to update option: %v", err)
+	}
+
+	if *simple {
+		fmt.Printf("%s\t%s\t%s\t%.0f\n", updated.ID, updated.Title, updated.Color, updated.Position)
+		return nil
+	}
+	fmt.Printf("✅ Updated option of '%s'\n", field.Name)
+	if *title != "" {
+		fmt.Printf("Title: %s → %s\n", option.Title, updated.Title)
+	}
+	if *color != "" {
+		fmt.Printf("Color: %s → %s\n", orNone(option.Color), updated.Color)
+	}
+	if *position != "" {
+		fmt.Printf("Position: %.0f → %.0f\n", option.Position, updated.Position)
+	}
+	fmt.Printf("ID: %s\n", updated.ID)
+	return nil
+}
+
+// resolveOptionPosition turns a position flag into a position between the field's other options
+func resolveOptionPosition(field *OptionField, option *PositionedFieldOption, value string) (float64, error) {
+	var others []PositionedFieldOption
+	for _, other := range field.Options {
+		if other.ID != option.ID {
+			others = append(others, other)
+		}
+	}
+
+	var index int
+	beforeRef, before := strings.CutPrefix(value, "before:")
+	afterRef, after := strings.CutPrefix(value, "after:")
+	switch {
+	case value == "first":
+		index = 0
+	case value == "last":
+		index = len(others)
+	case before || after:
+		ref := afterRef
+		if before {
+			ref = beforeRef
+		}
+		anchor, err := findFieldOption(field, ref)
+		if err != nil {
+			return 0, err
+		}
+		if anchor.ID == option.ID {
+			return 0, fmt.Errorf("an option can't be positioned relative to itself")
+		}
+		for i, other := range others {
+			if other.ID == anchor.ID {
+				index = i
+				if after {
+					index = i + 1
+				}
+			}
+		}
+	default:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid position '%s' (use a number, first, last, before:<option> or after:<option>)", value)
+		}
+		return number, nil
+	}
+
+	// Place the option halfway between its new neighbours
+	switch {
+	case len(others) == 0:
+		return 65535, nil
+	case index == 0:
+		return others[0].Position / 2, nil
+	case index == len(others):
+		return others[len(others)-1].Position + 65535, nil
+	default:
+		return (others[index-1].Position + others[index].Position) / 2, nil
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"flag"
 	"fmt"
 	"strconv"
-	"strings"
 )
 
 // Update list input
@@ -29,6 +28,31 @@ type UpdateTodoListResponse struct {
 	EditTodoList EditedTodoList `json:"editTodoList"`
 }
 
+// Execute GraphQL mutation to edit a list
+func executeUpdateTodoList(client *common.Client, input UpdateTodoListInput) (*EditedTodoList, error) {
+	mutation := `
+		mutation EditTodoList($input: EditTodoListInput!) {
+			editTodoList(input: $input) {
+				id
+				uid
+				title
+				position
+				isLocked
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response UpdateTodoListResponse
+	if err := client.ExecuteQueryWithResult(mutation, variables, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.EditTodoList, nil
+}
+
 func RunUpdateList(args []string) error {
 	fs := flag.NewFlagSet("update-list", flag.ExitOnError)
 	listID := fs.String("list", "", "List ID (required)")
@@ -63,11 +87,10 @@ func RunUpdateList(args []string) error {
 		client.SetProjectID(*projectID)
 	}
 
-	// Build the mutation fields dynamically
-	var fields []string
-
-	if *title != "" {
-		fields = append(fields, fmt.Sprintf("title: \"%s\"", *title))
+	// Build the update input
+	input := UpdateTodoListInput{
+		TodoListID: *listID,
+		Title:      *title,
 	}
 
 	if *positionStr != "" {
@@ -75,7 +98,7 @@ func RunUpdateList(args []string) error {
 		if err != nil {
 			return fmt.Errorf("invalid position value '%s': %v", *positionStr, err)
 		}
-		fields = append(fields, fmt.Sprintf("position: %g", position))
+		input.Position = &position
 	}
 
 	if *lockedStr != "" {
@@ -83,37 +106,15 @@ func RunUpdateList(args []string) error {
 		if err != nil {
 			return fmt.Errorf("invalid locked value '%s': %v", *lockedStr, err)
 		}
-		fields = append(fields, fmt.Sprintf("isLocked: %t", locked))
-	}
-
-	// Build the fields string
-	fieldsStr := ""
-	if len(fields) > 0 {
-		fieldsStr = strings.Join(fields, "\n\t\t\t\t")
+		input.IsLocked = &locked
 	}
 
-	// Execute mutation using string interpolation like other working commands
-	mutation := fmt.Sprintf(`
-		mutation EditTodoList {
-			editTodoList(input: {
-				todoListId: "%s"
-				%s
-			}) {
-				id
-				uid
-				title
-				position
-				isLocked
-			}
-		}`, *listID, fieldsStr)
-
-	var response UpdateTodoListResponse
-	if err := client.ExecuteQueryWithResult(mutation, nil, &response); err != nil {
+	list, err := executeUpdateTodoList(client, input)
+	if err != nil {
 		return fmt.Errorf("failed to edit list: %v. Note: Try providing -project flag for proper authorization", err)
 	}
 
 	// Display results
-	list := response.EditTodoList
 	if *simple {
 		fmt.Printf("List updated: %s (ID: %s)\n", list.Title, list.ID)
 	} else {
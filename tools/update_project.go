@@ -97,6 +97,28 @@ func getCurrentProject(client *common.Client, projectID string) (*EditedProject,
 	return &response.Project, nil
 }
 
+// projectFeatureTypes returns the feature types a project supports. The schema
+// declares ProjectFeature.type as a plain String rather than an enum, so the
+// types the API reports for the project are authoritative; featureTypes only
+// fills in types the API leaves out (they default to enabled).
+func projectFeatureTypes(existingFeatures []common.ProjectFeature) []string {
+	var types []string
+	seen := make(map[string]bool)
+	for _, feature := range existingFeatures {
+		if !seen[feature.Type] {
+			seen[feature.Type] = true
+			types = append(types, feature.Type)
+		}
+	}
+	for _, featureType := range featureTypes {
+		if !seen[featureType] {
+			seen[featureType] = true
+			types = append(types, featureType)
+		}
+	}
+	return types
+}
+
 // Merge existing features with user-specified changes
 func mergeFeatures(existingFeatures []common.ProjectFeature, newFeatures []common.ProjectFeatureInput) []common.ProjectFeatureInput {
 	// Create a map of all possible feature types with default enabled=true
@@ -115,9 +137,10 @@ func mergeFeatures(existingFeatures []common.ProjectFeature, newFeatures []commo
 		featureMap[feature.Type] = feature.Enabled
 	}
 
-	// Convert back to array with all feature types
+	// Convert back to array with all feature types, keeping any the API reports
+	// that are not in featureTypes so they are not dropped
 	var result []common.ProjectFeatureInput
-	for _, featureType := range featureTypes {
+	for _, featureType := range projectFeatureTypes(existingFeatures) {
 		result = append(result, common.ProjectFeatureInput{
 			Type:    featureType,
 			Enabled: featureMap[featureType],
@@ -1,7 +1,6 @@
 package tools
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"strconv"
@@ -66,59 +65,6 @@ type SetTagsResponse struct {
 }
 
 
-// parseCustomFieldValues parses the custom field values from command line arguments
-func parseUpdateCustomFieldValues(customFieldsStr string) ([]common.CustomFieldValue, error) {
-	if customFieldsStr == "" {
-		return nil, nil
-	}
-
-	var customFieldValues []common.CustomFieldValue
-
-	// Split by semicolon for multiple custom fields
-	fieldPairs := strings.Split(customFieldsStr, ";")
-
-	for _, pair := range fieldPairs {
-		// Split by colon for field_id:value
-		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid custom field format: %s (expected field_id:value)", pair)
-		}
-
-		fieldID := strings.TrimSpace(parts[0])
-		valueStr := strings.TrimSpace(parts[1])
-
-		// Try to parse as different types
-		var value interface{}
-		
-		// Try boolean first
-		if valueStr == "true" || valueStr == "false" {
-			value, _ = strconv.ParseBool(valueStr)
-		} else if floatVal, err := strconv.ParseFloat(valueStr, 64); err == nil {
-			// Try as number
-			value = floatVal
-		} else if strings.HasPrefix(valueStr, "[") && strings.HasSuffix(valueStr, "]") {
-			// Try as array
-			var arr []string
-			if err := json.Unmarshal([]byte(valueStr), &arr); err == nil {
-				value = arr
-			} else {
-				value = valueStr // fallback to string
-			}
-		} else {
-			// Default to string
-			value = valueStr
-		}
-
-		customFieldValues = append(customFieldValues, common.CustomFieldValue{
-			CustomFieldID: fieldID,
-			Value:         value,
-		})
-	}
-
-	return customFieldValues, nil
-}
-
-
 // executeEditTodo performs the main record update
 func executeEditTodo(client *common.Client, input UpdateRecordInput) (*UpdateRecordResponse, error) {
 	// Build optional fields
@@ -298,6 +244,35 @@ func getProjectIDFromRecord(client *common.Client, todoID string) (string, error
 	return response.Todo.TodoList.Project.ID, nil
 }
 
+// getRecordTimezone returns the timezone stored on a record (empty if unset)
+func getRecordTimezone(client *common.Client, todoID string) (string, error) {
+	query := fmt.Sprintf(`
+		query GetTodoTimezone {
+			todo(id: "%s") {
+				id
+				timezone
+			}
+		}
+	`, todoID)
+
+	var response struct {
+		Todo struct {
+			ID       string `json:"id"`
+			Timezone string `json:"timezone"`
+		} `json:"todo"`
+	}
+
+	if err := client.ExecuteQueryWithResult(query, nil, &response); err != nil {
+		return "", fmt.Errorf("failed to get record timezone: %v", err)
+	}
+
+	if response.Todo.ID == "" {
+		return "", fmt.Errorf("record not found: %s", todoID)
+	}
+
+	return response.Todo.Timezone, nil
+}
+
 func RunUpdateRecord(args []string) error {
 	fs := flag.NewFlagSet("update-record", flag.ExitOnError)
 	
@@ -315,8 +290,9 @@ func RunUpdateRecord(args []string) error {
 	listID := fs.String("list", "", "Move to different list ID")
 	
 	// Date fields
-	startDate := fs.String("start-date", "", "Start date (ISO format)")
-	dueDate := fs.String("due-date", "", "Due date (ISO format)")
+	startDate := fs.String("start-date", "", "Start date: "+common.DateExamples)
+	dueDate := fs.String("due-date", "", "Due date: "+common.DateExamples)
+	timezone := fs.String("timezone", "", "Timezone for relative dates (default: the record's timezone)")
 	
 	// Visual fields
 	color := fs.String("color", "", "Record color")
@@ -328,9 +304,10 @@ func RunUpdateRecord(args []string) error {
 	tagTitles := fs.String("tag-titles", "", "Comma-separated tag titles")
 	
 	// Custom fields
-	customFields := fs.String("custom-fields", "", "Custom field values (format: field_id1:value1;field_id2:value2)")
+	customFields := fs.String("custom-fields", "", "Custom field values (format: field1:value1;field2:value2, fields by ID or name)")
 	
 	// Options
+	validateOnly := fs.Bool("validate-only", false, "Check the custom field values and report every problem without changing the record")
 	simple := fs.Bool("simple", false, "Simple output format")
 
 	fs.Parse(args)
@@ -350,9 +327,14 @@ func RunUpdateRecord(args []string) error {
 		fmt.Println("")
 		fmt.Println("  # Update custom fields (requires project ID)")
 		fmt.Println("  go run . update-record -record rec123 -project proj456 -custom-fields \"cf123:High Priority;cf456:42.5;cf789:true\"")
+		fmt.Println("  # Custom field values are checked against each field's type:")
+		printCustomFieldValueFormats()
 		fmt.Println("")
 		fmt.Println("  # Move to different list with new due date")
 		fmt.Println("  go run . update-record -record rec123 -list list456 -due-date \"2024-12-31T23:59:59Z\"")
+		fmt.Println("")
+		fmt.Println("  # Relative dates are resolved in the record's timezone")
+		fmt.Println("  go run . update-record -record rec123 -start-date today -due-date \"next friday 17:00\"")
 		return fmt.Errorf("required flags missing")
 	}
 
@@ -378,6 +360,24 @@ func RunUpdateRecord(args []string) error {
 		}
 	}
 
+	// Resolve date expressions in the record's timezone
+	var startedAt, duedAt string
+	if *startDate != "" || *dueDate != "" {
+		tz := *timezone
+		if tz == "" {
+			tz, err = getRecordTimezone(client, *todoID)
+			if err != nil {
+				return err
+			}
+		}
+		if startedAt, err = common.ParseDateFlag(*startDate, tz); err != nil {
+			return fmt.Errorf("invalid -start-date: %v", err)
+		}
+		if duedAt, err = common.ParseDateFlag(*dueDate, tz); err != nil {
+			return fmt.Errorf("invalid -due-date: %v", err)
+		}
+	}
+
 	// Parse assignees
 	var assigneeIds []string
 	if *assignees != "" {
@@ -405,13 +405,35 @@ func RunUpdateRecord(args []string) error {
 		}
 	}
 
-	// Parse custom fields
+	// Parse custom fields and check them against the field types of the record's project
 	var customFieldValues []common.CustomFieldValue
 	if *customFields != "" {
-		customFieldValues, err = parseUpdateCustomFieldValues(*customFields)
+		customFieldValues, err = parseCustomFieldValues(*customFields)
 		if err != nil {
 			return fmt.Errorf("failed to parse custom fields: %v", err)
 		}
+		recordProjectID, err := getProjectIDFromRecord(client, *todoID)
+		if err != nil {
+			return err
+		}
+		fields, err := fetchProjectCustomFields(client, recordProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch custom fields: %v", err)
+		}
+		// REFERENCE values may name records by title
+		if customFieldValues, err = resolveReferenceValues(client, fields, customFieldValues); err != nil {
+			return err
+		}
+		var problems []string
+		if customFieldValues, problems = validateCustomFieldValues(fields, customFieldValues); len(problems) > 0 {
+			return printValidationProblems(problems, *simple)
+		}
+	}
+	if *validateOnly {
+		if !*simple {
+			fmt.Printf("✅ All %d custom field value(s) are valid; the record was not changed\n", len(customFieldValues))
+		}
+		return nil
 	}
 
 	// Build update input
@@ -422,8 +444,8 @@ func RunUpdateRecord(args []string) error {
 		Title:        *title,
 		HTML:         *htmlContent,
 		Text:         *description,
-		StartedAt:    *startDate,
-		DuedAt:       *dueDate,
+		StartedAt:    startedAt,
+		DuedAt:       duedAt,
 		Color:        *color,
 		Cover:        *cover,
 		AssigneeIds:  assigneeIds,